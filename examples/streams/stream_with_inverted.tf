@@ -34,22 +34,22 @@ resource "graylog_stream" "filtered" {
   description  = "Include WARN/ERROR but exclude healthchecks"
   index_set_id = graylog_index_set.main.id
 
-  # type is an integer Graylog enum; values vary across versions
+  # match: exact | regex | greater | smaller | field_presence | contains | always_match | match_input
   rule {
     field = "level"
-    type  = 1           # equals
+    match = "exact"
     value = "ERROR"
   }
 
   rule {
     field = "level"
-    type  = 1           # equals
+    match = "exact"
     value = "WARN"
   }
 
   rule {
     field    = "message"
-    type     = 3        # regex
+    match    = "regex"
     value    = ".*healthcheck.*"
     inverted = true     # exclude matches
   }
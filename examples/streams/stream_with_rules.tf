@@ -33,17 +33,16 @@ resource "graylog_stream" "errors_timeouts" {
   description  = "Only ERROR level and messages containing 'timeout'"
   index_set_id = graylog_index_set.main.id
 
-  # type is Graylog enum (integer); typical values:
-  # 1 = match exact; 3 = regex; values may differ by version
+  # match: exact | regex | greater | smaller | field_presence | contains | always_match | match_input
   rule {
     field = "level"
-    type  = 1
+    match = "exact"
     value = "ERROR"
   }
 
   rule {
     field = "message"
-    type  = 3
+    match = "regex"
     value = ".*timeout.*"
     description = "Contains 'timeout'"
   }
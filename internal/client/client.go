@@ -13,6 +13,7 @@ import (
 	"math"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -925,6 +926,41 @@ func (c *Client) UpdateStream(id string, s *Stream) (*Stream, error) {
 	return &out, nil
 }
 
+// CloneStream creates a copy of the stream (rules and outputs included) with the given title,
+// description, index set and default-stream setting. Graylog creates clones paused; the clone
+// is resumed unless s.Disabled is set.
+func (c *Client) CloneStream(sourceID string, s *Stream) (*Stream, error) {
+	path := fmt.Sprintf("/api/streams/%s/clone", sourceID)
+	body := map[string]any{
+		"title":                              s.Title,
+		"description":                        s.Description,
+		"index_set_id":                       s.IndexSetID,
+		"remove_matches_from_default_stream": s.RemoveMatchesFromDefaultStream,
+	}
+	resp, err := c.doRequest("POST", path, body)
+	if err != nil {
+		return nil, err
+	}
+	var created struct {
+		StreamID string `json:"stream_id"`
+		ID       string `json:"id"`
+	}
+	_ = json.Unmarshal(resp, &created)
+	id := created.StreamID
+	if id == "" {
+		id = created.ID
+	}
+	if id == "" {
+		return nil, fmt.Errorf("failed to clone stream: unexpected response %s", string(resp))
+	}
+	if !s.Disabled {
+		if _, err := c.doRequest("POST", fmt.Sprintf("/api/streams/%s/resume", id), nil); err != nil {
+			return nil, fmt.Errorf("stream %s cloned but could not be resumed: %w", id, err)
+		}
+	}
+	return c.GetStream(id)
+}
+
 func (c *Client) DeleteStream(id string) error {
 	// Unified path for all supported versions
 	path := fmt.Sprintf("/api/streams/%s", id)
@@ -1012,6 +1048,8 @@ type Input struct {
 	Global        bool                   `json:"global,omitempty"`
 	Node          string                 `json:"node,omitempty"`
 	Configuration map[string]interface{} `json:"configuration,omitempty"`
+	// StaticFields is read-only here: Graylog returns it on GET but manages it via /staticfields
+	StaticFields map[string]string `json:"static_fields,omitempty"`
 }
 
 func (c *Client) CreateInput(in *Input) (*Input, error) {
@@ -1057,6 +1095,182 @@ func (c *Client) DeleteInput(id string) error {
 	return err
 }
 
+// AddInputStaticField sets a static field that Graylog adds to every message received by the input.
+func (c *Client) AddInputStaticField(inputID, key, value string) error {
+	// Унифицированный путь для всех версий
+	path := fmt.Sprintf("/api/system/inputs/%s/staticfields", inputID)
+	_, err := c.doRequest("POST", path, map[string]string{"key": key, "value": value})
+	return err
+}
+
+// DeleteInputStaticField removes a static field from the input.
+func (c *Client) DeleteInputStaticField(inputID, key string) error {
+	path := fmt.Sprintf("/api/system/inputs/%s/staticfields/%s", inputID, key)
+	_, err := c.doRequest("DELETE", path, nil)
+	return err
+}
+
+// InputTypeInfo describes an input class and the configuration it requests.
+type InputTypeInfo struct {
+	Type                   string                      `json:"type"`
+	Name                   string                      `json:"name"`
+	IsExclusive            bool                        `json:"is_exclusive"`
+	LinkToDocs             string                      `json:"link_to_docs,omitempty"`
+	RequestedConfiguration map[string]InputConfigField `json:"requested_configuration"`
+}
+
+// InputConfigField is one entry of an input type's requested configuration.
+type InputConfigField struct {
+	Type         string      `json:"type"`
+	HumanName    string      `json:"human_name,omitempty"`
+	Description  string      `json:"description,omitempty"`
+	DefaultValue interface{} `json:"default_value,omitempty"`
+	IsOptional   bool        `json:"is_optional"`
+}
+
+// ListInputTypes returns the available input classes mapped to their display names.
+func (c *Client) ListInputTypes() (map[string]string, error) {
+	// Унифицированный путь для всех версий
+	path := "/api/system/inputs/types"
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var wrapped struct {
+		Types map[string]string `json:"types"`
+	}
+	if err := json.Unmarshal(resp, &wrapped); err != nil || wrapped.Types == nil {
+		return nil, errors.New("unexpected input types response format")
+	}
+	return wrapped.Types, nil
+}
+
+// GetInputType returns the description of a single input class.
+func (c *Client) GetInputType(class string) (*InputTypeInfo, error) {
+	path := fmt.Sprintf("/api/system/inputs/types/%s", class)
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var out InputTypeInfo
+	if err := json.Unmarshal(resp, &out); err != nil {
+		return nil, err
+	}
+	if out.Type == "" {
+		out.Type = class
+	}
+	return &out, nil
+}
+
+// ListInputTypeInfos returns descriptions of all input classes keyed by class.
+// Uses /types/all when available and falls back to one request per type.
+func (c *Client) ListInputTypeInfos() (map[string]InputTypeInfo, error) {
+	resp, err := c.doRequest("GET", "/api/system/inputs/types/all", nil)
+	if err == nil {
+		var out map[string]InputTypeInfo
+		if err := json.Unmarshal(resp, &out); err == nil && len(out) > 0 {
+			for k, v := range out {
+				if v.Type == "" {
+					v.Type = k
+					out[k] = v
+				}
+			}
+			return out, nil
+		}
+	}
+	names, err := c.ListInputTypes()
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]InputTypeInfo, len(names))
+	for class := range names {
+		info, err := c.GetInputType(class)
+		if err != nil {
+			return nil, err
+		}
+		out[class] = *info
+	}
+	return out, nil
+}
+
+// InputState is the runtime state of an input on a node (RUNNING, FAILED, STOPPED, STARTING, ...).
+type InputState struct {
+	ID              string `json:"id"`
+	State           string `json:"state"`
+	StartedAt       string `json:"started_at,omitempty"`
+	DetailedMessage string `json:"detailed_message,omitempty"`
+	MessageInput    *Input `json:"message_input,omitempty"`
+}
+
+// InputID returns the ID of the input this state belongs to.
+func (s InputState) InputID() string {
+	if s.MessageInput != nil && s.MessageInput.ID != "" {
+		return s.MessageInput.ID
+	}
+	return s.ID
+}
+
+// ListInputStates returns the input states of the node serving the API ({"states": [...]} or a raw array).
+func (c *Client) ListInputStates() ([]InputState, error) {
+	// Унифицированный путь для всех версий
+	path := "/api/system/inputstates"
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var wrapped struct {
+		States []InputState `json:"states"`
+	}
+	if err := json.Unmarshal(resp, &wrapped); err == nil && wrapped.States != nil {
+		return wrapped.States, nil
+	}
+	var arr []InputState
+	if err := json.Unmarshal(resp, &arr); err == nil {
+		return arr, nil
+	}
+	return nil, errors.New("unexpected input states response format")
+}
+
+// ListClusterInputStates returns input states of all nodes keyed by node ID.
+// Falls back to the local node (key "local") when the cluster endpoint is unavailable.
+func (c *Client) ListClusterInputStates() (map[string][]InputState, error) {
+	resp, err := c.doRequest("GET", "/api/cluster/inputstates", nil)
+	if err == nil {
+		var out map[string][]InputState
+		if err := json.Unmarshal(resp, &out); err == nil {
+			return out, nil
+		}
+	} else if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	local, err := c.ListInputStates()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]InputState{"local": local}, nil
+}
+
+// StartInput (re)starts the input on all nodes.
+func (c *Client) StartInput(id string) error {
+	return c.setInputState("PUT", id)
+}
+
+// StopInput stops the input on all nodes without deleting it.
+func (c *Client) StopInput(id string) error {
+	return c.setInputState("DELETE", id)
+}
+
+// setInputState uses the cluster-wide input state endpoint, so a global input changes state on every
+// node. Falls back to the node that answers the request when the cluster endpoint is unavailable.
+func (c *Client) setInputState(method, id string) error {
+	_, err := c.doRequest(method, fmt.Sprintf("/api/cluster/inputstates/%s", id), nil)
+	if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	_, err = c.doRequest(method, fmt.Sprintf("/api/system/inputstates/%s", id), nil)
+	return err
+}
+
 // ListInputs returns all inputs. Graylog may return either a wrapped object
 // like {"inputs": [...]} or a raw array; support both.
 func (c *Client) ListInputs() ([]Input, error) {
@@ -1198,6 +1412,180 @@ func (c *Client) UpdateLDAPSettings(s *LDAPSettings) (*LDAPSettings, error) {
 	return &out, nil
 }
 
+// ===== Authentication Services (Graylog 4+) =====
+// Graylog 4.0 replaced /system/ldap/settings with authentication service backends. A backend's
+// config is type specific ("ldap", "active-directory") and kept as a free-form map.
+
+type AuthBackend struct {
+	ID           string                 `json:"id,omitempty"`
+	Title        string                 `json:"title"`
+	Description  string                 `json:"description"`
+	DefaultRoles []string               `json:"default_roles"`
+	Config       map[string]interface{} `json:"config"`
+}
+
+// decodeAuthBackend accepts both {"backend": {...}, "context": {...}} and a bare backend object.
+func decodeAuthBackend(resp []byte) (*AuthBackend, error) {
+	var wrap struct {
+		Backend *AuthBackend `json:"backend"`
+	}
+	if err := json.Unmarshal(resp, &wrap); err == nil && wrap.Backend != nil {
+		return wrap.Backend, nil
+	}
+	var out AuthBackend
+	if err := json.Unmarshal(resp, &out); err != nil {
+		return nil, errors.New("unexpected authentication backend response format")
+	}
+	return &out, nil
+}
+
+func (c *Client) CreateAuthBackend(b *AuthBackend) (*AuthBackend, error) {
+	// Унифицированный путь для всех версий
+	path := "/api/system/authentication/services/backends"
+	resp, err := c.doRequest("POST", path, b)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAuthBackend(resp)
+}
+
+func (c *Client) GetAuthBackend(id string) (*AuthBackend, error) {
+	path := fmt.Sprintf("/api/system/authentication/services/backends/%s", id)
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAuthBackend(resp)
+}
+
+func (c *Client) UpdateAuthBackend(id string, b *AuthBackend) (*AuthBackend, error) {
+	path := fmt.Sprintf("/api/system/authentication/services/backends/%s", id)
+	resp, err := c.doRequest("PUT", path, b)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAuthBackend(resp)
+}
+
+func (c *Client) DeleteAuthBackend(id string) error {
+	path := fmt.Sprintf("/api/system/authentication/services/backends/%s", id)
+	_, err := c.doRequest("DELETE", path, nil)
+	return err
+}
+
+func (c *Client) ListAuthBackends() ([]AuthBackend, error) {
+	path := "/api/system/authentication/services/backends?per_page=500"
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var wrap struct {
+		Backends []AuthBackend `json:"backends"`
+	}
+	if err := json.Unmarshal(resp, &wrap); err == nil && wrap.Backends != nil {
+		return wrap.Backends, nil
+	}
+	var direct []AuthBackend
+	if err := json.Unmarshal(resp, &direct); err == nil && direct != nil {
+		return direct, nil
+	}
+	return nil, errors.New("unexpected authentication backends response format")
+}
+
+// GetActiveAuthBackendID returns the ID of the active authentication backend ("" when none is active).
+func (c *Client) GetActiveAuthBackendID() (string, error) {
+	resp, err := c.doRequest("GET", "/api/system/authentication/services/configuration", nil)
+	if err != nil {
+		return "", err
+	}
+	var out struct {
+		Configuration struct {
+			ActiveBackend *string `json:"active_backend"`
+		} `json:"configuration"`
+	}
+	if err := json.Unmarshal(resp, &out); err != nil {
+		return "", errors.New("unexpected authentication services configuration format")
+	}
+	if out.Configuration.ActiveBackend == nil {
+		return "", nil
+	}
+	return *out.Configuration.ActiveBackend, nil
+}
+
+// SetActiveAuthBackend activates the given backend; an empty id deactivates the active one.
+func (c *Client) SetActiveAuthBackend(id string) error {
+	var active any
+	if id != "" {
+		active = id
+	}
+	_, err := c.doRequest("POST", "/api/system/authentication/services/configuration", map[string]any{"active_backend": active})
+	return err
+}
+
+// AuthBackendTestResult is the outcome of a backend connection or login test. For login tests
+// Result carries user_exists, login_success and user_details.
+type AuthBackendTestResult struct {
+	Success bool                   `json:"success"`
+	Message string                 `json:"message"`
+	Errors  []string               `json:"errors"`
+	Result  map[string]interface{} `json:"result"`
+}
+
+// TestAuthBackendConnection checks that Graylog can connect and bind with the given backend config.
+// With backendID set, Graylog fills secrets that are not part of cfg from the saved backend.
+func (c *Client) TestAuthBackendConnection(backendID string, cfg *AuthBackend) (*AuthBackendTestResult, error) {
+	return c.testAuthBackend("connection", backendID, cfg, nil)
+}
+
+// TestAuthBackendLogin looks up and authenticates a user through the given backend config.
+func (c *Client) TestAuthBackendLogin(backendID string, cfg *AuthBackend, username, password string) (*AuthBackendTestResult, error) {
+	return c.testAuthBackend("login", backendID, cfg, map[string]any{"username": username, "password": password})
+}
+
+func (c *Client) testAuthBackend(kind, backendID string, cfg *AuthBackend, login map[string]any) (*AuthBackendTestResult, error) {
+	path := "/api/system/authentication/services/test/backend/" + kind
+	body := map[string]any{"backend_configuration": cfg, "user_login": login}
+	if backendID != "" {
+		body["backend_id"] = backendID
+	}
+	resp, err := c.doRequest("POST", path, body)
+	if err != nil {
+		return nil, err
+	}
+	var out AuthBackendTestResult
+	if err := json.Unmarshal(resp, &out); err != nil {
+		return nil, errors.New("unexpected authentication backend test response format")
+	}
+	return &out, nil
+}
+
+// ListRoleIDs maps role names to role IDs (/authz/roles); authentication backends reference roles by ID.
+func (c *Client) ListRoleIDs() (map[string]string, error) {
+	resp, err := c.doRequest("GET", "/api/authz/roles?per_page=1000", nil)
+	if err != nil {
+		return nil, err
+	}
+	type roleRef struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	var wrap struct {
+		Roles []roleRef `json:"roles"`
+	}
+	if err := json.Unmarshal(resp, &wrap); err != nil || wrap.Roles == nil {
+		var direct []roleRef
+		if err := json.Unmarshal(resp, &direct); err != nil {
+			return nil, errors.New("unexpected roles response format")
+		}
+		wrap.Roles = direct
+	}
+	out := make(map[string]string, len(wrap.Roles))
+	for _, r := range wrap.Roles {
+		out[r.Name] = r.ID
+	}
+	return out, nil
+}
+
 // ===== Outputs =====
 
 type Output struct {
@@ -1458,6 +1846,25 @@ func (c *Client) DeleteRole(name string) error {
 	return err
 }
 
+// ListRoles returns all roles with their permissions.
+func (c *Client) ListRoles() ([]Role, error) {
+	resp, err := c.doRequest("GET", "/api/roles", nil)
+	if err != nil {
+		return nil, err
+	}
+	var wrap struct {
+		Roles []Role `json:"roles"`
+	}
+	if err := json.Unmarshal(resp, &wrap); err != nil || wrap.Roles == nil {
+		var direct []Role
+		if err := json.Unmarshal(resp, &direct); err != nil {
+			return nil, errors.New("unexpected roles response format")
+		}
+		wrap.Roles = direct
+	}
+	return wrap.Roles, nil
+}
+
 // CreateStreamRule creates a rule for the given stream and returns the created rule (with ID, if provided by API).
 func (c *Client) CreateStreamRule(streamID string, rule *StreamRule) (*StreamRule, error) {
 	// Унифицированный путь для всех версий
@@ -1521,6 +1928,68 @@ func (c *Client) DeleteStreamRule(streamID, ruleID string) error {
 	return err
 }
 
+// GetStreamRule returns a single rule of the given stream.
+func (c *Client) GetStreamRule(streamID, ruleID string) (*StreamRule, error) {
+	// Унифицированный путь для всех версий
+	base := fmt.Sprintf("/api/streams/%s/rules/%s", streamID, ruleID)
+	resp, err := c.doRequest("GET", base, nil)
+	if err != nil {
+		return nil, err
+	}
+	var out StreamRule
+	if err := json.Unmarshal(resp, &out); err != nil {
+		return nil, err
+	}
+	if out.ID == "" {
+		out.ID = ruleID
+	}
+	return &out, nil
+}
+
+// UpdateStreamRule updates a rule in place, keeping its ID.
+func (c *Client) UpdateStreamRule(streamID, ruleID string, rule *StreamRule) (*StreamRule, error) {
+	// Унифицированный путь для всех версий
+	base := fmt.Sprintf("/api/streams/%s/rules/%s", streamID, ruleID)
+	// inverted is sent explicitly so that it can be switched back to false
+	body := map[string]any{
+		"field":       rule.Field,
+		"type":        rule.Type,
+		"value":       rule.Value,
+		"inverted":    rule.Inverted,
+		"description": rule.Description,
+	}
+	if _, err := c.doRequest("PUT", base, body); err != nil {
+		return nil, err
+	}
+	out := *rule
+	out.ID = ruleID
+	return &out, nil
+}
+
+// StreamMatchResult is the response of the stream matching test endpoint.
+type StreamMatchResult struct {
+	Matches bool            `json:"matches"`
+	Rules   map[string]bool `json:"rules"`
+}
+
+// TestStreamMatch runs the stream's rules against a sample message without ingesting it.
+func (c *Client) TestStreamMatch(streamID string, message map[string]any) (*StreamMatchResult, error) {
+	// Унифицированный путь для всех версий
+	base := fmt.Sprintf("/api/streams/%s/testMatch", streamID)
+	resp, err := c.doRequest("POST", base, map[string]any{"message": message})
+	if err != nil {
+		return nil, err
+	}
+	var out StreamMatchResult
+	if err := json.Unmarshal(resp, &out); err != nil {
+		return nil, errors.New("unexpected stream match response format")
+	}
+	if out.Rules == nil {
+		out.Rules = map[string]bool{}
+	}
+	return &out, nil
+}
+
 // ===== Extractors (Inputs) =====
 // We keep extractor payloads as free-form maps to allow full flexibility across Graylog versions.
 
@@ -1575,6 +2044,48 @@ func (c *Client) DeleteInputExtractor(inputID, extractorID string) error {
 	return err
 }
 
+// GetInputExtractor returns a single extractor of the given input.
+func (c *Client) GetInputExtractor(inputID, extractorID string) (map[string]interface{}, error) {
+	// Унифицированный путь для всех версий
+	path := fmt.Sprintf("/api/system/inputs/%s/extractors/%s", inputID, extractorID)
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(resp, &out); err != nil || out == nil {
+		return nil, errors.New("unexpected extractor response format")
+	}
+	return out, nil
+}
+
+// UpdateInputExtractor updates an extractor in place. The payload uses the same shape as create
+// (title, cut_or_copy, source_field, target_field, extractor_type, extractor_config, converters, ...).
+func (c *Client) UpdateInputExtractor(inputID, extractorID string, extractor map[string]interface{}) (map[string]interface{}, error) {
+	path := fmt.Sprintf("/api/system/inputs/%s/extractors/%s", inputID, extractorID)
+	resp, err := c.doRequest("PUT", path, extractor)
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]interface{}
+	_ = json.Unmarshal(resp, &out)
+	if out == nil {
+		out = map[string]interface{}{}
+	}
+	return out, nil
+}
+
+// OrderInputExtractors sets the execution order of the input's extractors to the order of ids.
+func (c *Client) OrderInputExtractors(inputID string, ids []string) error {
+	path := fmt.Sprintf("/api/system/inputs/%s/extractors/order", inputID)
+	order := make(map[string]string, len(ids))
+	for i, id := range ids {
+		order[strconv.Itoa(i)] = id
+	}
+	_, err := c.doRequest("POST", path, map[string]any{"order": order})
+	return err
+}
+
 type IndexSet struct {
 	ID          string `json:"id,omitempty"`
 	Title       string `json:"title"`
@@ -1994,13 +2505,469 @@ func (c *Client) DeletePipeline(id string) error {
 	return err
 }
 
-// ---- Dashboards ----
+// ---- Pipeline Rules ----
 
-type Dashboard struct {
+type PipelineRule struct {
 	ID          string `json:"id,omitempty"`
-	Title       string `json:"title"`
+	Title       string `json:"title,omitempty"`
 	Description string `json:"description,omitempty"`
-}
+	// Rule source in pipeline DSL. Graylog derives the title from the `rule "..."` header.
+	Source     string `json:"source"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	ModifiedAt string `json:"modified_at,omitempty"`
+}
+
+// RuleParseError describes a single pipeline rule parse/validation error reported by Graylog.
+type RuleParseError struct {
+	Type           string `json:"type,omitempty"`
+	Line           int    `json:"line"`
+	PositionInLine int    `json:"position_in_line"`
+	Message        string `json:"message,omitempty"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// Text returns a human readable message for the parse error.
+func (e RuleParseError) Text() string {
+	switch {
+	case e.Message != "":
+		return e.Message
+	case e.Reason != "":
+		return e.Reason
+	case e.Type != "":
+		return e.Type
+	}
+	return "parse error"
+}
+
+// PipelineRuleParseErrors extracts rule parse errors from a Graylog API error.
+// Graylog responds with 400 and either a bare array of errors or an object with an `errors` array.
+func PipelineRuleParseErrors(err error) []RuleParseError {
+	var ge *GraylogError
+	if !errors.As(err, &ge) || ge.Raw == "" {
+		return nil
+	}
+	var list []RuleParseError
+	if json.Unmarshal([]byte(ge.Raw), &list) == nil {
+		return list
+	}
+	var wrapped struct {
+		Errors []RuleParseError `json:"errors"`
+	}
+	if json.Unmarshal([]byte(ge.Raw), &wrapped) == nil {
+		return wrapped.Errors
+	}
+	return nil
+}
+
+func (c *Client) CreatePipelineRule(r *PipelineRule) (*PipelineRule, error) {
+	// Унифицированный путь для всех версий
+	path := "/api/system/pipelines/rule"
+	resp, err := c.doRequest("POST", path, r)
+	if err != nil {
+		return nil, err
+	}
+	var out PipelineRule
+	_ = json.Unmarshal(resp, &out)
+	return &out, nil
+}
+
+func (c *Client) GetPipelineRule(id string) (*PipelineRule, error) {
+	// Унифицированный путь для всех версий
+	path := fmt.Sprintf("/api/system/pipelines/rule/%s", id)
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var out PipelineRule
+	_ = json.Unmarshal(resp, &out)
+	return &out, nil
+}
+
+func (c *Client) UpdatePipelineRule(id string, r *PipelineRule) (*PipelineRule, error) {
+	// Унифицированный путь для всех версий
+	path := fmt.Sprintf("/api/system/pipelines/rule/%s", id)
+	resp, err := c.doRequest("PUT", path, r)
+	if err != nil {
+		return nil, err
+	}
+	var out PipelineRule
+	_ = json.Unmarshal(resp, &out)
+	return &out, nil
+}
+
+func (c *Client) DeletePipelineRule(id string) error {
+	// Унифицированный путь для всех версий
+	path := fmt.Sprintf("/api/system/pipelines/rule/%s", id)
+	_, err := c.doRequest("DELETE", path, nil)
+	return err
+}
+
+// ListPipelineRules returns all pipeline rules. Graylog returns a raw array,
+// but some images wrap it into {"rules": [...]}; support both.
+func (c *Client) ListPipelineRules() ([]PipelineRule, error) {
+	path := "/api/system/pipelines/rule"
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var arr []PipelineRule
+	if err := json.Unmarshal(resp, &arr); err == nil {
+		return arr, nil
+	}
+	var wrapped struct {
+		Rules []PipelineRule `json:"rules"`
+	}
+	if err := json.Unmarshal(resp, &wrapped); err == nil && wrapped.Rules != nil {
+		return wrapped.Rules, nil
+	}
+	return nil, errors.New("unexpected pipeline rules list response format")
+}
+
+// ---- Pipeline Connections ----
+// Pipelines process messages only for streams they are connected to.
+
+type PipelineConnection struct {
+	ID          string   `json:"id,omitempty"`
+	StreamID    string   `json:"stream_id"`
+	PipelineIDs []string `json:"pipeline_ids"`
+}
+
+// ListPipelineConnections returns all stream↔pipeline connections.
+func (c *Client) ListPipelineConnections() ([]PipelineConnection, error) {
+	path := "/api/system/pipelines/connections"
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var arr []PipelineConnection
+	if err := json.Unmarshal(resp, &arr); err == nil {
+		return arr, nil
+	}
+	var wrapped struct {
+		Connections []PipelineConnection `json:"connections"`
+	}
+	if err := json.Unmarshal(resp, &wrapped); err == nil && wrapped.Connections != nil {
+		return wrapped.Connections, nil
+	}
+	return nil, errors.New("unexpected pipeline connections response format")
+}
+
+// GetPipelineConnectionsForStream returns pipeline IDs connected to the stream.
+// Graylog responds 404 when a stream has never been connected; treat it as an empty connection.
+func (c *Client) GetPipelineConnectionsForStream(streamID string) (*PipelineConnection, error) {
+	path := fmt.Sprintf("/api/system/pipelines/connections/%s", streamID)
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return &PipelineConnection{StreamID: streamID, PipelineIDs: []string{}}, nil
+		}
+		return nil, err
+	}
+	var out PipelineConnection
+	_ = json.Unmarshal(resp, &out)
+	if out.StreamID == "" {
+		out.StreamID = streamID
+	}
+	return &out, nil
+}
+
+// ConnectPipelinesToStream replaces the full set of pipelines connected to the stream.
+// An empty list disconnects all pipelines.
+func (c *Client) ConnectPipelinesToStream(streamID string, pipelineIDs []string) (*PipelineConnection, error) {
+	path := "/api/system/pipelines/connections/to_stream"
+	if pipelineIDs == nil {
+		pipelineIDs = []string{}
+	}
+	body := map[string]any{"stream_id": streamID, "pipeline_ids": pipelineIDs}
+	resp, err := c.doRequest("POST", path, body)
+	if err != nil {
+		return nil, err
+	}
+	var out PipelineConnection
+	_ = json.Unmarshal(resp, &out)
+	return &out, nil
+}
+
+// ConnectStreamsToPipeline replaces the full set of streams the pipeline is connected to.
+func (c *Client) ConnectStreamsToPipeline(pipelineID string, streamIDs []string) ([]PipelineConnection, error) {
+	path := "/api/system/pipelines/connections/to_pipeline"
+	if streamIDs == nil {
+		streamIDs = []string{}
+	}
+	body := map[string]any{"pipeline_id": pipelineID, "stream_ids": streamIDs}
+	resp, err := c.doRequest("POST", path, body)
+	if err != nil {
+		return nil, err
+	}
+	var out []PipelineConnection
+	_ = json.Unmarshal(resp, &out)
+	return out, nil
+}
+
+// ---- Lookup Tables (data adapters, caches, tables) ----
+// Graylog accepts either ID or name in /api/system/lookup/{adapters|caches|tables}/{idOrName}.
+
+type LookupDataAdapter struct {
+	ID          string         `json:"id,omitempty"`
+	Name        string         `json:"name"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Config      map[string]any `json:"config"`
+}
+
+type LookupCache struct {
+	ID          string         `json:"id,omitempty"`
+	Name        string         `json:"name"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Config      map[string]any `json:"config"`
+}
+
+type LookupTable struct {
+	ID                     string `json:"id,omitempty"`
+	Name                   string `json:"name"`
+	Title                  string `json:"title"`
+	Description            string `json:"description"`
+	CacheID                string `json:"cache_id"`
+	DataAdapterID          string `json:"data_adapter_id"`
+	DefaultSingleValue     string `json:"default_single_value"`
+	DefaultSingleValueType string `json:"default_single_value_type"`
+	DefaultMultiValue      string `json:"default_multi_value"`
+	DefaultMultiValueType  string `json:"default_multi_value_type"`
+}
+
+func (c *Client) CreateLookupAdapter(a *LookupDataAdapter) (*LookupDataAdapter, error) {
+	// Унифицированный путь для всех версий
+	path := "/api/system/lookup/adapters"
+	resp, err := c.doRequest("POST", path, a)
+	if err != nil {
+		return nil, err
+	}
+	var out LookupDataAdapter
+	_ = json.Unmarshal(resp, &out)
+	return &out, nil
+}
+
+// GetLookupAdapter returns a data adapter by ID or name.
+func (c *Client) GetLookupAdapter(idOrName string) (*LookupDataAdapter, error) {
+	path := fmt.Sprintf("/api/system/lookup/adapters/%s", idOrName)
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var out LookupDataAdapter
+	_ = json.Unmarshal(resp, &out)
+	return &out, nil
+}
+
+func (c *Client) UpdateLookupAdapter(id string, a *LookupDataAdapter) (*LookupDataAdapter, error) {
+	path := fmt.Sprintf("/api/system/lookup/adapters/%s", id)
+	// Graylog требует id в теле PUT
+	a.ID = id
+	resp, err := c.doRequest("PUT", path, a)
+	if err != nil {
+		return nil, err
+	}
+	var out LookupDataAdapter
+	_ = json.Unmarshal(resp, &out)
+	return &out, nil
+}
+
+func (c *Client) DeleteLookupAdapter(id string) error {
+	path := fmt.Sprintf("/api/system/lookup/adapters/%s", id)
+	_, err := c.doRequest("DELETE", path, nil)
+	return err
+}
+
+func (c *Client) CreateLookupCache(lc *LookupCache) (*LookupCache, error) {
+	// Унифицированный путь для всех версий
+	path := "/api/system/lookup/caches"
+	resp, err := c.doRequest("POST", path, lc)
+	if err != nil {
+		return nil, err
+	}
+	var out LookupCache
+	_ = json.Unmarshal(resp, &out)
+	return &out, nil
+}
+
+// GetLookupCache returns a cache by ID or name.
+func (c *Client) GetLookupCache(idOrName string) (*LookupCache, error) {
+	path := fmt.Sprintf("/api/system/lookup/caches/%s", idOrName)
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var out LookupCache
+	_ = json.Unmarshal(resp, &out)
+	return &out, nil
+}
+
+func (c *Client) UpdateLookupCache(id string, lc *LookupCache) (*LookupCache, error) {
+	path := fmt.Sprintf("/api/system/lookup/caches/%s", id)
+	lc.ID = id
+	resp, err := c.doRequest("PUT", path, lc)
+	if err != nil {
+		return nil, err
+	}
+	var out LookupCache
+	_ = json.Unmarshal(resp, &out)
+	return &out, nil
+}
+
+func (c *Client) DeleteLookupCache(id string) error {
+	path := fmt.Sprintf("/api/system/lookup/caches/%s", id)
+	_, err := c.doRequest("DELETE", path, nil)
+	return err
+}
+
+func (c *Client) CreateLookupTable(t *LookupTable) (*LookupTable, error) {
+	// Унифицированный путь для всех версий
+	path := "/api/system/lookup/tables"
+	resp, err := c.doRequest("POST", path, t)
+	if err != nil {
+		return nil, err
+	}
+	var out LookupTable
+	_ = json.Unmarshal(resp, &out)
+	return &out, nil
+}
+
+// GetLookupTable returns a lookup table by ID or name. Graylog wraps a single table
+// into a page object {"lookup_tables": [...], "caches": {}, "data_adapters": {}}; support both shapes.
+func (c *Client) GetLookupTable(idOrName string) (*LookupTable, error) {
+	path := fmt.Sprintf("/api/system/lookup/tables/%s", idOrName)
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var page struct {
+		LookupTables []LookupTable `json:"lookup_tables"`
+	}
+	if err := json.Unmarshal(resp, &page); err == nil && page.LookupTables != nil {
+		if len(page.LookupTables) == 0 {
+			return nil, ErrNotFound
+		}
+		return &page.LookupTables[0], nil
+	}
+	var out LookupTable
+	_ = json.Unmarshal(resp, &out)
+	return &out, nil
+}
+
+func (c *Client) UpdateLookupTable(id string, t *LookupTable) (*LookupTable, error) {
+	path := fmt.Sprintf("/api/system/lookup/tables/%s", id)
+	t.ID = id
+	resp, err := c.doRequest("PUT", path, t)
+	if err != nil {
+		return nil, err
+	}
+	var out LookupTable
+	_ = json.Unmarshal(resp, &out)
+	return &out, nil
+}
+
+func (c *Client) DeleteLookupTable(id string) error {
+	path := fmt.Sprintf("/api/system/lookup/tables/%s", id)
+	_, err := c.doRequest("DELETE", path, nil)
+	return err
+}
+
+// ---- Grok Patterns ----
+
+type GrokPattern struct {
+	ID      string `json:"id,omitempty"`
+	Name    string `json:"name"`
+	Pattern string `json:"pattern"`
+}
+
+// ListGrokPatterns returns all grok patterns ({"patterns": [...]} or a raw array).
+func (c *Client) ListGrokPatterns() ([]GrokPattern, error) {
+	path := "/api/system/grok"
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var wrapped struct {
+		Patterns []GrokPattern `json:"patterns"`
+	}
+	if err := json.Unmarshal(resp, &wrapped); err == nil && wrapped.Patterns != nil {
+		return wrapped.Patterns, nil
+	}
+	var arr []GrokPattern
+	if err := json.Unmarshal(resp, &arr); err == nil {
+		return arr, nil
+	}
+	return nil, errors.New("unexpected grok patterns list response format")
+}
+
+func (c *Client) GetGrokPattern(id string) (*GrokPattern, error) {
+	// Унифицированный путь для всех версий
+	path := fmt.Sprintf("/api/system/grok/%s", id)
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var out GrokPattern
+	_ = json.Unmarshal(resp, &out)
+	return &out, nil
+}
+
+func (c *Client) CreateGrokPattern(p *GrokPattern) (*GrokPattern, error) {
+	// Унифицированный путь для всех версий
+	path := "/api/system/grok"
+	resp, err := c.doRequest("POST", path, p)
+	if err != nil {
+		return nil, err
+	}
+	var out GrokPattern
+	_ = json.Unmarshal(resp, &out)
+	return &out, nil
+}
+
+func (c *Client) UpdateGrokPattern(id string, p *GrokPattern) (*GrokPattern, error) {
+	path := fmt.Sprintf("/api/system/grok/%s", id)
+	p.ID = id
+	resp, err := c.doRequest("PUT", path, p)
+	if err != nil {
+		return nil, err
+	}
+	var out GrokPattern
+	_ = json.Unmarshal(resp, &out)
+	return &out, nil
+}
+
+func (c *Client) DeleteGrokPattern(id string) error {
+	path := fmt.Sprintf("/api/system/grok/%s", id)
+	_, err := c.doRequest("DELETE", path, nil)
+	return err
+}
+
+// TestGrokPattern compiles the pattern on the server and matches it against sample data.
+// Graylog responds 400 when the pattern cannot be compiled; on success it returns the extracted fields
+// and a "matched" flag.
+func (c *Client) TestGrokPattern(p *GrokPattern, sample string) (map[string]any, error) {
+	path := "/api/system/grok/test"
+	body := map[string]any{
+		"grok_pattern": map[string]any{"name": p.Name, "pattern": p.Pattern},
+		"sampleData":   sample,
+	}
+	resp, err := c.doRequest("POST", path, body)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{}
+	_ = json.Unmarshal(resp, &out)
+	return out, nil
+}
+
+// ---- Dashboards ----
+
+type Dashboard struct {
+	ID          string `json:"id,omitempty"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
 
 func (c *Client) CreateDashboard(d *Dashboard) (*Dashboard, error) {
 	// Graylog 7.x использует Views API вместо legacy /dashboards
@@ -2287,11 +3254,50 @@ type EventDefinition struct {
 	// Graylog 5 requires additional fields
 	KeySpec              []string               `json:"key_spec,omitempty"`
 	NotificationSettings map[string]interface{} `json:"notification_settings,omitempty"`
+	// Custom event fields: name => {data_type, providers:[{type: template-v1|lookup-v1, ...}]}
+	FieldSpec map[string]interface{} `json:"field_spec,omitempty"`
+	// Event storage handlers, e.g. [{type: persist-to-streams-v1, streams: [...]}]
+	Storage []map[string]interface{} `json:"storage,omitempty"`
+	// Scheduling state reported by Graylog 5+ (ENABLED/DISABLED); read-only
+	State string `json:"state,omitempty"`
+	// Schedule=false saves the definition without scheduling it (?schedule=false); nil keeps
+	// Graylog's default of scheduling on create and update
+	Schedule *bool `json:"-"`
+}
+
+// eventDefinitionPath appends the schedule query parameter when scheduling is set explicitly.
+func eventDefinitionPath(path string, ed *EventDefinition) string {
+	if ed.Schedule != nil {
+		path += fmt.Sprintf("?schedule=%t", *ed.Schedule)
+	}
+	return path
+}
+
+// v5Body renders the snake_case payload Graylog 5 expects. GL5 uses "notifications" objects,
+// so unknown notification_ids are omitted.
+func (ed *EventDefinition) v5Body() map[string]any {
+	body := map[string]any{
+		"title":                 ed.Title,
+		"description":           ed.Description,
+		"priority":              ed.Priority,
+		"alert":                 ed.Alert,
+		"config":                ed.Config,
+		"notifications":         []any{},
+		"notification_settings": ed.NotificationSettings,
+		"key_spec":              ed.KeySpec,
+	}
+	if ed.FieldSpec != nil {
+		body["field_spec"] = ed.FieldSpec
+	}
+	if ed.Storage != nil {
+		body["storage"] = ed.Storage
+	}
+	return body
 }
 
 func (c *Client) CreateEventDefinition(ed *EventDefinition) (*EventDefinition, error) {
 	// Унифицированный путь для всех версий
-	path := "/api/events/definitions"
+	path := eventDefinitionPath("/api/events/definitions", ed)
 	// Ensure required defaults for Graylog 5 compatibility
 	if ed.KeySpec == nil {
 		ed.KeySpec = []string{}
@@ -2306,17 +3312,7 @@ func (c *Client) CreateEventDefinition(ed *EventDefinition) (*EventDefinition, e
 	var baseBody any = ed
 	if c.APIVersion == APIV5 {
 		// GL5 expects snake_case fields key_spec/notification_settings
-		baseBody = map[string]any{
-			"title":       ed.Title,
-			"description": ed.Description,
-			"priority":    ed.Priority,
-			"alert":       ed.Alert,
-			"config":      ed.Config,
-			// GL5 uses "notifications" objects; omit unknown notification_ids
-			"notifications":         []any{},
-			"notification_settings": ed.NotificationSettings,
-			"key_spec":              ed.KeySpec,
-		}
+		baseBody = ed.v5Body()
 	}
 
 	// Для устойчивости: пробуем оба варианта тела запроса вне зависимости от детекции версии,
@@ -2359,7 +3355,7 @@ func (c *Client) GetEventDefinition(id string) (*EventDefinition, error) {
 
 func (c *Client) UpdateEventDefinition(id string, ed *EventDefinition) (*EventDefinition, error) {
 	// Унифицированный путь для всех версий
-	path := fmt.Sprintf("/api/events/definitions/%s", id)
+	path := eventDefinitionPath(fmt.Sprintf("/api/events/definitions/%s", id), ed)
 	if ed.KeySpec == nil {
 		ed.KeySpec = []string{}
 	}
@@ -2371,17 +3367,7 @@ func (c *Client) UpdateEventDefinition(id string, ed *EventDefinition) (*EventDe
 	}
 	var body any = ed
 	if c.APIVersion == APIV5 {
-		req := map[string]any{
-			"title":                 ed.Title,
-			"description":           ed.Description,
-			"priority":              ed.Priority,
-			"alert":                 ed.Alert,
-			"config":                ed.Config,
-			"notifications":         []any{},
-			"notification_settings": ed.NotificationSettings,
-			"key_spec":              ed.KeySpec,
-		}
-		body = req
+		body = ed.v5Body()
 	}
 	resp, err := c.doRequest("PUT", path, body)
 	if err != nil {
@@ -2400,6 +3386,108 @@ func (c *Client) DeleteEventDefinition(id string) error {
 	return err
 }
 
+// ListEventDefinitions returns all event definitions.
+func (c *Client) ListEventDefinitions() ([]EventDefinition, error) {
+	resp, err := c.doRequest("GET", "/api/events/definitions?per_page=1000", nil)
+	if err != nil {
+		return nil, err
+	}
+	var wrap struct {
+		EventDefinitions []EventDefinition `json:"event_definitions"`
+	}
+	if err := json.Unmarshal(resp, &wrap); err != nil || wrap.EventDefinitions == nil {
+		var direct []EventDefinition
+		if err := json.Unmarshal(resp, &direct); err != nil {
+			return nil, errors.New("unexpected event definitions response format")
+		}
+		wrap.EventDefinitions = direct
+	}
+	return wrap.EventDefinitions, nil
+}
+
+// ReplaceEventDefinitionStream replaces oldStreamID with newStreamID in the definition's filter
+// streams. The definition is sent back as stored, so fields the provider does not model survive.
+func (c *Client) ReplaceEventDefinitionStream(id, oldStreamID, newStreamID string) error {
+	path := fmt.Sprintf("/api/events/definitions/%s", id)
+	resp, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return err
+	}
+	var ed map[string]any
+	if err := json.Unmarshal(resp, &ed); err != nil {
+		return fmt.Errorf("unexpected event definition response: %w", err)
+	}
+	cfg, _ := ed["config"].(map[string]any)
+	streams, _ := cfg["streams"].([]any)
+	for i, s := range streams {
+		if s == oldStreamID {
+			streams[i] = newStreamID
+		}
+	}
+	_, err = c.doRequest("PUT", path, ed)
+	return err
+}
+
+// ScheduleEventDefinition (re-)creates the scheduler job of an event definition.
+func (c *Client) ScheduleEventDefinition(id string) error {
+	path := fmt.Sprintf("/api/events/definitions/%s/schedule", id)
+	_, err := c.doRequest("PUT", path, nil)
+	return err
+}
+
+// UnscheduleEventDefinition removes the scheduler job; the definition stays but never runs.
+func (c *Client) UnscheduleEventDefinition(id string) error {
+	path := fmt.Sprintf("/api/events/definitions/%s/unschedule", id)
+	_, err := c.doRequest("PUT", path, nil)
+	return err
+}
+
+// IsEventDefinitionScheduled reports whether the definition has an active scheduler job.
+// It reads the scheduler context from /with-context and falls back to the `state` field (Graylog 5+).
+func (c *Client) IsEventDefinitionScheduled(id string) (bool, error) {
+	path := fmt.Sprintf("/api/events/definitions/%s/with-context", id)
+	resp, err := c.doRequest("GET", path, nil)
+	if err == nil {
+		var out struct {
+			EventDefinition EventDefinition `json:"event_definition"`
+			Context         struct {
+				Scheduler map[string]json.RawMessage `json:"scheduler"`
+			} `json:"context"`
+		}
+		if json.Unmarshal(resp, &out) == nil {
+			var sched struct {
+				IsScheduled *bool `json:"is_scheduled"`
+			}
+			// Keyed by definition ID in most versions, flat in others
+			if raw, ok := out.Context.Scheduler[id]; ok && json.Unmarshal(raw, &sched) == nil && sched.IsScheduled != nil {
+				return *sched.IsScheduled, nil
+			}
+			if raw, ok := out.Context.Scheduler["is_scheduled"]; ok {
+				var b bool
+				if json.Unmarshal(raw, &b) == nil {
+					return b, nil
+				}
+			}
+			if st := out.EventDefinition.State; st != "" {
+				return strings.EqualFold(st, "ENABLED"), nil
+			}
+		}
+	} else if errors.Is(err, ErrNotFound) {
+		// Older versions lack /with-context; the plain definition may still carry `state`
+		ed, gerr := c.GetEventDefinition(id)
+		if gerr != nil {
+			return false, gerr
+		}
+		if ed.State != "" {
+			return strings.EqualFold(ed.State, "ENABLED"), nil
+		}
+		return false, fmt.Errorf("scheduling state of event definition %s is not reported by this Graylog version", id)
+	} else {
+		return false, err
+	}
+	return false, fmt.Errorf("scheduling state of event definition %s is not reported by this Graylog version", id)
+}
+
 // ---- Event Notifications ----
 
 type EventNotification struct {
@@ -2410,31 +3498,79 @@ type EventNotification struct {
 	Config      map[string]interface{} `json:"config"`
 }
 
+// NotificationConfigType maps a short notification type (email, http, slack, teams, pagerduty, script)
+// to the config.type discriminator expected by this Graylog version. Other values are returned as is.
+func (c *Client) NotificationConfigType(t string) string {
+	switch t {
+	case "email":
+		return "email-notification-v1"
+	case "http":
+		return "http-notification-v1"
+	case "slack":
+		return "slack-notification-v1"
+	case "teams":
+		// Graylog 6 replaced the MessageCard based Teams notification with an adaptive card one
+		if c.APIVersion == APIV5 {
+			return "teams-notification-v1"
+		}
+		return "teams-notification-v2"
+	case "pagerduty":
+		return "pagerduty-notification-v2"
+	case "script":
+		return "script-notification-v1"
+	}
+	return t
+}
+
+// NotificationShortType is the inverse of NotificationConfigType ("slack-notification-v1" => "slack").
+func NotificationShortType(configType string) string {
+	if i := strings.Index(configType, "-notification-v"); i > 0 {
+		return configType[:i]
+	}
+	if strings.Contains(strings.ToLower(configType), "email") {
+		return "email"
+	}
+	return configType
+}
+
+// withConfigType returns a copy of n whose config carries the type discriminator.
+func (c *Client) withConfigType(n *EventNotification) *EventNotification {
+	cfg := make(map[string]interface{}, len(n.Config)+1)
+	for k, v := range n.Config {
+		cfg[k] = v
+	}
+	if _, ok := cfg["type"]; !ok && n.Type != "" {
+		cfg["type"] = c.NotificationConfigType(n.Type)
+	}
+	out := *n
+	out.Config = cfg
+	return &out
+}
+
+// normalizeNotificationType fills Type from config.type when the response has no top-level type.
+func normalizeNotificationType(n *EventNotification) {
+	if n.Type != "" {
+		return
+	}
+	if t, _ := n.Config["type"].(string); t != "" {
+		n.Type = NotificationShortType(t)
+	}
+}
+
 func (c *Client) CreateEventNotification(n *EventNotification) (*EventNotification, error) {
 	path := "/events/notifications"
 	if c.APIVersion == APIV6 || c.APIVersion == APIV7 {
 		path = "/api/events/notifications"
 	}
+	n = c.withConfigType(n)
 	// Для v7 используется CreateEntityRequest с обёрткой entity
 	if c.APIVersion == APIV7 {
-		// entity без поля type (см. ошибку маппинга), только title/description/config
-		// Для v7 конфиг email чаще ожидает snake_case ключи — оставим как передано пользователем
-		cfg := map[string]any{}
-		for k, v := range n.Config {
-			cfg[k] = v
-		}
-		// Для email-уведомлений в v7 требуется явный тип конфигурации
-		if n.Type == "email" {
-			if _, ok := cfg["type"]; !ok {
-				// На разных сборках встречаются значения вида "email-notification-v1" или "org.graylog.events.notifications.types.EmailEventNotificationConfig"
-				// Попробуем более короткий вариант, а при ошибке сервер вернёт конкретный ожидаемый тип
-				cfg["type"] = "email-notification-v1"
-			}
-		}
+		// entity без поля type (см. ошибку маппинга), только title/description/config;
+		// тип конфигурации уже проставлен в config.type (withConfigType)
 		entity := map[string]any{
 			"title":       n.Title,
 			"description": n.Description,
-			"config":      cfg,
+			"config":      n.Config,
 		}
 		body := map[string]any{
 			// В Graylog 7 CreateEntityRequest для notifications ожидает только { entity, share_request? }
@@ -2454,17 +3590,8 @@ func (c *Client) CreateEventNotification(n *EventNotification) (*EventNotificati
 		}
 		var out EventNotification
 		_ = json.Unmarshal(resp, &out)
-		// Нормализуем тип: если в config.type присутствует email — проставим Type="email"
-		if out.Type == "" {
-			var aux map[string]any
-			if json.Unmarshal(resp, &aux) == nil {
-				if cfg, ok := aux["config"].(map[string]any); ok {
-					if t, _ := cfg["type"].(string); strings.Contains(strings.ToLower(t), "email") {
-						out.Type = "email"
-					}
-				}
-			}
-		}
+		// Нормализуем тип по config.type ("email-notification-v1" => "email")
+		normalizeNotificationType(&out)
 		if out.ID == "" {
 			// Возможный ответ-обёртка {"id":"..."}
 			var aux map[string]any
@@ -2497,16 +3624,7 @@ func (c *Client) GetEventNotification(id string) (*EventNotification, error) {
 	var out EventNotification
 	_ = json.Unmarshal(resp, &out)
 	// Нормализуем тип на чтении
-	if out.Type == "" {
-		var aux map[string]any
-		if json.Unmarshal(resp, &aux) == nil {
-			if cfg, ok := aux["config"].(map[string]any); ok {
-				if t, _ := cfg["type"].(string); strings.Contains(strings.ToLower(t), "email") {
-					out.Type = "email"
-				}
-			}
-		}
-	}
+	normalizeNotificationType(&out)
 	return &out, nil
 }
 
@@ -2515,7 +3633,7 @@ func (c *Client) UpdateEventNotification(id string, n *EventNotification) (*Even
 	if c.APIVersion == APIV6 || c.APIVersion == APIV7 {
 		path = fmt.Sprintf("/api/events/notifications/%s", id)
 	}
-	resp, err := c.doRequest("PUT", path, n)
+	resp, err := c.doRequest("PUT", path, c.withConfigType(n))
 	if err != nil {
 		return nil, err
 	}
@@ -2546,17 +3664,64 @@ func (c *Client) ListEventNotifications() ([]EventNotification, error) {
 	var arr []EventNotification
 	if len(resp) > 0 && resp[0] == '[' {
 		_ = json.Unmarshal(resp, &arr)
-		return arr, nil
-	}
-	var wrap struct {
-		Notifications []EventNotification `json:"notifications"`
+	} else {
+		var wrap struct {
+			Notifications []EventNotification `json:"notifications"`
+		}
+		if err := json.Unmarshal(resp, &wrap); err == nil && wrap.Notifications != nil {
+			arr = wrap.Notifications
+		}
 	}
-	if err := json.Unmarshal(resp, &wrap); err == nil && wrap.Notifications != nil {
-		return wrap.Notifications, nil
+	for i := range arr {
+		normalizeNotificationType(&arr[i])
 	}
 	return arr, nil
 }
 
+// NotificationTestResult is the outcome of a notification test fire. Graylog answers 200 when the
+// notification was sent and an error status with the failure detail otherwise.
+type NotificationTestResult struct {
+	Success bool
+	Status  int
+	Message string
+}
+
+// TestEventNotification fires a test event through a saved notification.
+func (c *Client) TestEventNotification(id string) (*NotificationTestResult, error) {
+	path := fmt.Sprintf("/events/notifications/%s/test", id)
+	if c.APIVersion == APIV6 || c.APIVersion == APIV7 {
+		path = fmt.Sprintf("/api/events/notifications/%s/test", id)
+	}
+	return c.testNotification(path, nil)
+}
+
+// TestEventNotificationConfig fires a test event through an unsaved notification definition.
+func (c *Client) TestEventNotificationConfig(n *EventNotification) (*NotificationTestResult, error) {
+	path := "/events/notifications/test"
+	if c.APIVersion == APIV6 || c.APIVersion == APIV7 {
+		path = "/api/events/notifications/test"
+	}
+	return c.testNotification(path, c.withConfigType(n))
+}
+
+func (c *Client) testNotification(path string, body any) (*NotificationTestResult, error) {
+	// No retries: every attempt would send the notification again
+	nc := c.WithContext(c.ctx)
+	nc.MaxRetries = 0
+	if _, err := nc.doRequest("POST", path, body); err != nil {
+		var ge *GraylogError
+		if errors.As(err, &ge) && ge.Status != http.StatusUnauthorized && ge.Status != http.StatusForbidden {
+			msg := ge.Message
+			if msg == "" {
+				msg = ge.Error()
+			}
+			return &NotificationTestResult{Status: ge.Status, Message: msg}, nil
+		}
+		return nil, err
+	}
+	return &NotificationTestResult{Success: true, Status: http.StatusOK}, nil
+}
+
 // ---- Views (read-only listing used for governance/data sources) ----
 
 type View struct {
@@ -2670,6 +3835,24 @@ type User struct {
 	Password         string   `json:"password,omitempty"`
 }
 
+// UnmarshalJSON also decodes account_status ("enabled"/"disabled"), which Graylog 4+ reports
+// instead of the disabled flag.
+func (u *User) UnmarshalJSON(b []byte) error {
+	type plain User
+	var v struct {
+		plain
+		AccountStatus string `json:"account_status"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*u = User(v.plain)
+	if v.AccountStatus != "" {
+		u.Disabled = strings.EqualFold(v.AccountStatus, "disabled")
+	}
+	return nil
+}
+
 func (c *Client) CreateUser(u *User) (*User, error) {
 	// Унифицированный путь для всех версий
 	path := "/api/users"
@@ -2785,6 +3968,25 @@ func (c *Client) UpdateUser(username string, u *User) (*User, error) {
 	return c.GetUser(username)
 }
 
+// SetUserDisabled enables or disables a user account (/users/{id}/status/{enabled|disabled}, Graylog 4.1+).
+// The account status endpoint expects the user ID; the username is resolved first.
+func (c *Client) SetUserDisabled(username string, disabled bool) error {
+	u, err := c.GetUser(username)
+	if err != nil {
+		return err
+	}
+	id := u.ID
+	if id == "" {
+		id = username
+	}
+	status := "enabled"
+	if disabled {
+		status = "disabled"
+	}
+	_, err = c.doRequest("PUT", fmt.Sprintf("/api/users/%s/status/%s", id, status), nil)
+	return err
+}
+
 func (c *Client) DeleteUser(username string) error {
 	// Унифицированный путь для всех версий
 	path := fmt.Sprintf("/api/users/%s", username)
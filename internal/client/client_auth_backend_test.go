@@ -0,0 +1,101 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthBackendCRUDAndActivation(t *testing.T) {
+	active := any(nil)
+	var created map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/system/authentication/services/backends":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			created["id"] = "b1"
+			_ = json.NewEncoder(w).Encode(map[string]any{"backend": created, "context": map[string]any{}})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/system/authentication/services/backends/b1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"backend": created})
+		case r.URL.Path == "/api/system/authentication/services/configuration":
+			if r.Method == http.MethodPost {
+				var body map[string]any
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				active = body["active_backend"]
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"configuration": map[string]any{"active_backend": active}})
+		case r.URL.Path == "/api/authz/roles":
+			_ = json.NewEncoder(w).Encode(map[string]any{"roles": []any{map[string]any{"id": "r1", "name": "Reader"}}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	b, err := c.CreateAuthBackend(&AuthBackend{Title: "ldap", DefaultRoles: []string{"r1"}, Config: map[string]interface{}{"type": "ldap"}})
+	if err != nil || b.ID != "b1" || b.Config["type"] != "ldap" {
+		t.Fatalf("unexpected create result: %+v (%v)", b, err)
+	}
+	if got, err := c.GetAuthBackend("b1"); err != nil || got.Title != "ldap" || len(got.DefaultRoles) != 1 {
+		t.Fatalf("unexpected get result: %+v (%v)", got, err)
+	}
+	if _, err := c.GetAuthBackend("missing"); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if id, err := c.GetActiveAuthBackendID(); err != nil || id != "" {
+		t.Fatalf("expected no active backend, got %q (%v)", id, err)
+	}
+	if err := c.SetActiveAuthBackend("b1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id, _ := c.GetActiveAuthBackendID(); id != "b1" {
+		t.Fatalf("expected b1 active, got %q", id)
+	}
+	if err := c.SetActiveAuthBackend(""); err != nil || active != nil {
+		t.Fatalf("expected active_backend null, got %v (%v)", active, err)
+	}
+	if roles, err := c.ListRoleIDs(); err != nil || roles["Reader"] != "r1" {
+		t.Fatalf("unexpected roles: %v (%v)", roles, err)
+	}
+}
+
+func TestAuthBackendConnectionAndLoginTest(t *testing.T) {
+	var bodies []map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		switch r.URL.Path {
+		case "/api/system/authentication/services/test/backend/connection":
+			_ = json.NewEncoder(w).Encode(map[string]any{"success": true, "message": "Connection successful", "errors": []any{}})
+		case "/api/system/authentication/services/test/backend/login":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"success": false, "message": "Login failed", "errors": []any{"invalid credentials"},
+				"result": map[string]any{"user_exists": true, "login_success": false, "user_details": map[string]any{"username": "jdoe"}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	cfg := &AuthBackend{Title: "t", Config: map[string]interface{}{"type": "ldap"}}
+	conn, err := c.TestAuthBackendConnection("", cfg)
+	if err != nil || !conn.Success || conn.Message != "Connection successful" {
+		t.Fatalf("unexpected connection result: %+v (%v)", conn, err)
+	}
+	if _, ok := bodies[0]["backend_id"]; ok || bodies[0]["user_login"] != nil {
+		t.Fatalf("connection test must not send backend_id or user_login: %+v", bodies[0])
+	}
+	login, err := c.TestAuthBackendLogin("b1", cfg, "jdoe", "secret")
+	if err != nil || login.Success || len(login.Errors) != 1 || login.Result["user_exists"] != true {
+		t.Fatalf("unexpected login result: %+v (%v)", login, err)
+	}
+	ul, _ := bodies[1]["user_login"].(map[string]any)
+	if bodies[1]["backend_id"] != "b1" || ul["username"] != "jdoe" || ul["password"] != "secret" {
+		t.Fatalf("unexpected login request: %+v", bodies[1])
+	}
+}
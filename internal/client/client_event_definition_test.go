@@ -0,0 +1,231 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpdateEventDefinition_V5SendsFieldSpecAndStorage(t *testing.T) {
+	var body map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/events/definitions/e1" {
+			w.WriteHeader(404)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "e1"})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	_, err := c.UpdateEventDefinition("e1", &EventDefinition{
+		Title:     "t",
+		KeySpec:   []string{"host"},
+		FieldSpec: map[string]interface{}{"host": map[string]interface{}{"data_type": "string"}},
+		Storage:   []map[string]interface{}{{"type": "persist-to-streams-v1", "streams": []string{"000000000000000000000002"}}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := body["field_spec"].(map[string]any)["host"]; !ok {
+		t.Fatalf("expected field_spec in body, got %+v", body)
+	}
+	if st, ok := body["storage"].([]any); !ok || len(st) != 1 {
+		t.Fatalf("expected storage in body, got %+v", body)
+	}
+	if ns, ok := body["notification_settings"].(map[string]any); !ok || ns["grace_period_ms"] != float64(0) {
+		t.Fatalf("expected default notification_settings, got %+v", body)
+	}
+}
+
+func TestIsEventDefinitionScheduled(t *testing.T) {
+	withContext := true
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/api/events/definitions/e1/with-context":
+			if !withContext {
+				w.WriteHeader(404)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"event_definition": map[string]any{"id": "e1"},
+				"context":          map[string]any{"scheduler": map[string]any{"e1": map[string]any{"is_scheduled": false}}},
+			})
+		case "/api/events/definitions/e1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "e1", "state": "ENABLED"})
+		case "/api/events/definitions/e1/schedule", "/api/events/definitions/e1/unschedule":
+			if r.Method != http.MethodPut {
+				w.WriteHeader(405)
+			}
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	if sched, err := c.IsEventDefinitionScheduled("e1"); err != nil || sched {
+		t.Fatalf("expected unscheduled from context, got %v %v", sched, err)
+	}
+	withContext = false
+	if sched, err := c.IsEventDefinitionScheduled("e1"); err != nil || !sched {
+		t.Fatalf("expected scheduled from state fallback, got %v %v", sched, err)
+	}
+	if err := c.UnscheduleEventDefinition("e1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.ScheduleEventDefinition("e1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if last := calls[len(calls)-1]; last != "PUT /api/events/definitions/e1/schedule" {
+		t.Fatalf("unexpected call %q", last)
+	}
+}
+
+func TestEventNotification_ConfigTypeByVersion(t *testing.T) {
+	var body map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "n1"})
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "n1", "title": "t", "config": map[string]any{"type": "teams-notification-v2"}})
+		}
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	if _, err := c.CreateEventNotification(&EventNotification{Title: "t", Type: "teams", Config: map[string]interface{}{"webhook_url": "https://teams/x"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg := body["config"].(map[string]any); cfg["type"] != "teams-notification-v1" {
+		t.Fatalf("expected teams-notification-v1 on Graylog 5, got %+v", cfg)
+	}
+	c.APIVersion = APIV6
+	if got := c.NotificationConfigType("teams"); got != "teams-notification-v2" {
+		t.Fatalf("expected teams-notification-v2 on Graylog 6, got %s", got)
+	}
+	// An explicit config.type is left alone
+	if _, err := c.CreateEventNotification(&EventNotification{Title: "t", Type: "http", Config: map[string]interface{}{"type": "custom-v1"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg := body["config"].(map[string]any); cfg["type"] != "custom-v1" {
+		t.Fatalf("config.type must not be overridden, got %+v", cfg)
+	}
+	got, err := c.GetEventNotification("n1")
+	if err != nil || got.Type != "teams" {
+		t.Fatalf("expected type normalized to teams, got %+v (%v)", got, err)
+	}
+}
+
+func TestTestEventNotification(t *testing.T) {
+	calls := 0
+	var body map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/events/notifications/ok/test":
+			w.WriteHeader(http.StatusOK)
+		case "/events/notifications/bad/test":
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(map[string]any{"type": "ApiError", "message": "Notification has failed: connect timed out"})
+		case "/events/notifications/denied/test":
+			w.WriteHeader(http.StatusForbidden)
+		case "/events/notifications/test":
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	c.MaxRetries = 2
+	if res, err := c.TestEventNotification("ok"); err != nil || !res.Success {
+		t.Fatalf("expected success, got %+v (%v)", res, err)
+	}
+	calls = 0
+	res, err := c.TestEventNotification("bad")
+	if err != nil || res.Success || res.Status != 500 || res.Message != "Notification has failed: connect timed out" {
+		t.Fatalf("expected failed result with detail, got %+v (%v)", res, err)
+	}
+	if calls != 1 {
+		t.Fatalf("test notification must not be retried, got %d calls", calls)
+	}
+	if _, err := c.TestEventNotification("denied"); err == nil {
+		t.Fatal("expected error for 403")
+	}
+	if _, err := c.TestEventNotification("missing"); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := c.TestEventNotificationConfig(&EventNotification{Title: "t", Type: "slack", Config: map[string]interface{}{"channel": "#a"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg := body["config"].(map[string]any); cfg["type"] != "slack-notification-v1" {
+		t.Fatalf("expected config.type in test body, got %+v", body)
+	}
+}
+
+func TestReplaceEventDefinitionStream_KeepsStoredFields(t *testing.T) {
+	var body map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/events/definitions/e1" {
+			w.WriteHeader(404)
+			return
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"id":"e1","title":"t","notifications":[{"notification_id":"n1"}],"config":{"type":"aggregation-v1","streams":["old","other"]}}`))
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	if err := newTestClient(ts.URL).ReplaceEventDefinitionStream("e1", "old", "new"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	streams := body["config"].(map[string]any)["streams"].([]any)
+	if len(streams) != 2 || streams[0] != "new" || streams[1] != "other" {
+		t.Fatalf("unexpected streams %v", streams)
+	}
+	if n, ok := body["notifications"].([]any); !ok || len(n) != 1 {
+		t.Fatalf("stored notifications were not sent back: %+v", body)
+	}
+}
+
+func TestEventDefinition_ScheduleFalseQuery(t *testing.T) {
+	var queries []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.Method+" "+r.URL.Query().Get("schedule"))
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "e1"})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	off := false
+	if _, err := c.CreateEventDefinition(&EventDefinition{Title: "t", Schedule: &off}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := c.UpdateEventDefinition("e1", &EventDefinition{Title: "t", Schedule: &off}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if _, err := c.UpdateEventDefinition("e1", &EventDefinition{Title: "t"}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	want := []string{"POST false", "PUT false", "PUT "}
+	if len(queries) != len(want) {
+		t.Fatalf("unexpected requests %v", queries)
+	}
+	for i := range want {
+		if queries[i] != want[i] {
+			t.Fatalf("unexpected requests %v, want %v", queries, want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpdateInputExtractor_PutsPayload(t *testing.T) {
+	var got map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/system/inputs/in1/extractors/ex1" || r.Method != http.MethodPut {
+			w.WriteHeader(404)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "ex1", "title": got["title"]})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	out, err := c.UpdateInputExtractor("in1", "ex1", map[string]interface{}{"title": "renamed", "extractor_type": "regex"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["title"] != "renamed" || out["id"] != "ex1" {
+		t.Fatalf("unexpected request/response: %+v / %+v", got, out)
+	}
+}
+
+func TestOrderInputExtractors_Body(t *testing.T) {
+	var got struct {
+		Order map[string]string `json:"order"`
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/system/inputs/in1/extractors/order" || r.Method != http.MethodPost {
+			w.WriteHeader(404)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	if err := c.OrderInputExtractors("in1", []string{"b", "a"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Order["0"] != "b" || got.Order["1"] != "a" {
+		t.Fatalf("unexpected order body: %+v", got.Order)
+	}
+}
+
+func TestGetInputExtractor_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	if _, err := c.GetInputExtractor("in1", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListGrokPatterns_Wrapped(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/system/grok" {
+			w.WriteHeader(404)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"patterns": []GrokPattern{{ID: "g1", Name: "MY_WORD", Pattern: `\w+`}}})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	list, err := c.ListGrokPatterns()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 1 || list[0].Name != "MY_WORD" || list[0].ID != "g1" {
+		t.Fatalf("unexpected patterns: %+v", list)
+	}
+}
+
+func TestTestGrokPattern_Body(t *testing.T) {
+	var got map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/system/grok/test" || r.Method != http.MethodPost {
+			w.WriteHeader(404)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_ = json.NewEncoder(w).Encode(map[string]any{"matched": true, "matches": []map[string]any{{"name": "MY_WORD", "match": "hello"}}})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	res, err := c.TestGrokPattern(&GrokPattern{Name: "MY_WORD", Pattern: `\w+`}, "hello")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res["matched"] != true {
+		t.Fatalf("expected matched=true, got %+v", res)
+	}
+	gp, _ := got["grok_pattern"].(map[string]any)
+	if gp["name"] != "MY_WORD" || got["sampleData"] != "hello" {
+		t.Fatalf("unexpected request body: %+v", got)
+	}
+}
+
+func TestTestGrokPattern_BadRequest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+		_ = json.NewEncoder(w).Encode(map[string]any{"type": "ApiError", "message": "Invalid pattern"})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	if _, err := c.TestGrokPattern(&GrokPattern{Name: "BAD", Pattern: "(unclosed"}, ""); err == nil {
+		t.Fatal("expected error on 400 response")
+	}
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListClusterInputStates_Cluster(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/cluster/inputstates" {
+			w.WriteHeader(404)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"node-a": []map[string]any{{"id": "in1", "state": "RUNNING", "message_input": map[string]any{"id": "in1", "title": "t", "type": "x"}}},
+			"node-b": []map[string]any{{"id": "in1", "state": "FAILED", "detailed_message": "Address already in use"}},
+		})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	states, err := c.ListClusterInputStates()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(states) != 2 || states["node-b"][0].State != "FAILED" || states["node-a"][0].InputID() != "in1" {
+		t.Fatalf("unexpected states: %+v", states)
+	}
+}
+
+func TestListClusterInputStates_FallbackToLocal(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/system/inputstates" {
+			w.WriteHeader(404)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"states": []map[string]any{{"id": "in1", "state": "STOPPED"}}})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	states, err := c.ListClusterInputStates()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(states["local"]) != 1 || states["local"][0].State != "STOPPED" {
+		t.Fatalf("unexpected states: %+v", states)
+	}
+}
+
+func TestStartStopInput_ClusterWide(t *testing.T) {
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		if r.URL.Path != "/api/cluster/inputstates/in1" {
+			w.WriteHeader(404)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"node-1": map[string]any{"id": "in1"}, "node-2": map[string]any{"id": "in1"}})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	if err := c.StopInput("in1"); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	if err := c.StartInput("in1"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if len(calls) != 2 || calls[0] != "DELETE /api/cluster/inputstates/in1" || calls[1] != "PUT /api/cluster/inputstates/in1" {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+}
+
+// Older versions without the cluster endpoint change the state on the answering node
+func TestStartStopInput_Methods(t *testing.T) {
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/system/inputstates/in1" {
+			w.WriteHeader(404)
+			return
+		}
+		calls = append(calls, r.Method)
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "in1"})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	if err := c.StopInput("in1"); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	if err := c.StartInput("in1"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if len(calls) != 2 || calls[0] != http.MethodDelete || calls[1] != http.MethodPut {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInputStaticFields_AddDeleteAndRead(t *testing.T) {
+	fields := map[string]string{"env": "prod"}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/system/inputs/in1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "in1", "title": "t", "type": "x", "static_fields": fields})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/system/inputs/in1/staticfields":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			fields[body["key"]] = body["value"]
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/system/inputs/in1/staticfields/env":
+			delete(fields, "env")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	if err := c.AddInputStaticField("in1", "team", "sre"); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err := c.DeleteInputStaticField("in1", "env"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	in, err := c.GetInput("in1")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if len(in.StaticFields) != 1 || in.StaticFields["team"] != "sre" {
+		t.Fatalf("unexpected static fields: %+v", in.StaticFields)
+	}
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const syslogUDPClass = "org.graylog2.inputs.syslog.udp.SyslogUDPInput"
+
+func TestListInputTypeInfos_FallbackPerType(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/system/inputs/types":
+			_ = json.NewEncoder(w).Encode(map[string]any{"types": map[string]string{syslogUDPClass: "Syslog UDP"}})
+		case "/api/system/inputs/types/" + syslogUDPClass:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"type": syslogUDPClass,
+				"name": "Syslog UDP",
+				"requested_configuration": map[string]any{
+					"port":         map[string]any{"type": "number", "default_value": 514, "is_optional": false},
+					"bind_address": map[string]any{"type": "text", "default_value": "0.0.0.0", "is_optional": false},
+				},
+			})
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	infos, err := c.ListInputTypeInfos()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	info, ok := infos[syslogUDPClass]
+	if !ok || info.Name != "Syslog UDP" || len(info.RequestedConfiguration) != 2 || info.RequestedConfiguration["port"].IsOptional {
+		t.Fatalf("unexpected infos: %+v", infos)
+	}
+}
+
+func TestListInputTypeInfos_All(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/system/inputs/types/all" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(404)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			syslogUDPClass: map[string]any{"name": "Syslog UDP", "requested_configuration": map[string]any{}},
+		})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	infos, err := c.ListInputTypeInfos()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if infos[syslogUDPClass].Type != syslogUDPClass {
+		t.Fatalf("expected type to default to the map key, got %+v", infos)
+	}
+}
+
+func TestGetInputType_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	if _, err := c.GetInputType("no.such.Input"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
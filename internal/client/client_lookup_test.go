@@ -0,0 +1,63 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetLookupTable_PageAndPlain(t *testing.T) {
+	tbl := LookupTable{ID: "t1", Name: "hosts", Title: "Hosts", CacheID: "c1", DataAdapterID: "a1"}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/system/lookup/tables/hosts":
+			// Graylog оборачивает одну таблицу в страницу
+			_ = json.NewEncoder(w).Encode(map[string]any{"lookup_tables": []LookupTable{tbl}, "caches": map[string]any{}, "data_adapters": map[string]any{}})
+		case "/api/system/lookup/tables/t1":
+			_ = json.NewEncoder(w).Encode(tbl)
+		case "/api/system/lookup/tables/empty":
+			_ = json.NewEncoder(w).Encode(map[string]any{"lookup_tables": []LookupTable{}})
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	for _, key := range []string{"hosts", "t1"} {
+		got, err := c.GetLookupTable(key)
+		if err != nil {
+			t.Fatalf("GetLookupTable(%s) error: %v", key, err)
+		}
+		if got.ID != "t1" || got.CacheID != "c1" || got.DataAdapterID != "a1" {
+			t.Fatalf("unexpected table for %s: %+v", key, got)
+		}
+	}
+	if _, err := c.GetLookupTable("empty"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for empty page, got %v", err)
+	}
+}
+
+func TestUpdateLookupAdapter_SendsID(t *testing.T) {
+	var body map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/system/lookup/adapters/a1" || r.Method != http.MethodPut {
+			w.WriteHeader(404)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	out, err := c.UpdateLookupAdapter("a1", &LookupDataAdapter{Name: "hosts", Title: "Hosts", Config: map[string]any{"type": "csvfile"}})
+	if err != nil {
+		t.Fatalf("UpdateLookupAdapter error: %v", err)
+	}
+	if body["id"] != "a1" || out.ID != "a1" {
+		t.Fatalf("expected id in PUT body, got %#v", body)
+	}
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetPipelineConnectionsForStream_NotFoundIsEmpty(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	conn, err := c.GetPipelineConnectionsForStream("s1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conn.StreamID != "s1" || len(conn.PipelineIDs) != 0 {
+		t.Fatalf("unexpected connection: %+v", conn)
+	}
+}
+
+func TestConnectPipelinesToStream_SendsFullSet(t *testing.T) {
+	var got map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/system/pipelines/connections/to_stream" || r.Method != http.MethodPost {
+			w.WriteHeader(404)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "c1", "stream_id": "s1", "pipeline_ids": got["pipeline_ids"]})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	// nil должен отправляться как пустой массив, а не null
+	out, err := c.ConnectPipelinesToStream("s1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ids, ok := got["pipeline_ids"].([]any)
+	if !ok || len(ids) != 0 {
+		t.Fatalf("expected empty pipeline_ids array, got %#v", got["pipeline_ids"])
+	}
+	if got["stream_id"] != "s1" || out.StreamID != "s1" {
+		t.Fatalf("unexpected request/response: %#v / %+v", got, out)
+	}
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreatePipelineRule_ParseErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/system/pipelines/rule" || r.Method != http.MethodPost {
+			w.WriteHeader(404)
+			return
+		}
+		w.WriteHeader(400)
+		_ = json.NewEncoder(w).Encode([]map[string]any{
+			{"type": "syntax_error", "line": 2, "position_in_line": 5, "message": "mismatched input 'then'"},
+		})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	_, err := c.CreatePipelineRule(&PipelineRule{Source: "rule \"r\"\nwhen then\nend"})
+	if err == nil {
+		t.Fatal("expected error on 400 response")
+	}
+	pes := PipelineRuleParseErrors(err)
+	if len(pes) != 1 {
+		t.Fatalf("expected 1 parse error, got %d (%v)", len(pes), err)
+	}
+	if pes[0].Line != 2 || pes[0].PositionInLine != 5 || pes[0].Text() != "mismatched input 'then'" {
+		t.Fatalf("unexpected parse error: %+v", pes[0])
+	}
+}
+
+func TestPipelineRuleParseErrors_NotGraylogError(t *testing.T) {
+	if pes := PipelineRuleParseErrors(ErrNotFound); pes != nil {
+		t.Fatalf("expected nil, got %+v", pes)
+	}
+	// Обычная ошибка Graylog без деталей парсинга
+	if pes := PipelineRuleParseErrors(ParseGraylogError(500, []byte(`{"message":"boom"}`))); len(pes) != 0 {
+		t.Fatalf("expected no parse errors, got %+v", pes)
+	}
+}
+
+func TestListPipelineRules_ArrayAndWrapped(t *testing.T) {
+	rules := []PipelineRule{{ID: "r1", Title: "one", Source: "rule \"one\" when true then end"}}
+	wrapped := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/system/pipelines/rule" {
+			w.WriteHeader(404)
+			return
+		}
+		if wrapped {
+			_ = json.NewEncoder(w).Encode(map[string]any{"rules": rules})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(rules)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	for _, w := range []bool{false, true} {
+		wrapped = w
+		out, err := c.ListPipelineRules()
+		if err != nil {
+			t.Fatalf("ListPipelineRules (wrapped=%v) error: %v", w, err)
+		}
+		if len(out) != 1 || out[0].ID != "r1" || out[0].Title != "one" {
+			t.Fatalf("unexpected rules (wrapped=%v): %+v", w, out)
+		}
+	}
+}
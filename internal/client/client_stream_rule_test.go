@@ -0,0 +1,71 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpdateStreamRule_PUTKeepsID(t *testing.T) {
+	var body map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/streams/s1/rules/r1" {
+			w.WriteHeader(404)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(map[string]any{"streamrule_id": "r1"})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	out, err := c.UpdateStreamRule("s1", "r1", &StreamRule{Field: "source", Type: 1, Value: "web"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.ID != "r1" {
+		t.Fatalf("expected rule ID r1, got %q", out.ID)
+	}
+	if inv, ok := body["inverted"]; !ok || inv != false {
+		t.Fatalf("expected inverted=false to be sent explicitly, got %+v", body)
+	}
+}
+
+func TestGetStreamRule_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	if _, err := c.GetStreamRule("s1", "r1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestTestStreamMatch(t *testing.T) {
+	var body map[string]map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/streams/s1/testMatch" {
+			w.WriteHeader(404)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(map[string]any{"matches": true, "rules": map[string]bool{"r1": true, "r2": false}})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	res, err := c.TestStreamMatch("s1", map[string]any{"source": "web-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.Matches || !res.Rules["r1"] || res.Rules["r2"] {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if body["message"]["source"] != "web-1" {
+		t.Fatalf("expected sample wrapped in 'message', got %+v", body)
+	}
+}
@@ -119,3 +119,35 @@ func TestCreateStream_V7AndLegacyBodies(t *testing.T) {
 		t.Fatalf("unexpected id: %+v", got)
 	}
 }
+
+func TestCloneStream_ResumesAndReadsBack(t *testing.T) {
+	var cloneBody map[string]any
+	var resumed bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/streams/src/clone":
+			_ = json.NewDecoder(r.Body).Decode(&cloneBody)
+			_ = json.NewEncoder(w).Encode(map[string]any{"stream_id": "new1"})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/streams/new1/resume":
+			resumed = true
+			w.WriteHeader(204)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/streams/new1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "new1", "title": "copy", "index_set_id": "is2", "disabled": !resumed})
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer ts.Close()
+
+	c := newStreamTestClient(ts.URL)
+	got, err := c.CloneStream("src", &Stream{Title: "copy", IndexSetID: "is2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != "new1" || got.Disabled || !resumed {
+		t.Fatalf("unexpected clone result: %+v (resumed=%v)", got, resumed)
+	}
+	if cloneBody["index_set_id"] != "is2" || cloneBody["title"] != "copy" {
+		t.Fatalf("unexpected clone request: %+v", cloneBody)
+	}
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetUserDisabled(t *testing.T) {
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/users/jdoe":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "u1", "username": "jdoe"})
+		case r.Method == http.MethodPut && (r.URL.Path == "/api/users/u1/status/disabled" || r.URL.Path == "/api/users/u1/status/enabled"):
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	if err := c.SetUserDisabled("jdoe", true); err != nil {
+		t.Fatalf("disable: %v", err)
+	}
+	if err := c.SetUserDisabled("jdoe", false); err != nil {
+		t.Fatalf("enable: %v", err)
+	}
+	if calls[1] != "PUT /api/users/u1/status/disabled" || calls[3] != "PUT /api/users/u1/status/enabled" {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+	if err := c.SetUserDisabled("ghost", true); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound for unknown user, got %v", err)
+	}
+}
+
+func TestGetUser_DecodesAccountStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/users/jdoe":
+			_, _ = w.Write([]byte(`{"id":"u1","username":"jdoe","account_status":"disabled"}`))
+		case "/api/users/asmith":
+			_, _ = w.Write([]byte(`{"id":"u2","username":"asmith","account_status":"enabled"}`))
+		case "/api/users/legacy":
+			_, _ = w.Write([]byte(`{"id":"u3","username":"legacy","disabled":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := newTestClient(ts.URL)
+	for name, want := range map[string]bool{"jdoe": true, "asmith": false, "legacy": true} {
+		u, err := c.GetUser(name)
+		if err != nil {
+			t.Fatalf("get %s: %v", name, err)
+		}
+		if u.Disabled != want || u.Username != name {
+			t.Fatalf("%s: got %+v, want disabled=%v", name, u, want)
+		}
+	}
+}
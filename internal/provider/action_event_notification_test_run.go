@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/actionvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// graylog_event_notification_test — тестовая отправка уведомления (сохранённого или по конфигу).
+// Это action: уведомление отправляется только при явном вызове, а не при каждом plan/refresh.
+type eventNotificationTestAction struct{ client *client.Client }
+
+type eventNotificationTestModel struct {
+	NotificationID types.String `tfsdk:"notification_id"`
+	Title          types.String `tfsdk:"title"`
+	Type           types.String `tfsdk:"type"`
+	Config         types.String `tfsdk:"config"`
+}
+
+func NewEventNotificationTestAction() action.Action {
+	return &eventNotificationTestAction{}
+}
+
+func (a *eventNotificationTestAction) Metadata(_ context.Context, _ action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = "graylog_event_notification_test"
+}
+
+func (a *eventNotificationTestAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Sends a test event through a saved notification or an unsaved notification config and fails when Graylog does not deliver it. The notification only fires when the action is invoked.",
+		Attributes: map[string]schema.Attribute{
+			"notification_id": schema.StringAttribute{Optional: true, Description: "Saved notification to test"},
+			"title":           schema.StringAttribute{Optional: true, Description: "Title of the unsaved notification (default: Terraform notification test)"},
+			"type":            schema.StringAttribute{Optional: true, Description: "Type of the unsaved notification (email/http/slack/teams/pagerduty/script); derived from config.type when omitted"},
+			"config":          schema.StringAttribute{Optional: true, Description: "JSON-encoded config of an unsaved notification to test"},
+		},
+	}
+}
+
+func (a *eventNotificationTestAction) ConfigValidators(_ context.Context) []action.ConfigValidator {
+	return []action.ConfigValidator{
+		actionvalidator.ExactlyOneOf(path.MatchRoot("notification_id"), path.MatchRoot("config")),
+		actionvalidator.Conflicting(path.MatchRoot("notification_id"), path.MatchRoot("title")),
+		actionvalidator.Conflicting(path.MatchRoot("notification_id"), path.MatchRoot("type")),
+	}
+}
+
+func (a *eventNotificationTestAction) Configure(_ context.Context, req action.ConfigureRequest, _ *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	a.client = req.ProviderData.(*client.Client)
+}
+
+func (a *eventNotificationTestAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data eventNotificationTestModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	c := a.client.WithContext(ctx)
+
+	var (
+		res *client.NotificationTestResult
+		err error
+	)
+	if id := data.NotificationID.ValueString(); id != "" {
+		res, err = c.TestEventNotification(id)
+	} else {
+		n, diags := unsavedTestNotification(&data)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		res, err = c.TestEventNotificationConfig(n)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to test event notification", err.Error())
+		return
+	}
+	if !res.Success {
+		resp.Diagnostics.AddError("Event notification test failed", fmt.Sprintf("Graylog answered with status %d: %s", res.Status, res.Message))
+		return
+	}
+	if resp.SendProgress != nil {
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Test notification sent (status %d)", res.Status)})
+	}
+}
+
+// unsavedTestNotification builds the notification to test from the config, title and type arguments.
+func unsavedTestNotification(m *eventNotificationTestModel) (*client.EventNotification, diag.Diagnostics) {
+	var d diag.Diagnostics
+	var cfg map[string]interface{}
+	if err := json.Unmarshal([]byte(m.Config.ValueString()), &cfg); err != nil {
+		d.AddAttributeError(path.Root("config"), "Invalid JSON", err.Error())
+		return nil, d
+	}
+	typ := m.Type.ValueString()
+	if typ == "" {
+		t, _ := cfg["type"].(string)
+		typ = client.NotificationShortType(t)
+	}
+	if typ == "" {
+		d.AddAttributeError(path.Root("type"), "Missing type", "Set 'type' or a 'type' key in 'config'.")
+		return nil, d
+	}
+	title := m.Title.ValueString()
+	if title == "" {
+		title = "Terraform notification test"
+	}
+	return &client.EventNotification{Title: title, Type: typ, Config: cfg}, d
+}
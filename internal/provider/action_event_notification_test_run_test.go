@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEventNotificationTestAction_Schema(t *testing.T) {
+	a := NewEventNotificationTestAction()
+	var resp action.SchemaResponse
+	a.Schema(context.Background(), action.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("schema diagnostics: %v", resp.Diagnostics)
+	}
+	for _, name := range []string{"notification_id", "config", "title", "type"} {
+		if _, ok := resp.Schema.Attributes[name]; !ok {
+			t.Fatalf("missing attribute %q", name)
+		}
+	}
+	if d := resp.Schema.ValidateImplementation(context.Background()); d.HasError() {
+		t.Fatalf("invalid schema: %v", d)
+	}
+}
+
+func TestUnsavedTestNotification(t *testing.T) {
+	n, d := unsavedTestNotification(&eventNotificationTestModel{
+		Config: types.StringValue(`{"type":"http-notification-v1","url":"https://hooks.example.com"}`),
+	})
+	if d.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", d)
+	}
+	if n.Type != "http" || n.Title != "Terraform notification test" || n.Config["url"] != "https://hooks.example.com" {
+		t.Fatalf("unexpected notification %+v", n)
+	}
+
+	if _, d := unsavedTestNotification(&eventNotificationTestModel{Config: types.StringValue(`{"url":"x"}`)}); !d.HasError() {
+		t.Fatal("expected an error without a type")
+	}
+	if _, d := unsavedTestNotification(&eventNotificationTestModel{Config: types.StringValue(`{`)}); !d.HasError() {
+		t.Fatal("expected an error for invalid JSON")
+	}
+}
@@ -0,0 +1,53 @@
+package provider
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// Helpers that read values of a Graylog config map (notification and authentication backend
+// configs) back into optional attributes. A missing key keeps the prior value, and a server
+// default stays null when the attribute was null.
+
+// readCfgString returns the server value for key; a missing key keeps prev, see keepNullString.
+func readCfgString(prev types.String, cfg map[string]interface{}, key, def string) types.String {
+	v, ok := cfg[key]
+	if !ok || v == nil {
+		return prev
+	}
+	return keepNullString(prev, toString(v), def)
+}
+
+func readCfgBool(prev types.Bool, cfg map[string]interface{}, key string, def bool) types.Bool {
+	b, ok := cfg[key].(bool)
+	if !ok {
+		return prev
+	}
+	if prev.IsNull() && b == def {
+		return types.BoolNull()
+	}
+	return types.BoolValue(b)
+}
+
+func readCfgInt64(prev types.Int64, cfg map[string]interface{}, key string, def int64) types.Int64 {
+	v := cfgInt64(cfg, key)
+	if v.IsNull() {
+		return prev
+	}
+	if prev.IsNull() && v.ValueInt64() == def {
+		return types.Int64Null()
+	}
+	return v
+}
+
+func readCfgStrings(prev []types.String, cfg map[string]interface{}, key string) []types.String {
+	raw, ok := cfg[key].([]interface{})
+	if !ok {
+		return prev
+	}
+	if prev == nil && len(raw) == 0 {
+		return nil
+	}
+	out := make([]types.String, 0, len(raw))
+	for _, v := range raw {
+		out = append(out, types.StringValue(toString(v)))
+	}
+	return out
+}
@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// graylog_authentication_backend_test — проверка подключения и логина через authentication backend
+type authBackendTestDataSource struct{ client *client.Client }
+
+type authBackendTestModel struct {
+	BackendID         types.String            `tfsdk:"backend_id"`
+	LDAP              *authBackendLDAPModel   `tfsdk:"ldap"`
+	ActiveDirectory   *authBackendLDAPModel   `tfsdk:"active_directory"`
+	Username          types.String            `tfsdk:"username"`
+	Password          types.String            `tfsdk:"password"`
+	ConnectionSuccess types.Bool              `tfsdk:"connection_success"`
+	ConnectionMessage types.String            `tfsdk:"connection_message"`
+	UserExists        types.Bool              `tfsdk:"user_exists"`
+	LoginSuccess      types.Bool              `tfsdk:"login_success"`
+	LoginMessage      types.String            `tfsdk:"login_message"`
+	UserAttributes    map[string]types.String `tfsdk:"user_attributes"`
+}
+
+func NewAuthBackendTestDataSource() datasource.DataSource { return &authBackendTestDataSource{} }
+
+func (d *authBackendTestDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "graylog_authentication_backend_test"
+}
+
+// authBackendTestBlock mirrors the graylog_authentication_backend blocks for data source schemas.
+func authBackendTestBlock(desc string, d authBackendDefaults) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: desc,
+		Attributes: map[string]schema.Attribute{
+			"servers": schema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Directory servers as host:port"},
+			"transport_security": schema.StringAttribute{
+				Optional:    true,
+				Description: "none | tls | start_tls (default tls)",
+				Validators:  []validator.String{stringvalidator.OneOf("none", "tls", "start_tls")},
+			},
+			"verify_certificates":      schema.BoolAttribute{Optional: true, Description: "Verify server certificates (default true)"},
+			"system_user_dn":           schema.StringAttribute{Optional: true, Description: "Bind DN of the system user"},
+			"system_user_password":     schema.StringAttribute{Optional: true, Sensitive: true, Description: "Password of the system user; with backend_id, the saved password is used when unset"},
+			"user_search_base":         schema.StringAttribute{Optional: true, Description: "Base DN for user searches"},
+			"user_search_pattern":      schema.StringAttribute{Optional: true, Description: "User search filter (default " + d.searchPattern + ")"},
+			"user_name_attribute":      schema.StringAttribute{Optional: true, Description: "User name attribute (default " + d.nameAttr + ")"},
+			"user_full_name_attribute": schema.StringAttribute{Optional: true, Description: "Full name attribute (default " + d.fullNameAttr + ")"},
+			"user_unique_id_attribute": schema.StringAttribute{Optional: true, Description: "Unique ID attribute (default " + d.uniqueIDAttr + ")"},
+			"email_attributes":         schema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Email attributes (Graylog 5+)"},
+		},
+	}
+}
+
+func (d *authBackendTestDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Tests an authentication backend (saved or given inline) through Graylog: connection/bind and, with a username, user lookup and login.",
+		Attributes: map[string]schema.Attribute{
+			"backend_id": schema.StringAttribute{Optional: true, Description: "Saved backend to test; an inline block overrides its config"},
+			"username":   schema.StringAttribute{Optional: true, Description: "User to look up and log in with"},
+			"password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password of username; without it only the user lookup is meaningful",
+				Validators:  []validator.String{stringvalidator.AlsoRequires(path.MatchRoot("username"))},
+			},
+			"connection_success": schema.BoolAttribute{Computed: true, Description: "Whether Graylog could connect and bind"},
+			"connection_message": schema.StringAttribute{Computed: true, Description: "Connection test message and errors"},
+			"user_exists":        schema.BoolAttribute{Computed: true, Description: "Whether username was found (null without username)"},
+			"login_success":      schema.BoolAttribute{Computed: true, Description: "Whether username could log in (null without username)"},
+			"login_message":      schema.StringAttribute{Computed: true, Description: "Login test message and errors"},
+			"user_attributes":    schema.MapAttribute{Computed: true, ElementType: types.StringType, Description: "Attributes of the resolved user (dn, username, full_name, email, ...)"},
+		},
+		Blocks: map[string]schema.Block{
+			"ldap":             authBackendTestBlock("Inline LDAP backend config", authBackendLDAPDefaults),
+			"active_directory": authBackendTestBlock("Inline Active Directory backend config", authBackendADDefaults),
+		},
+	}
+}
+
+func (d *authBackendTestDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.AtLeastOneOf(path.MatchRoot("backend_id"), path.MatchRoot("ldap"), path.MatchRoot("active_directory")),
+		datasourcevalidator.Conflicting(path.MatchRoot("ldap"), path.MatchRoot("active_directory")),
+	}
+}
+
+func (d *authBackendTestDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*client.Client)
+}
+
+func (d *authBackendTestDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data authBackendTestModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	c := d.client.WithContext(ctx)
+	id := data.BackendID.ValueString()
+
+	var backend *client.AuthBackend
+	switch {
+	case data.LDAP != nil || data.ActiveDirectory != nil:
+		m, def := data.LDAP, authBackendLDAPDefaults
+		p := path.Root("ldap")
+		if data.ActiveDirectory != nil {
+			m, def, p = data.ActiveDirectory, authBackendADDefaults, path.Root("active_directory")
+		}
+		if len(m.Servers) == 0 {
+			resp.Diagnostics.AddAttributeError(p.AtName("servers"), "Missing servers", "Inline backend config needs at least one server.")
+		}
+		for i, s := range m.Servers {
+			if _, _, err := splitServer(s.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(p.AtName("servers").AtListIndex(i), "Invalid server", "Servers must be host:port: "+err.Error())
+			}
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		cfg := m.toConfig(def)
+		if _, ok := cfg["system_user_password"]; !ok && id != "" {
+			cfg["system_user_password"] = map[string]interface{}{"keep_value": true}
+		}
+		backend = &client.AuthBackend{Title: "Terraform backend test", DefaultRoles: []string{}, Config: cfg}
+	default:
+		saved, err := c.GetAuthBackend(id)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("backend_id"), "Unable to read authentication backend", err.Error())
+			return
+		}
+		backend = keepSavedSecrets(saved)
+	}
+
+	conn, err := c.TestAuthBackendConnection(id, backend)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to test authentication backend connection", err.Error())
+		return
+	}
+	data.ConnectionSuccess = types.BoolValue(conn.Success)
+	data.ConnectionMessage = types.StringValue(authBackendTestMessage(conn))
+	data.UserExists = types.BoolNull()
+	data.LoginSuccess = types.BoolNull()
+	data.LoginMessage = types.StringNull()
+	data.UserAttributes = nil
+
+	if u := data.Username.ValueString(); u != "" {
+		login, err := c.TestAuthBackendLogin(id, backend, u, data.Password.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to test authentication backend login", err.Error())
+			return
+		}
+		exists, _ := login.Result["user_exists"].(bool)
+		ok, _ := login.Result["login_success"].(bool)
+		data.UserExists = types.BoolValue(exists)
+		data.LoginSuccess = types.BoolValue(login.Success && ok)
+		data.LoginMessage = types.StringValue(authBackendTestMessage(login))
+		details, _ := login.Result["user_details"].(map[string]interface{})
+		data.UserAttributes = flattenUserDetails(details)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// keepSavedSecrets replaces encrypted values of a saved backend ({"is_set": true}) with keep_value,
+// so Graylog uses the stored secrets for the test.
+func keepSavedSecrets(b *client.AuthBackend) *client.AuthBackend {
+	cfg := make(map[string]interface{}, len(b.Config))
+	for k, v := range b.Config {
+		if enc, ok := v.(map[string]interface{}); ok {
+			if _, encrypted := enc["is_set"]; encrypted {
+				v = map[string]interface{}{"keep_value": true}
+			}
+		}
+		cfg[k] = v
+	}
+	out := *b
+	out.Config = cfg
+	return &out
+}
+
+func authBackendTestMessage(r *client.AuthBackendTestResult) string {
+	if len(r.Errors) == 0 {
+		return r.Message
+	}
+	return strings.TrimSpace(r.Message + " " + strings.Join(r.Errors, "; "))
+}
+
+// flattenUserDetails turns user_details into a flat string map. Nested objects (e.g. the raw LDAP
+// entry) contribute their keys unless a top-level key of the same name exists; when several nested objects
+// share a key, the one under the alphabetically first top-level key wins. Lists are joined with ", ".
+func flattenUserDetails(details map[string]interface{}) map[string]types.String {
+	if details == nil {
+		return nil
+	}
+	top := make([]string, 0, len(details))
+	for k := range details {
+		top = append(top, k)
+	}
+	sort.Strings(top)
+	out := map[string]types.String{}
+	var nested []map[string]interface{}
+	for _, k := range top {
+		switch t := details[k].(type) {
+		case nil:
+		case map[string]interface{}:
+			nested = append(nested, t)
+		default:
+			out[k] = types.StringValue(userDetailString(t))
+		}
+	}
+	for _, n := range nested {
+		keys := make([]string, 0, len(n))
+		for k := range n {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if _, exists := out[k]; !exists && n[k] != nil {
+				out[k] = types.StringValue(userDetailString(n[k]))
+			}
+		}
+	}
+	return out
+}
+
+func userDetailString(v interface{}) string {
+	if list, ok := v.([]interface{}); ok {
+		parts := make([]string, 0, len(list))
+		for _, e := range list {
+			parts = append(parts, fmt.Sprint(e))
+		}
+		return strings.Join(parts, ", ")
+	}
+	return toString(v)
+}
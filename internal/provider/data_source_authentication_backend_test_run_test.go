@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestAuthBackendTestDataSource_Schema(t *testing.T) {
+	ds := NewAuthBackendTestDataSource()
+	var resp datasource.SchemaResponse
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("schema diagnostics: %v", resp.Diagnostics)
+	}
+	for _, name := range []string{"backend_id", "username", "password", "connection_success", "login_success", "user_attributes"} {
+		if _, ok := resp.Schema.Attributes[name]; !ok {
+			t.Fatalf("missing attribute %q", name)
+		}
+	}
+	for _, name := range []string{"ldap", "active_directory"} {
+		if _, ok := resp.Schema.Blocks[name]; !ok {
+			t.Fatalf("missing block %q", name)
+		}
+	}
+}
+
+func TestFlattenUserDetails(t *testing.T) {
+	got := flattenUserDetails(map[string]interface{}{
+		"username":  "jdoe",
+		"email":     "jdoe@example.com",
+		"is_active": true,
+		"groups":    []interface{}{"ops", "dev"},
+		"entry":     map[string]interface{}{"dn": "uid=jdoe,dc=example,dc=com", "username": "ignored", "mail": nil},
+		"full_name": nil,
+	})
+	want := map[string]string{
+		"username":  "jdoe",
+		"email":     "jdoe@example.com",
+		"is_active": "true",
+		"groups":    "ops, dev",
+		"dn":        "uid=jdoe,dc=example,dc=com",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected attributes: %v", got)
+	}
+	for k, v := range want {
+		if got[k].ValueString() != v {
+			t.Fatalf("%s: expected %q, got %q", k, v, got[k].ValueString())
+		}
+	}
+	for i := 0; i < 20; i++ {
+		got = flattenUserDetails(map[string]interface{}{
+			"b_entry": map[string]interface{}{"cn": "second"},
+			"a_entry": map[string]interface{}{"cn": "first"},
+		})
+		if got["cn"].ValueString() != "first" {
+			t.Fatalf("nested keys must merge in top-level key order, got %q", got["cn"].ValueString())
+		}
+	}
+	if flattenUserDetails(nil) != nil {
+		t.Fatalf("no details must give null attributes")
+	}
+}
+
+func TestKeepSavedSecrets(t *testing.T) {
+	saved := &client.AuthBackend{ID: "b1", Config: map[string]interface{}{
+		"type":                 "ldap",
+		"system_user_password": map[string]interface{}{"is_set": true},
+	}}
+	got := keepSavedSecrets(saved)
+	if pw, _ := got.Config["system_user_password"].(map[string]interface{}); pw["keep_value"] != true {
+		t.Fatalf("expected keep_value for the saved password, got %+v", got.Config)
+	}
+	if _, ok := saved.Config["system_user_password"].(map[string]interface{})["is_set"]; !ok {
+		t.Fatalf("saved backend must not be modified")
+	}
+}
@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// graylog_input_types — каталог классов инпутов и их requested_configuration
+type inputTypesDataSource struct{ client *client.Client }
+
+type inputTypesModel struct {
+	Type  types.String `tfsdk:"type"`
+	Types types.List   `tfsdk:"types"`
+}
+
+var inputTypeFieldAttrTypes = map[string]attr.Type{
+	"name":        types.StringType,
+	"type":        types.StringType,
+	"human_name":  types.StringType,
+	"description": types.StringType,
+	"default":     types.StringType,
+	"optional":    types.BoolType,
+}
+
+var inputTypeAttrTypes = map[string]attr.Type{
+	"class":        types.StringType,
+	"name":         types.StringType,
+	"is_exclusive": types.BoolType,
+	"link_to_docs": types.StringType,
+	"fields":       types.ListType{ElemType: types.ObjectType{AttrTypes: inputTypeFieldAttrTypes}},
+}
+
+func NewInputTypesDataSource() datasource.DataSource { return &inputTypesDataSource{} }
+
+func (d *inputTypesDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "graylog_input_types"
+}
+
+func (d *inputTypesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the input types available on the Graylog server together with the configuration fields each type accepts.",
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{Optional: true, Description: "Return only this input class (e.g. org.graylog2.inputs.syslog.udp.SyslogUDPInput)."},
+			"types": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Input types sorted by class.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"class":        schema.StringAttribute{Computed: true, Description: "Input class, used as graylog_input.type"},
+						"name":         schema.StringAttribute{Computed: true, Description: "Display name"},
+						"is_exclusive": schema.BoolAttribute{Computed: true, Description: "Whether only one input of this type may exist"},
+						"link_to_docs": schema.StringAttribute{Computed: true},
+						"fields": schema.ListNestedAttribute{
+							Computed:    true,
+							Description: "Requested configuration fields sorted by name.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name":        schema.StringAttribute{Computed: true, Description: "Configuration key"},
+									"type":        schema.StringAttribute{Computed: true, Description: "Field type (text, number, boolean, dropdown, list)"},
+									"human_name":  schema.StringAttribute{Computed: true},
+									"description": schema.StringAttribute{Computed: true},
+									"default":     schema.StringAttribute{Computed: true, Description: "Default value; strings as-is, other values JSON-encoded. Null when there is no default."},
+									"optional":    schema.BoolAttribute{Computed: true, Description: "Whether the field may be omitted"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *inputTypesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*client.Client)
+}
+
+func (d *inputTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data inputTypesModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	c := d.client.WithContext(ctx)
+	infos := map[string]client.InputTypeInfo{}
+	if class := data.Type.ValueString(); class != "" {
+		info, err := c.GetInputType(class)
+		if err != nil {
+			if errors.Is(err, client.ErrNotFound) {
+				resp.Diagnostics.AddAttributeError(path.Root("type"), "Input type not found", "Input type '"+class+"' is not available on this Graylog server.")
+				return
+			}
+			resp.Diagnostics.AddError("Unable to read input type", err.Error())
+			return
+		}
+		infos[class] = *info
+	} else {
+		all, err := c.ListInputTypeInfos()
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to list input types", err.Error())
+			return
+		}
+		infos = all
+	}
+	list, diags := flattenInputTypes(infos)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Types = list
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func flattenInputTypes(infos map[string]client.InputTypeInfo) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	fieldType := types.ObjectType{AttrTypes: inputTypeFieldAttrTypes}
+	classes := make([]string, 0, len(infos))
+	for k := range infos {
+		classes = append(classes, k)
+	}
+	sort.Strings(classes)
+	vals := make([]attr.Value, 0, len(classes))
+	for _, class := range classes {
+		info := infos[class]
+		fieldVals := make([]attr.Value, 0, len(info.RequestedConfiguration))
+		for _, name := range inputConfigFieldNames(&info) {
+			f := info.RequestedConfiguration[name]
+			def := types.StringNull()
+			if f.DefaultValue != nil {
+				def = types.StringValue(inputConfigDefaultString(f.DefaultValue))
+			}
+			obj, di := types.ObjectValue(inputTypeFieldAttrTypes, map[string]attr.Value{
+				"name":        types.StringValue(name),
+				"type":        types.StringValue(f.Type),
+				"human_name":  types.StringValue(f.HumanName),
+				"description": types.StringValue(f.Description),
+				"default":     def,
+				"optional":    types.BoolValue(f.IsOptional),
+			})
+			diags.Append(di...)
+			fieldVals = append(fieldVals, obj)
+		}
+		fields, di := types.ListValue(fieldType, fieldVals)
+		diags.Append(di...)
+		obj, di := types.ObjectValue(inputTypeAttrTypes, map[string]attr.Value{
+			"class":        types.StringValue(class),
+			"name":         types.StringValue(info.Name),
+			"is_exclusive": types.BoolValue(info.IsExclusive),
+			"link_to_docs": types.StringValue(info.LinkToDocs),
+			"fields":       fields,
+		})
+		diags.Append(di...)
+		vals = append(vals, obj)
+	}
+	list, di := types.ListValue(types.ObjectType{AttrTypes: inputTypeAttrTypes}, vals)
+	diags.Append(di...)
+	return list, diags
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var testSyslogUDPType = &client.InputTypeInfo{
+	Type: "org.graylog2.inputs.syslog.udp.SyslogUDPInput",
+	Name: "Syslog UDP",
+	RequestedConfiguration: map[string]client.InputConfigField{
+		"port":             {Type: "number", DefaultValue: float64(514)},
+		"bind_address":     {Type: "text", DefaultValue: "0.0.0.0"},
+		"recv_buffer_size": {Type: "number", IsOptional: true},
+	},
+}
+
+func TestInputTypesDataSource_New(t *testing.T) {
+	if NewInputTypesDataSource() == nil {
+		t.Fatal("NewInputTypesDataSource returned nil")
+	}
+}
+
+func TestFlattenInputTypes(t *testing.T) {
+	list, diags := flattenInputTypes(map[string]client.InputTypeInfo{testSyslogUDPType.Type: *testSyslogUDPType})
+	if diags.HasError() {
+		t.Fatalf("unexpected diags: %v", diags)
+	}
+	if len(list.Elements()) != 1 {
+		t.Fatalf("expected 1 type, got %d", len(list.Elements()))
+	}
+	fields := list.Elements()[0].(types.Object).Attributes()["fields"].(types.List).Elements()
+	if len(fields) != 3 {
+		t.Fatalf("expected 3 fields, got %d", len(fields))
+	}
+	// Sorted by name: bind_address, port, recv_buffer_size
+	port := fields[1].(types.Object).Attributes()
+	if port["name"].(types.String).ValueString() != "port" || port["default"].(types.String).ValueString() != "514" || port["optional"].(types.Bool).ValueBool() {
+		t.Fatalf("unexpected port field: %v", port)
+	}
+	if !fields[2].(types.Object).Attributes()["default"].IsNull() {
+		t.Fatal("expected null default for field without default_value")
+	}
+}
+
+func TestValidateInputConfigAgainstCatalog(t *testing.T) {
+	p := path.Root("configuration")
+	ok := map[string]interface{}{"port": 5514, "bind_address": "0.0.0.0"}
+	if diags := validateInputConfigAgainstCatalog(testSyslogUDPType, ok, p); diags.HasError() {
+		t.Fatalf("unexpected diags: %v", diags)
+	}
+
+	typo := map[string]interface{}{"prot": 5514, "bind_address": "0.0.0.0"}
+	diags := validateInputConfigAgainstCatalog(testSyslogUDPType, typo, p)
+	if diags.WarningsCount() != 1 || diags.ErrorsCount() != 1 {
+		t.Fatalf("expected unknown key warning and missing field error, got %v", diags)
+	}
+	if !strings.Contains(diags.Warnings()[0].Detail(), "'prot'") || !strings.Contains(diags.Errors()[0].Detail(), "'port'") {
+		t.Fatalf("unexpected diags: %v", diags)
+	}
+
+	// A key missing from the catalog (plugin, other version) does not fail the plan
+	extra := map[string]interface{}{"port": 5514, "bind_address": "0.0.0.0", "plugin_option": true}
+	if diags := validateInputConfigAgainstCatalog(testSyslogUDPType, extra, p); diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("expected only a warning for an unknown key, got %v", diags)
+	}
+}
+
+func TestValidateInput_Catalog(t *testing.T) {
+	data := &inputModel{
+		Title:         types.StringValue("syslog"),
+		Type:          types.StringValue(testSyslogUDPType.Type),
+		Global:        types.BoolValue(true),
+		Configuration: types.StringValue(`{"bind_address":"0.0.0.0"}`),
+	}
+	if diags := validateInput(context.Background(), data, nil); diags.HasError() {
+		t.Fatalf("unexpected diags without catalog: %v", diags)
+	}
+	if diags := validateInput(context.Background(), data, testSyslogUDPType); !diags.HasError() {
+		t.Fatal("expected missing port to be reported with catalog")
+	}
+}
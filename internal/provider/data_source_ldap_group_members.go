@@ -4,11 +4,15 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"strconv"
 	"strings"
 
 	ldap "github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -32,6 +36,12 @@ type ldapGroupMembersModel struct {
 	EmailAttr       types.String `tfsdk:"email_attr"`        // mail
 	DisplayNameAttr types.String `tfsdk:"display_name_attr"` // cn or displayName
 
+	// Group schema and nested membership
+	GroupSchema         types.String `tfsdk:"group_schema"`           // groupOfNames | groupOfUniqueNames | posixGroup
+	Recursive           types.Bool   `tfsdk:"recursive"`              // walk nested groups
+	MaxDepth            types.Int64  `tfsdk:"max_depth"`              // nesting limit (default 10)
+	MatchingRuleInChain types.Bool   `tfsdk:"matching_rule_in_chain"` // AD: resolve nesting server-side
+
 	Members []ldapMember `tfsdk:"members"`
 }
 
@@ -62,12 +72,17 @@ func (d *ldapGroupMembersDataSource) Schema(_ context.Context, _ datasource.Sche
 			"group_name":           schema.StringAttribute{Required: true, Description: "Group common name (cn) to lookup"},
 
 			"group_filter":      schema.StringAttribute{Optional: true, Description: "Group search filter with %s placeholder for group name (default: (cn=%s))"},
-			"member_attr":       schema.StringAttribute{Optional: true, Description: "Attribute holding member DN (default: member)"},
+			"member_attr":       schema.StringAttribute{Optional: true, Description: "Attribute holding members (default: per group_schema, member)"},
 			"user_filter":       schema.StringAttribute{Optional: true, Description: "Filter to apply to user objects (default: (objectClass=inetOrgPerson))"},
 			"user_id_attr":      schema.StringAttribute{Optional: true, Description: "User ID attribute to emit as username (default: uid)"},
 			"email_attr":        schema.StringAttribute{Optional: true, Description: "Email attribute (default: mail)"},
 			"display_name_attr": schema.StringAttribute{Optional: true, Description: "Display name attribute (default: cn)"},
 
+			"group_schema":           ldapGroupSchemaAttribute(),
+			"recursive":              schema.BoolAttribute{Optional: true, Description: ldapRecursiveDescription},
+			"max_depth":              schema.Int64Attribute{Optional: true, Description: ldapMaxDepthDescription, Validators: []validator.Int64{int64validator.AtLeast(1)}},
+			"matching_rule_in_chain": schema.BoolAttribute{Optional: true, Description: ldapMatchingRuleInChainDescription},
+
 			"members": schema.ListNestedAttribute{
 				Computed:    true,
 				Description: "Resolved group members",
@@ -84,6 +99,20 @@ func (d *ldapGroupMembersDataSource) Schema(_ context.Context, _ datasource.Sche
 	}
 }
 
+const (
+	ldapRecursiveDescription           = "Walk nested groups (member DNs that are groups) with cycle detection"
+	ldapMaxDepthDescription            = "Maximum nesting depth for recursive (default 10); deeper nesting is an error"
+	ldapMatchingRuleInChainDescription = "Active Directory: resolve direct and nested members server-side with LDAP_MATCHING_RULE_IN_CHAIN (memberOf:1.2.840.113556.1.4.1941:=)"
+)
+
+func ldapGroupSchemaAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: "Group object schema: groupOfNames (member) | groupOfUniqueNames (uniqueMember) | posixGroup (memberUid); sets the default member_attr (default: groupOfNames)",
+		Validators:  []validator.String{stringvalidator.OneOf("groupOfNames", "groupOfUniqueNames", "posixGroup")},
+	}
+}
+
 func (d *ldapGroupMembersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data ldapGroupMembersModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -95,81 +124,342 @@ func (d *ldapGroupMembersDataSource) Read(ctx context.Context, req datasource.Re
 		resp.Diagnostics.AddError("missing url", "'url' is required")
 		return
 	}
-	conn, err := ldap.DialURL(url)
+	search := ldapSearchSettings{
+		URL:             url,
+		StartTLS:        !data.StartTLS.IsNull() && data.StartTLS.ValueBool(),
+		Insecure:        !data.Insecure.IsNull() && data.Insecure.ValueBool(),
+		BindDN:          data.BindDN.ValueString(),
+		BindPassword:    data.Password.ValueString(),
+		BaseDN:          data.BaseDN.ValueString(),
+		GroupFilter:     getString(data.GroupFilter),
+		MemberAttr:      getString(data.MemberAttr),
+		UserFilter:      getString(data.UserFilter),
+		UserIDAttr:      getString(data.UserIDAttr),
+		EmailAttr:       getString(data.EmailAttr),
+		DisplayNameAttr: getString(data.DisplayNameAttr),
+
+		GroupSchema:         getString(data.GroupSchema),
+		Recursive:           getBool(data.Recursive, false),
+		MaxDepth:            int(data.MaxDepth.ValueInt64()),
+		MatchingRuleInChain: getBool(data.MatchingRuleInChain, false),
+	}
+	conn, err := search.dial()
 	if err != nil {
-		resp.Diagnostics.AddError("ldap connect failed", err.Error())
+		resp.Diagnostics.AddError("ldap connection failed", err.Error())
 		return
 	}
 	defer conn.Close()
-	if !data.StartTLS.IsNull() && data.StartTLS.ValueBool() {
-		tlsConf := &tls.Config{InsecureSkipVerify: !data.Insecure.IsNull() && data.Insecure.ValueBool()}
-		if err := conn.StartTLS(tlsConf); err != nil {
-			resp.Diagnostics.AddError("ldap starttls failed", err.Error())
-			return
+	members, err := search.groupMembers(conn, data.GroupName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("ldap search group failed", err.Error())
+		return
+	}
+	data.Members = members
+	data.ID = types.StringValue(data.GroupName.ValueString() + "@" + data.BaseDN.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// ldapSearchSettings holds the connection and attribute mapping shared by graylog_ldap_group_members
+// and graylog_ldap_group_sync. Empty mapping fields fall back to the groupOfNames defaults.
+type ldapSearchSettings struct {
+	URL          string
+	StartTLS     bool
+	Insecure     bool
+	BindDN       string
+	BindPassword string
+	BaseDN       string
+
+	GroupFilter     string
+	MemberAttr      string
+	UserFilter      string
+	UserIDAttr      string
+	EmailAttr       string
+	DisplayNameAttr string
+
+	GroupSchema         string // groupOfNames (default) | groupOfUniqueNames | posixGroup
+	Recursive           bool
+	MaxDepth            int
+	MatchingRuleInChain bool
+}
+
+// dial connects, optionally upgrades to TLS and binds.
+func (s ldapSearchSettings) dial() (*ldap.Conn, error) {
+	conn, err := ldap.DialURL(s.URL)
+	if err != nil {
+		return nil, fmt.Errorf("ldap connect failed: %w", err)
+	}
+	if s.StartTLS {
+		if err := conn.StartTLS(&tls.Config{InsecureSkipVerify: s.Insecure}); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("ldap starttls failed: %w", err)
 		}
 	}
-	if err := conn.Bind(data.BindDN.ValueString(), data.Password.ValueString()); err != nil {
-		resp.Diagnostics.AddError("ldap bind failed", err.Error())
-		return
+	if err := conn.Bind(s.BindDN, s.BindPassword); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("ldap bind failed: %w", err)
+	}
+	return conn, nil
+}
+
+const (
+	ldapPageSize        = 500
+	ldapMaxDepthDefault = 10
+	// LDAP_MATCHING_RULE_IN_CHAIN (Active Directory): matches members of nested groups server-side
+	ldapMatchingRuleInChain = "1.2.840.113556.1.4.1941"
+	ldapNestedGroupFilter   = "(|(objectClass=group)(objectClass=groupOfNames)(objectClass=groupOfUniqueNames))"
+)
+
+// ldapSearcher is the part of *ldap.Conn used to resolve group members.
+type ldapSearcher interface {
+	Search(searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error)
+	SearchWithPaging(searchRequest *ldap.SearchRequest, pagingSize uint32) (*ldap.SearchResult, error)
+}
+
+func (s ldapSearchSettings) memberAttr() string {
+	if s.MemberAttr != "" {
+		return s.MemberAttr
+	}
+	switch s.GroupSchema {
+	case "groupOfUniqueNames":
+		return "uniqueMember"
+	case "posixGroup":
+		return "memberUid"
+	}
+	return "member"
+}
+
+func (s ldapSearchSettings) maxDepth() int {
+	if s.MaxDepth > 0 {
+		return s.MaxDepth
 	}
-	groupFilter := firstNonEmpty(getString(data.GroupFilter), "(cn=%s)")
-	memberAttr := firstNonEmpty(getString(data.MemberAttr), "member")
-	userFilter := firstNonEmpty(getString(data.UserFilter), "(objectClass=inetOrgPerson)")
-	userIDAttr := firstNonEmpty(getString(data.UserIDAttr), "uid")
-	emailAttr := firstNonEmpty(getString(data.EmailAttr), "mail")
-	dnAttr := "dn"
-	displayNameAttr := firstNonEmpty(getString(data.DisplayNameAttr), "cn")
+	return ldapMaxDepthDefault
+}
+
+// groupMembers resolves the members of the group with the given name. Members that cannot be read or do
+// not match the user filter are skipped; an unknown group has no members. Nested groups are walked when
+// Recursive is set, or resolved by the server with MatchingRuleInChain.
+func (s ldapSearchSettings) groupMembers(conn ldapSearcher, groupName string) ([]ldapMember, error) {
+	if s.MatchingRuleInChain && s.GroupSchema == "posixGroup" {
+		return nil, fmt.Errorf("matching_rule_in_chain needs groups with member DNs; it cannot be used with posixGroup")
+	}
+	groupFilter := firstNonEmpty(s.GroupFilter, "(cn=%s)")
 
 	// Find group by name
-	gf := fmt.Sprintf(groupFilter, ldap.EscapeFilter(data.GroupName.ValueString()))
+	gf := fmt.Sprintf(groupFilter, ldap.EscapeFilter(groupName))
 	gs := ldap.NewSearchRequest(
-		data.BaseDN.ValueString(),
+		s.BaseDN,
 		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
 		fmt.Sprintf("(&%s)", gf),
-		[]string{memberAttr},
+		[]string{s.memberAttr()},
 		nil,
 	)
-	gres, err := conn.Search(gs)
+	gres, err := conn.SearchWithPaging(gs, ldapPageSize)
 	if err != nil {
-		resp.Diagnostics.AddError("ldap search group failed", err.Error())
+		return nil, err
+	}
+	r := &ldapMemberResolver{s: s, conn: conn, groups: map[string]bool{}, users: map[string]bool{}, members: []ldapMember{}}
+	for _, e := range gres.Entries {
+		if s.MatchingRuleInChain {
+			err = r.inChain(e.DN)
+		} else {
+			err = r.walk(e, 0)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r.members, nil
+}
+
+// ldapMemberResolver collects the users of a group tree; groups and users are tracked by DN so that
+// membership cycles end and users in several nested groups are listed once.
+type ldapMemberResolver struct {
+	s       ldapSearchSettings
+	conn    ldapSearcher
+	groups  map[string]bool
+	users   map[string]bool
+	members []ldapMember
+}
+
+func (r *ldapMemberResolver) userAttrs() []string {
+	return []string{r.userIDAttr(), firstNonEmpty(r.s.EmailAttr, "mail"), firstNonEmpty(r.s.DisplayNameAttr, "cn")}
+}
+
+func (r *ldapMemberResolver) userIDAttr() string { return firstNonEmpty(r.s.UserIDAttr, "uid") }
+
+func (r *ldapMemberResolver) userFilter() string {
+	return firstNonEmpty(r.s.UserFilter, "(objectClass=inetOrgPerson)")
+}
+
+func (r *ldapMemberResolver) walk(group *ldap.Entry, depth int) error {
+	key := strings.ToLower(group.DN)
+	if r.groups[key] {
+		return nil
+	}
+	r.groups[key] = true
+	if depth > r.s.maxDepth() {
+		return fmt.Errorf("group %s is nested deeper than max_depth (%d)", group.DN, r.s.maxDepth())
+	}
+	values, err := r.attributeValues(group, r.s.memberAttr())
+	if err != nil {
+		return err
+	}
+	for _, v := range values {
+		if r.s.GroupSchema == "posixGroup" {
+			// memberUid holds user IDs, not DNs
+			r.addByUID(v)
+			continue
+		}
+		mdn := uniqueMemberDN(v)
+		if r.addByDN(mdn) || !r.s.Recursive {
+			continue
+		}
+		if sub := r.nestedGroup(mdn); sub != nil {
+			if err := r.walk(sub, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// inChain asks Active Directory for all users that are direct or nested members of the group.
+func (r *ldapMemberResolver) inChain(groupDN string) error {
+	us := ldap.NewSearchRequest(
+		r.s.BaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf("(&%s(memberOf:%s:=%s))", r.userFilter(), ldapMatchingRuleInChain, ldap.EscapeFilter(groupDN)),
+		r.userAttrs(),
+		nil,
+	)
+	ures, err := r.conn.SearchWithPaging(us, ldapPageSize)
+	if err != nil {
+		return err
+	}
+	for _, ue := range ures.Entries {
+		r.add(ue)
+	}
+	return nil
+}
+
+// addByDN looks up a member DN as a user; it reports false when the DN is not a user matching the filter.
+func (r *ldapMemberResolver) addByDN(mdn string) bool {
+	// Lookup user by DN; validate user matches filter
+	us := ldap.NewSearchRequest(
+		mdn,
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf("(&%s)", r.userFilter()),
+		r.userAttrs(),
+		nil,
+	)
+	ures, err := r.conn.Search(us)
+	if err != nil || len(ures.Entries) == 0 {
+		// skip broken member but continue
+		return false
+	}
+	ures.Entries[0].DN = firstNonEmpty(ures.Entries[0].DN, mdn)
+	r.add(ures.Entries[0])
+	return true
+}
+
+func (r *ldapMemberResolver) addByUID(uid string) {
+	us := ldap.NewSearchRequest(
+		r.s.BaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 1, 0, false,
+		fmt.Sprintf("(&%s(%s=%s))", r.userFilter(), r.userIDAttr(), ldap.EscapeFilter(uid)),
+		r.userAttrs(),
+		nil,
+	)
+	ures, err := r.conn.Search(us)
+	if err != nil || len(ures.Entries) == 0 {
 		return
 	}
-	if len(gres.Entries) == 0 {
-		// empty group
-		data.ID = types.StringValue(data.GroupName.ValueString() + "@" + data.BaseDN.ValueString())
-		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	r.add(ures.Entries[0])
+}
+
+func (r *ldapMemberResolver) add(ue *ldap.Entry) {
+	key := strings.ToLower(ue.DN)
+	if r.users[key] {
 		return
 	}
-	members := []ldapMember{}
-	for _, e := range gres.Entries {
-		for _, mdn := range e.GetAttributeValues(memberAttr) {
-			// Lookup user by DN; validate user matches filter
-			us := ldap.NewSearchRequest(
-				mdn,
-				ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
-				fmt.Sprintf("(&%s)", userFilter),
-				[]string{userIDAttr, emailAttr, displayNameAttr},
-				nil,
-			)
-			ures, err := conn.Search(us)
-			if err != nil {
-				// skip broken member but continue
-				continue
-			}
-			if len(ures.Entries) == 0 {
-				continue
-			}
-			ue := ures.Entries[0]
-			m := ldapMember{
-				Username:    types.StringValue(firstNonEmpty(ue.GetAttributeValue(userIDAttr), strings.TrimPrefix(strings.ToLower(mdn), dnAttr+"="))),
-				DN:          types.StringValue(mdn),
-				Email:       types.StringValue(ue.GetAttributeValue(emailAttr)),
-				DisplayName: types.StringValue(ue.GetAttributeValue(displayNameAttr)),
-			}
-			members = append(members, m)
+	r.users[key] = true
+	r.members = append(r.members, ldapMember{
+		Username:    types.StringValue(firstNonEmpty(ue.GetAttributeValue(r.userIDAttr()), strings.TrimPrefix(strings.ToLower(ue.DN), "dn="))),
+		DN:          types.StringValue(ue.DN),
+		Email:       types.StringValue(ue.GetAttributeValue(firstNonEmpty(r.s.EmailAttr, "mail"))),
+		DisplayName: types.StringValue(ue.GetAttributeValue(firstNonEmpty(r.s.DisplayNameAttr, "cn"))),
+	})
+}
+
+// nestedGroup returns the member DN as a group entry, or nil when it is not a group.
+func (r *ldapMemberResolver) nestedGroup(mdn string) *ldap.Entry {
+	gs := ldap.NewSearchRequest(
+		mdn,
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		ldapNestedGroupFilter,
+		[]string{r.s.memberAttr()},
+		nil,
+	)
+	gres, err := r.conn.Search(gs)
+	if err != nil || len(gres.Entries) == 0 {
+		return nil
+	}
+	gres.Entries[0].DN = firstNonEmpty(gres.Entries[0].DN, mdn)
+	return gres.Entries[0]
+}
+
+// attributeValues returns all values of a multi-valued attribute. Active Directory returns at most
+// 1500 values and names the attribute like "member;range=0-1499"; the remaining ranges are fetched
+// until the server answers with an open range ("member;range=1500-*").
+func (r *ldapMemberResolver) attributeValues(e *ldap.Entry, attr string) ([]string, error) {
+	values := e.GetEqualFoldAttributeValues(attr)
+	for {
+		name, part := ldapRangedAttribute(e, attr)
+		if name == "" {
+			return values, nil
+		}
+		values = append(values, part...)
+		end := name[strings.LastIndex(name, "-")+1:]
+		if end == "*" {
+			return values, nil
 		}
+		last, err := strconv.Atoi(end)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected ranged attribute %q", name)
+		}
+		rs := ldap.NewSearchRequest(
+			e.DN,
+			ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+			"(objectClass=*)",
+			[]string{fmt.Sprintf("%s;range=%d-*", attr, last+1)},
+			nil,
+		)
+		res, err := r.conn.Search(rs)
+		if err != nil {
+			return nil, err
+		}
+		if len(res.Entries) == 0 {
+			return values, nil
+		}
+		res.Entries[0].DN = e.DN
+		e = res.Entries[0]
 	}
-	data.Members = members
-	data.ID = types.StringValue(data.GroupName.ValueString() + "@" + data.BaseDN.ValueString())
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func ldapRangedAttribute(e *ldap.Entry, attr string) (string, []string) {
+	prefix := strings.ToLower(attr) + ";range="
+	for _, a := range e.Attributes {
+		if strings.HasPrefix(strings.ToLower(a.Name), prefix) {
+			return a.Name, a.Values
+		}
+	}
+	return "", nil
+}
+
+// uniqueMemberDN strips the optional unique identifier of a uniqueMember value ("<dn>#'0101'B").
+func uniqueMemberDN(v string) string {
+	if i := strings.LastIndex(v, "#'"); i > 0 && strings.HasSuffix(v, "'B") {
+		return v[:i]
+	}
+	return v
 }
@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	ldap "github.com/go-ldap/ldap/v3"
+)
+
+// fakeDirectory answers the searches made by ldapMemberResolver from an in-memory tree.
+type fakeDirectory struct {
+	entries []*ldap.Entry
+	paged   int
+}
+
+var fakeFilterTerm = regexp.MustCompile(`\(([\w]+)(:[\d.]+:)?=([^()]*)\)`)
+
+func (f *fakeDirectory) SearchWithPaging(req *ldap.SearchRequest, _ uint32) (*ldap.SearchResult, error) {
+	f.paged++
+	return f.Search(req)
+}
+
+func (f *fakeDirectory) Search(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	res := &ldap.SearchResult{}
+	for _, e := range f.entries {
+		if req.Scope == ldap.ScopeBaseObject && !strings.EqualFold(e.DN, req.BaseDN) {
+			continue
+		}
+		if !fakeMatches(e, req.Filter) {
+			continue
+		}
+		res.Entries = append(res.Entries, fakeProject(e, req.Attributes))
+	}
+	sort.Slice(res.Entries, func(i, j int) bool { return res.Entries[i].DN < res.Entries[j].DN })
+	return res, nil
+}
+
+func fakeMatches(e *ldap.Entry, filter string) bool {
+	terms := fakeFilterTerm.FindAllStringSubmatch(filter, -1)
+	or := strings.HasPrefix(filter, "(|")
+	for _, t := range terms {
+		attr, value := t[1], t[3]
+		ok := value == "*"
+		for _, v := range e.GetEqualFoldAttributeValues(attr) {
+			ok = ok || strings.EqualFold(v, value)
+		}
+		if or && ok {
+			return true
+		}
+		if !or && !ok {
+			return false
+		}
+	}
+	return !or
+}
+
+func fakeProject(e *ldap.Entry, attrs []string) *ldap.Entry {
+	out := &ldap.Entry{DN: e.DN}
+	for _, want := range attrs {
+		for _, a := range e.Attributes {
+			if strings.EqualFold(a.Name, want) || strings.HasPrefix(strings.ToLower(a.Name), strings.ToLower(want)+";range=0-") {
+				out.Attributes = append(out.Attributes, a)
+			}
+		}
+	}
+	return out
+}
+
+func fakeUser(uid string, groups ...string) *ldap.Entry {
+	return ldap.NewEntry("uid="+uid+",ou=people,dc=example,dc=org", map[string][]string{
+		"objectClass": {"inetOrgPerson"},
+		"uid":         {uid},
+		"mail":        {uid + "@example.org"},
+		"cn":          {strings.ToUpper(uid)},
+		"memberOf":    groups,
+	})
+}
+
+func fakeGroup(cn, class, attr string, members ...string) *ldap.Entry {
+	return ldap.NewEntry("cn="+cn+",ou=groups,dc=example,dc=org", map[string][]string{
+		"objectClass": {class},
+		"cn":          {cn},
+		attr:          members,
+	})
+}
+
+func memberNames(ms []ldapMember) []string {
+	out := []string{}
+	for _, m := range ms {
+		out = append(out, m.Username.ValueString())
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestLDAPGroupMembers_NestedGroups(t *testing.T) {
+	const (
+		alice  = "uid=alice,ou=people,dc=example,dc=org"
+		bob    = "uid=bob,ou=people,dc=example,dc=org"
+		carol  = "uid=carol,ou=people,dc=example,dc=org"
+		devops = "cn=devops,ou=groups,dc=example,dc=org"
+		sre    = "cn=sre,ou=groups,dc=example,dc=org"
+		oncall = "cn=oncall,ou=groups,dc=example,dc=org"
+	)
+	dir := &fakeDirectory{entries: []*ldap.Entry{
+		fakeUser("alice"), fakeUser("bob"), fakeUser("carol"),
+		fakeGroup("devops", "groupOfNames", "member", alice, sre),
+		// sre -> oncall -> devops is a cycle; alice is listed twice
+		fakeGroup("sre", "groupOfNames", "member", bob, alice, oncall),
+		fakeGroup("oncall", "groupOfNames", "member", carol, devops),
+	}}
+	s := ldapSearchSettings{BaseDN: "dc=example,dc=org"}
+
+	direct, err := s.groupMembers(dir, "devops")
+	if err != nil || strings.Join(memberNames(direct), ",") != "alice" {
+		t.Fatalf("direct members: %v (%v)", memberNames(direct), err)
+	}
+
+	s.Recursive = true
+	all, err := s.groupMembers(dir, "devops")
+	if err != nil || strings.Join(memberNames(all), ",") != "alice,bob,carol" {
+		t.Fatalf("recursive members: %v (%v)", memberNames(all), err)
+	}
+	if all[0].Email.ValueString() != "alice@example.org" || all[0].DisplayName.ValueString() != "ALICE" || all[0].DN.ValueString() != alice {
+		t.Fatalf("unexpected member attributes: %+v", all[0])
+	}
+
+	s.MaxDepth = 1
+	if _, err := s.groupMembers(dir, "devops"); err == nil || !strings.Contains(err.Error(), "max_depth") {
+		t.Fatalf("expected max_depth error, got %v", err)
+	}
+	if dir.paged == 0 {
+		t.Fatalf("group search must use paged search")
+	}
+}
+
+func TestLDAPGroupMembers_GroupSchemas(t *testing.T) {
+	dir := &fakeDirectory{entries: []*ldap.Entry{
+		fakeUser("alice"), fakeUser("bob"),
+		fakeGroup("admins", "posixGroup", "memberUid", "alice", "ghost"),
+		fakeGroup("auditors", "groupOfUniqueNames", "uniqueMember", "uid=bob,ou=people,dc=example,dc=org#'0101'B"),
+	}}
+	posix := ldapSearchSettings{BaseDN: "dc=example,dc=org", GroupSchema: "posixGroup"}
+	ms, err := posix.groupMembers(dir, "admins")
+	if err != nil || strings.Join(memberNames(ms), ",") != "alice" {
+		t.Fatalf("posixGroup members: %v (%v)", memberNames(ms), err)
+	}
+	unique := ldapSearchSettings{BaseDN: "dc=example,dc=org", GroupSchema: "groupOfUniqueNames"}
+	ms, err = unique.groupMembers(dir, "auditors")
+	if err != nil || strings.Join(memberNames(ms), ",") != "bob" {
+		t.Fatalf("groupOfUniqueNames members: %v (%v)", memberNames(ms), err)
+	}
+	posix.MatchingRuleInChain = true
+	if _, err := posix.groupMembers(dir, "admins"); err == nil {
+		t.Fatalf("matching_rule_in_chain must be rejected for posixGroup")
+	}
+}
+
+func TestLDAPGroupMembers_ActiveDirectory(t *testing.T) {
+	const group = "cn=big,ou=groups,dc=example,dc=org"
+	big := fakeGroup("big", "group", "member;range=0-1", "uid=u0,ou=people,dc=example,dc=org", "uid=u1,ou=people,dc=example,dc=org")
+	big.Attributes = append(big.Attributes,
+		ldap.NewEntryAttribute("member;range=2-*", []string{"uid=u2,ou=people,dc=example,dc=org"}))
+	dir := &fakeDirectory{entries: []*ldap.Entry{fakeUser("u0", group), fakeUser("u1", group), fakeUser("u2"), fakeUser("other"), big}}
+	s := ldapSearchSettings{BaseDN: "dc=example,dc=org"}
+
+	ms, err := s.groupMembers(dir, "big")
+	if err != nil || strings.Join(memberNames(ms), ",") != "u0,u1,u2" {
+		t.Fatalf("ranged members: %v (%v)", memberNames(ms), err)
+	}
+
+	// In-chain search returns the users whose (transitive) memberOf contains the group
+	s.MatchingRuleInChain = true
+	dir.paged = 0
+	ms, err = s.groupMembers(dir, "big")
+	if err != nil || strings.Join(memberNames(ms), ",") != "u0,u1" {
+		t.Fatalf("in-chain members: %v (%v)", memberNames(ms), err)
+	}
+	if dir.paged != 2 {
+		t.Fatalf("group and member searches must be paged, got %d paged searches", dir.paged)
+	}
+}
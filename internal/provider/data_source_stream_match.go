@@ -0,0 +1,309 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// graylog_stream_match_test — проверка, попадёт ли сообщение в стрим
+type streamMatchTestDataSource struct{ client *client.Client }
+
+type streamMatchTestModel struct {
+	StreamID     types.String            `tfsdk:"stream_id"`
+	Rules        []streamMatchRuleModel  `tfsdk:"rules"`
+	MatchingType types.String            `tfsdk:"matching_type"`
+	Message      map[string]types.String `tfsdk:"message"`
+	Matches      types.Bool              `tfsdk:"matches"`
+	Results      types.List              `tfsdk:"results"`
+}
+
+type streamMatchRuleModel struct {
+	Field    types.String `tfsdk:"field"`
+	Match    types.String `tfsdk:"match"`
+	Type     types.Int64  `tfsdk:"type"`
+	Value    types.String `tfsdk:"value"`
+	Inverted types.Bool   `tfsdk:"inverted"`
+}
+
+// streamMatchResult is one rule outcome before it is converted to a Terraform object.
+type streamMatchResult struct {
+	ID      string
+	Field   string
+	Type    int
+	Matched bool
+}
+
+var streamMatchResultAttrTypes = map[string]attr.Type{
+	"id":      types.StringType,
+	"field":   types.StringType,
+	"match":   types.StringType,
+	"matched": types.BoolType,
+}
+
+func NewStreamMatchTestDataSource() datasource.DataSource { return &streamMatchTestDataSource{} }
+
+func (d *streamMatchTestDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "graylog_stream_match_test"
+}
+
+func (d *streamMatchTestDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Tests whether a sample message matches a stream's rules (server-side testMatch) or a set of inline rules (evaluated by the provider).",
+		Attributes: map[string]schema.Attribute{
+			"stream_id": schema.StringAttribute{Optional: true, Description: "Existing stream to test against"},
+			"rules": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "Inline rules to test instead of an existing stream",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"field": schema.StringAttribute{Optional: true, Description: "Field name to match"},
+						"match": schema.StringAttribute{
+							Optional:    true,
+							Description: "Rule type: exact | regex | greater | smaller | field_presence | contains | always_match | match_input",
+							Validators:  []validator.String{stringvalidator.OneOf(streamRuleMatchNames...)},
+						},
+						"type":     schema.Int64Attribute{Optional: true, Description: "Rule type as Graylog integer enum; prefer `match`"},
+						"value":    schema.StringAttribute{Optional: true, Description: "Value to match"},
+						"inverted": schema.BoolAttribute{Optional: true, Description: "Invert rule condition"},
+					},
+				},
+			},
+			"matching_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "How inline rules are combined: AND (default) or OR",
+				Validators:  []validator.String{stringvalidator.OneOf("AND", "OR")},
+			},
+			"message": schema.MapAttribute{Required: true, ElementType: types.StringType, Description: "Sample message fields (e.g. message, source, level)"},
+			"matches": schema.BoolAttribute{Computed: true, Description: "Whether the message matches the stream"},
+			"results": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Per-rule results in rule order",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":      schema.StringAttribute{Computed: true, Description: "Rule ID (list index for inline rules)"},
+						"field":   schema.StringAttribute{Computed: true},
+						"match":   schema.StringAttribute{Computed: true},
+						"matched": schema.BoolAttribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *streamMatchTestDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("stream_id"), path.MatchRoot("rules")),
+		datasourcevalidator.Conflicting(path.MatchRoot("stream_id"), path.MatchRoot("matching_type")),
+	}
+}
+
+func (d *streamMatchTestDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*client.Client)
+}
+
+func (d *streamMatchTestDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data streamMatchTestModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	msg := make(map[string]string, len(data.Message))
+	for k, v := range data.Message {
+		msg[k] = v.ValueString()
+	}
+
+	var (
+		matches bool
+		results []streamMatchResult
+	)
+	if id := data.StreamID.ValueString(); id != "" {
+		c := d.client.WithContext(ctx)
+		payload := make(map[string]any, len(msg))
+		for k, v := range msg {
+			payload[k] = v
+		}
+		res, err := c.TestStreamMatch(id, payload)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to test stream match", err.Error())
+			return
+		}
+		rules, err := c.ListStreamRules(id)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to list stream rules", err.Error())
+			return
+		}
+		matches = res.Matches
+		results = streamMatchServerResults(rules, res.Rules)
+	} else {
+		rules := make([]client.StreamRule, 0, len(data.Rules))
+		for i, r := range data.Rules {
+			resp.Diagnostics.Append(validateStreamRuleMatch(path.Root("rules").AtListIndex(i), r.Field, r.Type, r.Match, r.Value)...)
+			// Work on copies: config values must be written back to state unchanged
+			typ, match := r.Type, r.Match
+			fillStreamRuleMatch(&typ, &match)
+			rules = append(rules, client.StreamRule{
+				ID:       strconv.Itoa(i),
+				Field:    r.Field.ValueString(),
+				Type:     int(typ.ValueInt64()),
+				Value:    r.Value.ValueString(),
+				Inverted: r.Inverted.ValueBool(),
+			})
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		var err error
+		matches, results, err = evalStreamRules(rules, stringOrDefault(data.MatchingType, "AND"), msg)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("rules"), "Unable to evaluate rules", err.Error())
+			return
+		}
+	}
+
+	list, diags := flattenStreamMatchResults(results)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Matches = types.BoolValue(matches)
+	data.Results = list
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// streamMatchServerResults orders the server's per-rule results by the stream's rule list.
+func streamMatchServerResults(rules []client.StreamRule, matched map[string]bool) []streamMatchResult {
+	out := make([]streamMatchResult, 0, len(matched))
+	seen := map[string]bool{}
+	for _, r := range rules {
+		if m, ok := matched[r.ID]; ok {
+			out = append(out, streamMatchResult{ID: r.ID, Field: r.Field, Type: r.Type, Matched: m})
+			seen[r.ID] = true
+		}
+	}
+	for _, id := range sortedBoolKeys(matched) {
+		if !seen[id] {
+			out = append(out, streamMatchResult{ID: id, Type: -1, Matched: matched[id]})
+		}
+	}
+	return out
+}
+
+func sortedBoolKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// evalStreamRules evaluates rules the way Graylog's stream router does. An empty rule set never matches.
+func evalStreamRules(rules []client.StreamRule, matchingType string, msg map[string]string) (bool, []streamMatchResult, error) {
+	out := make([]streamMatchResult, 0, len(rules))
+	anyMatched, allMatched := false, true
+	for _, r := range rules {
+		m, err := evalStreamRule(r, msg)
+		if err != nil {
+			return false, nil, fmt.Errorf("rule %s: %w", r.ID, err)
+		}
+		anyMatched = anyMatched || m
+		allMatched = allMatched && m
+		out = append(out, streamMatchResult{ID: r.ID, Field: r.Field, Type: r.Type, Matched: m})
+	}
+	if len(rules) == 0 {
+		return false, out, nil
+	}
+	if strings.EqualFold(matchingType, "OR") {
+		return anyMatched, out, nil
+	}
+	return allMatched, out, nil
+}
+
+// evalStreamRule mirrors Graylog's StreamRuleMatcher implementations. Missing fields never match
+// greater/smaller rules, even when inverted.
+func evalStreamRule(r client.StreamRule, msg map[string]string) (bool, error) {
+	v, present := msg[r.Field]
+	switch r.Type {
+	case 1: // exact
+		if !present {
+			return r.Inverted, nil
+		}
+		return r.Inverted != (v == r.Value), nil
+	case 2, 3: // greater, smaller
+		got, err1 := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		want, err2 := strconv.ParseFloat(strings.TrimSpace(r.Value), 64)
+		if !present || err1 != nil || err2 != nil {
+			return false, nil
+		}
+		if r.Type == 2 {
+			return r.Inverted != (got > want), nil
+		}
+		return r.Inverted != (got < want), nil
+	case 4: // regex
+		re, err := regexp.Compile(r.Value)
+		if err != nil {
+			return false, fmt.Errorf("regex %q cannot be evaluated locally: %w", r.Value, err)
+		}
+		if !present {
+			return r.Inverted, nil
+		}
+		return r.Inverted != re.MatchString(v), nil
+	case 5: // field_presence
+		return r.Inverted != (present && strings.TrimSpace(v) != ""), nil
+	case 6: // contains
+		if !present {
+			return r.Inverted, nil
+		}
+		return r.Inverted != strings.Contains(v, r.Value), nil
+	case 7: // always_match
+		return true, nil
+	case 8: // match_input
+		return r.Inverted != (msg["gl2_source_input"] == r.Value), nil
+	}
+	return false, fmt.Errorf("unsupported rule type %d", r.Type)
+}
+
+func flattenStreamMatchResults(results []streamMatchResult) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	vals := make([]attr.Value, 0, len(results))
+	for _, r := range results {
+		field := types.StringNull()
+		if r.Field != "" {
+			field = types.StringValue(r.Field)
+		}
+		match := types.StringNull()
+		if r.Type >= 0 {
+			match = streamRuleMatchValue(r.Type)
+		}
+		obj, di := types.ObjectValue(streamMatchResultAttrTypes, map[string]attr.Value{
+			"id":      types.StringValue(r.ID),
+			"field":   field,
+			"match":   match,
+			"matched": types.BoolValue(r.Matched),
+		})
+		diags.Append(di...)
+		vals = append(vals, obj)
+	}
+	list, di := types.ListValue(types.ObjectType{AttrTypes: streamMatchResultAttrTypes}, vals)
+	diags.Append(di...)
+	return list, diags
+}
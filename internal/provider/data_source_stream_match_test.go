@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+)
+
+func TestStreamMatchTestDataSource_New(t *testing.T) {
+	if NewStreamMatchTestDataSource() == nil {
+		t.Fatal("expected non-nil data source")
+	}
+}
+
+func TestEvalStreamRule(t *testing.T) {
+	msg := map[string]string{"source": "web-1", "took_ms": "250", "message": "GET /health 200", "gl2_source_input": "in1"}
+	cases := []struct {
+		name string
+		rule client.StreamRule
+		want bool
+	}{
+		{"exact", client.StreamRule{Field: "source", Type: 1, Value: "web-1"}, true},
+		{"exact inverted", client.StreamRule{Field: "source", Type: 1, Value: "web-1", Inverted: true}, false},
+		{"exact missing inverted", client.StreamRule{Field: "host", Type: 1, Value: "x", Inverted: true}, true},
+		{"greater", client.StreamRule{Field: "took_ms", Type: 2, Value: "100"}, true},
+		{"smaller", client.StreamRule{Field: "took_ms", Type: 3, Value: "100"}, false},
+		{"greater missing inverted", client.StreamRule{Field: "size", Type: 2, Value: "1", Inverted: true}, false},
+		{"regex", client.StreamRule{Field: "message", Type: 4, Value: `/health\s+2\d\d`}, true},
+		{"presence", client.StreamRule{Field: "source", Type: 5}, true},
+		{"presence missing", client.StreamRule{Field: "host", Type: 5}, false},
+		{"contains", client.StreamRule{Field: "message", Type: 6, Value: "GET"}, true},
+		{"always", client.StreamRule{Type: 7, Inverted: true}, true},
+		{"match input", client.StreamRule{Type: 8, Value: "in1"}, true},
+	}
+	for _, tc := range cases {
+		got, err := evalStreamRule(tc.rule, msg)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestEvalStreamRule_JavaOnlyRegexIsAnError(t *testing.T) {
+	// Must not silently count as "not matching"
+	if _, err := evalStreamRule(client.StreamRule{Field: "message", Type: 4, Value: "GET(?= /health)"}, map[string]string{"message": "GET /health"}); err == nil {
+		t.Fatal("expected an error for a regex that cannot be evaluated locally")
+	}
+}
+
+func TestEvalStreamRules_MatchingType(t *testing.T) {
+	msg := map[string]string{"source": "web-1"}
+	rules := []client.StreamRule{
+		{ID: "0", Field: "source", Type: 1, Value: "web-1"},
+		{ID: "1", Field: "source", Type: 1, Value: "db-1"},
+	}
+	if m, res, _ := evalStreamRules(rules, "AND", msg); m || len(res) != 2 || !res[0].Matched || res[1].Matched {
+		t.Fatalf("AND: unexpected result %v %+v", m, res)
+	}
+	if m, _, _ := evalStreamRules(rules, "OR", msg); !m {
+		t.Fatal("OR: expected match")
+	}
+	if m, _, _ := evalStreamRules(nil, "AND", msg); m {
+		t.Fatal("expected no match without rules")
+	}
+	if _, _, err := evalStreamRules([]client.StreamRule{{ID: "0", Field: "message", Type: 4, Value: "a(?=b)"}}, "AND", msg); err == nil {
+		t.Fatal("expected error for regex that cannot be evaluated locally")
+	}
+}
+
+func TestStreamMatchServerResults_Order(t *testing.T) {
+	rules := []client.StreamRule{{ID: "b", Field: "source", Type: 1}, {ID: "a", Field: "level", Type: 5}}
+	res := streamMatchServerResults(rules, map[string]bool{"a": true, "b": false, "z": true})
+	if len(res) != 3 || res[0].ID != "b" || res[1].ID != "a" || res[2].ID != "z" || res[2].Type != -1 {
+		t.Fatalf("unexpected order: %+v", res)
+	}
+	list, diags := flattenStreamMatchResults(res)
+	if diags.HasError() || len(list.Elements()) != 3 {
+		t.Fatalf("unexpected flatten result: %v %v", list, diags)
+	}
+}
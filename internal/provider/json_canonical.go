@@ -150,3 +150,31 @@ func escapeString(s string) string {
 	// Fallback: ensure any quotes/backslashes are escaped
 	return strconv.QuoteToASCII(s)[1 : len(strconv.QuoteToASCII(s))-1]
 }
+
+// normalizeJSONForState returns the JSON string to store in state for a free-form
+// config attribute. When prior (user-supplied) JSON is an object, only top-level keys
+// present in prior are kept from the server value, so server-side defaults do not show
+// up as drift. If the result is semantically equal to prior, prior is returned unchanged.
+func normalizeJSONForState(prior string, server map[string]interface{}) (string, error) {
+	if prior == "" {
+		return canonicalEncode(server)
+	}
+	var p map[string]interface{}
+	if err := json.Unmarshal([]byte(prior), &p); err != nil {
+		return canonicalEncode(server)
+	}
+	filtered := make(map[string]interface{}, len(p))
+	for k := range p {
+		if v, ok := server[k]; ok {
+			filtered[k] = v
+		}
+	}
+	got, err := canonicalEncode(filtered)
+	if err != nil {
+		return "", err
+	}
+	if want, err := CanonicalizeJSONFromString(prior); err == nil && want == got {
+		return prior, nil
+	}
+	return got, nil
+}
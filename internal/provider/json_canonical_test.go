@@ -50,3 +50,31 @@ func TestCanonicalizeJSONFromString_ArrayStable(t *testing.T) {
 		t.Fatalf("want %s, got %s", in, out)
 	}
 }
+
+func TestNormalizeJSONForState_KeepsPriorOnServerDefaults(t *testing.T) {
+	prior := `{"type":"csvfile","path":"/etc/graylog/hosts.csv"}`
+	server := map[string]interface{}{"type": "csvfile", "path": "/etc/graylog/hosts.csv", "separator": ",", "check_interval": float64(60)}
+	got, err := normalizeJSONForState(prior, server)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != prior {
+		t.Fatalf("expected prior to be kept, got %s", got)
+	}
+}
+
+func TestNormalizeJSONForState_DriftAndImport(t *testing.T) {
+	server := map[string]interface{}{"type": "csvfile", "path": "/tmp/other.csv", "separator": ","}
+	got, err := normalizeJSONForState(`{"path":"/tmp/a.csv","type":"csvfile"}`, server)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != `{"path":"/tmp/other.csv","type":"csvfile"}` {
+		t.Fatalf("unexpected drift value: %s", got)
+	}
+	// Без prior (импорт) сохраняется весь объект
+	got, _ = normalizeJSONForState("", server)
+	if got != `{"path":"/tmp/other.csv","separator":",","type":"csvfile"}` {
+		t.Fatalf("unexpected import value: %s", got)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -164,13 +165,24 @@ func (p *graylogProvider) Resources(_ context.Context) []func() resource.Resourc
 		NewInputResource,
 		NewIndexSetResource,
 		NewPipelineResource,
+		NewPipelineRuleResource,
 		NewDashboardResource,
 		NewDashboardWidgetResource,
 		NewAlertResource,
 		NewEventNotificationResource,
 		NewLDAPSettingResource,
+		NewLDAPGroupSyncResource,
+		NewAuthBackendResource,
 		NewOutputResource,
 		NewStreamOutputBindingResource,
+		NewPipelineStreamConnectionResource,
+		NewLookupAdapterResource,
+		NewLookupCacheResource,
+		NewLookupTableResource,
+		NewGrokPatternResource,
+		NewGrokPatternsResource,
+		NewInputExtractorResource,
+		NewStreamRuleResource,
 		NewStreamPermissionResource,
 		NewDashboardPermissionResource,
 		NewRoleResource,
@@ -183,9 +195,11 @@ func (p *graylogProvider) DataSources(_ context.Context) []func() datasource.Dat
 	return []func() datasource.DataSource{
 		NewStreamDataSource,
 		NewStreamsListDataSource,
+		NewStreamMatchTestDataSource,
 		NewViewsListDataSource,
 		NewInputDataSource,
 		NewInputsListDataSource,
+		NewInputTypesDataSource,
 		NewIndexSetDataSource,
 		NewIndexSetDefaultDataSource,
 		NewIndexSetsListDataSource,
@@ -193,12 +207,20 @@ func (p *graylogProvider) DataSources(_ context.Context) []func() datasource.Dat
 		NewDashboardsListDataSource,
 		NewEventNotificationDataSource,
 		NewEventNotificationsListDataSource,
+		NewAuthBackendTestDataSource,
 		NewUserDataSource,
 		NewUsersListDataSource,
 		NewLDAPGroupMembersDataSource,
 	}
 }
 
+// Actions are supported by Terraform 1.14 and later.
+func (p *graylogProvider) Actions(_ context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewEventNotificationTestAction,
+	}
+}
+
 func New() provider.Provider { return &graylogProvider{} }
 
 // ===== Вспомогательные функции/типы =====
@@ -5,16 +5,21 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
 
 type alertResource struct{ client *client.Client }
@@ -71,19 +76,44 @@ type alertThresholdBlockModel struct {
 }
 
 // alertAggregationBlockModel reuses the same shape as threshold for aggregation events
-// (query/group_by/series/threshold/execution) and maps to type=aggregation-v1.
-type alertAggregationBlockModel = alertThresholdBlockModel
+// (query/group_by/series/threshold/execution) plus a condition tree, and maps to type=aggregation-v1.
+type alertAggregationBlockModel struct {
+	alertThresholdBlockModel
+	Condition types.Object `tfsdk:"condition"`
+}
 
-// alertFilterModel represents shorthand filter block (currently only streams)
+// alertFilterModel represents shorthand filter block (currently only streams).
+// Streams holds what the user wrote (IDs, titles or aliases); StreamIDs the resolved IDs sent to Graylog.
 type alertFilterModel struct {
-	Streams []types.String `tfsdk:"streams"`
+	Streams   []types.String `tfsdk:"streams"`
+	StreamIDs types.List     `tfsdk:"stream_ids"`
+}
+
+// alertFilterBlockModel is a filter-only event (aggregation-v1 without series or conditions):
+// every message matching query and streams becomes an event.
+type alertFilterBlockModel struct {
+	alertFilterModel
+	Query          types.String `tfsdk:"query"`
+	SearchWithinMs types.Int64  `tfsdk:"search_within_ms"`
+	ExecuteEveryMs types.Int64  `tfsdk:"execute_every_ms"`
 }
 
+// alertSystemNotificationModel maps to type=system-notifications-v1, which has no settings
+type alertSystemNotificationModel struct{}
+
 // Extend main model with typed threshold block (optional)
 type alertModelV2 struct {
 	alertModel
-	Threshold   *alertThresholdBlockModel   `tfsdk:"threshold"`
-	Aggregation *alertAggregationBlockModel `tfsdk:"aggregation"`
+	Threshold          *alertThresholdBlockModel     `tfsdk:"threshold"`
+	Aggregation        *alertAggregationBlockModel   `tfsdk:"aggregation"`
+	Filter             *alertFilterBlockModel        `tfsdk:"filter"`
+	SystemNotification *alertSystemNotificationModel `tfsdk:"system_notification"`
+	Scheduled          types.Bool                    `tfsdk:"scheduled"`
+	// Typed top-level parts of the definition (see resource_alert_specs.go)
+	FieldSpec            []alertFieldSpecModel           `tfsdk:"field_spec"`
+	KeySpec              []types.String                  `tfsdk:"key_spec"`
+	NotificationSettings *alertNotificationSettingsModel `tfsdk:"notification_settings"`
+	Storage              []alertStorageModel             `tfsdk:"storage"`
 }
 
 func NewAlertResource() resource.Resource { return &alertResource{} }
@@ -94,7 +124,7 @@ func (r *alertResource) Metadata(_ context.Context, _ resource.MetadataRequest,
 
 func (r *alertResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Version:     6,
+		Version:     7,
 		Description: "Manages a Graylog Event Definition (alerts)",
 		Attributes: map[string]schema.Attribute{
 			"id":          schema.StringAttribute{Computed: true, Description: "Event Definition ID"},
@@ -105,7 +135,14 @@ func (r *alertResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 			// JSON-encoded free-form object remains as an escape-hatch
 			"config":           schema.StringAttribute{Optional: true, Description: "JSON-encoded event configuration (free-form object)."},
 			"notification_ids": schema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Notification IDs to trigger"},
-			"timeouts":         timeouts.Attributes(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+			"scheduled": schema.BoolAttribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   "Whether the definition is scheduled (runs). Set false to ship it disabled; unset keeps Graylog's state (scheduled on create)",
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+			"key_spec": schema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Custom event fields (from field_spec) that form the event key"},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
 		},
 		Blocks: map[string]schema.Block{
 			// Typed threshold block (minimal viable shape for 'threshold-v1')
@@ -123,7 +160,8 @@ func (r *alertResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 					"filter": schema.SingleNestedBlock{
 						Description: "Shorthand filters for the event definition (currently streams only)",
 						Attributes: map[string]schema.Attribute{
-							"streams": schema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "List of stream IDs to scope the search to"},
+							"streams":    schema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Streams to scope the search to: stream IDs, exact stream titles or the aliases default, all_events, all_system_events"},
+							"stream_ids": schema.ListAttribute{Computed: true, ElementType: types.StringType, Description: "Stream IDs resolved from 'streams' at plan time"},
 						},
 					},
 					"series": schema.ListNestedBlock{
@@ -170,10 +208,12 @@ func (r *alertResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 					"group_by":         schema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Group-by fields"},
 				},
 				Blocks: map[string]schema.Block{
+					"condition": alertConditionBlock(),
 					"filter": schema.SingleNestedBlock{
 						Description: "Shorthand filters for the event definition (currently streams only)",
 						Attributes: map[string]schema.Attribute{
-							"streams": schema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "List of stream IDs to scope the search to"},
+							"streams":    schema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Streams to scope the search to: stream IDs, exact stream titles or the aliases default, all_events, all_system_events"},
+							"stream_ids": schema.ListAttribute{Computed: true, ElementType: types.StringType, Description: "Stream IDs resolved from 'streams' at plan time"},
 						},
 					},
 					"series": schema.ListNestedBlock{
@@ -209,6 +249,73 @@ func (r *alertResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 			},
 		},
 	}
+	resp.Schema.Blocks["filter"] = schema.SingleNestedBlock{
+		Description: "Typed configuration for a filter-only Event Definition: every matching message is an event (maps to type=aggregation-v1 without series)",
+		Attributes: map[string]schema.Attribute{
+			"query":            schema.StringAttribute{Optional: true, Description: "Graylog query (e.g., level:ERROR)"},
+			"streams":          schema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Streams to scope the search to: stream IDs, exact stream titles or the aliases default, all_events, all_system_events"},
+			"stream_ids":       schema.ListAttribute{Computed: true, ElementType: types.StringType, Description: "Stream IDs resolved from 'streams' at plan time"},
+			"search_within_ms": schema.Int64Attribute{Optional: true, Description: "Search window in milliseconds (default 300000, 5 minutes)"},
+			"execute_every_ms": schema.Int64Attribute{Optional: true, Description: "Execution interval in milliseconds (default 300000, 5 minutes)"},
+		},
+	}
+	resp.Schema.Blocks["system_notification"] = schema.SingleNestedBlock{
+		Description: "Typed configuration for an Event Definition fed by Graylog system notifications (maps to type=system-notifications-v1); the block has no settings",
+	}
+	for name, b := range alertSpecBlocks() {
+		resp.Schema.Blocks[name] = b
+	}
+}
+
+// alertTypedBlockNames lists the typed configuration blocks; at most one of them or `config` may be set.
+var alertTypedBlockNames = []string{"threshold", "aggregation", "filter", "system_notification"}
+
+func (r *alertResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	exprs := []path.Expression{path.MatchRoot("config")}
+	for _, name := range alertTypedBlockNames {
+		exprs = append(exprs, path.MatchRoot(name))
+	}
+	return []resource.ConfigValidator{resourcevalidator.Conflicting(exprs...)}
+}
+
+// UpgradeState migrates v6 state (before stream_ids, the top-level filter and the spec blocks existed).
+// The new attributes decode as null; stream_ids is seeded from streams, which only held IDs in v6.
+func (r *alertResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		6: {StateUpgrader: upgradeAlertStateV6},
+	}
+}
+
+func upgradeAlertStateV6(_ context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil {
+		resp.Diagnostics.AddError("Unable to upgrade alert state", "Prior state is missing.")
+		return
+	}
+	b, err := upgradeAlertStateV6JSON(req.RawState.JSON)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to upgrade alert state", err.Error())
+		return
+	}
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: b}
+}
+
+func upgradeAlertStateV6JSON(raw []byte) ([]byte, error) {
+	var st map[string]interface{}
+	if err := json.Unmarshal(raw, &st); err != nil {
+		return nil, err
+	}
+	for _, name := range []string{"threshold", "aggregation"} {
+		block, _ := st[name].(map[string]interface{})
+		if filter, ok := block["filter"].(map[string]interface{}); ok {
+			filter["stream_ids"] = filter["streams"]
+		}
+	}
+	for _, name := range []string{"field_spec", "storage"} {
+		if _, ok := st[name]; !ok {
+			st[name] = []interface{}{}
+		}
+	}
+	return json.Marshal(st)
 }
 
 func (r *alertResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
@@ -218,6 +325,192 @@ func (r *alertResource) Configure(_ context.Context, req resource.ConfigureReque
 	r.client = req.ProviderData.(*client.Client)
 }
 
+// ModifyPlan resolves filter stream titles and aliases to IDs so the plan shows what is sent to Graylog.
+func (r *alertResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	lookup := r.streamLookup(ctx)
+	for _, p := range []path.Path{path.Root("threshold").AtName("filter"), path.Root("aggregation").AtName("filter"), path.Root("filter")} {
+		var filter types.Object
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, p, &filter)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if filter.IsNull() || filter.IsUnknown() {
+			continue
+		}
+		var entries types.List
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, p.AtName("streams"), &entries)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		ids := types.ListNull(types.StringType)
+		switch {
+		case entries.IsUnknown():
+			ids = types.ListUnknown(types.StringType)
+		case !entries.IsNull():
+			var vals []types.String
+			resp.Diagnostics.Append(entries.ElementsAs(ctx, &vals, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			var diags diag.Diagnostics
+			ids, diags = resolveFilterStreamList(p.AtName("streams"), vals, lookup)
+			resp.Diagnostics.Append(diags...)
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, p.AtName("stream_ids"), ids)...)
+	}
+}
+
+// streamLookup returns a ListStreams call that hits the server at most once.
+func (r *alertResource) streamLookup(ctx context.Context) func() ([]client.Stream, error) {
+	var (
+		cached []client.Stream
+		done   bool
+	)
+	return func() ([]client.Stream, error) {
+		if done {
+			return cached, nil
+		}
+		if r.client == nil {
+			return nil, errors.New("provider is not configured")
+		}
+		list, err := r.client.WithContext(ctx).ListStreams()
+		if err != nil {
+			return nil, err
+		}
+		cached, done = list, true
+		return cached, nil
+	}
+}
+
+// resolveFilterStreams fills stream_ids that were still unknown at plan time (e.g. streams created in the same apply).
+func (r *alertResource) resolveFilterStreams(ctx context.Context, m *alertModelV2) (d diag.Diagnostics) {
+	lookup := r.streamLookup(ctx)
+	filters := map[string]*alertFilterModel{}
+	if m.Threshold != nil {
+		filters["threshold"] = &m.Threshold.Filter
+	}
+	if m.Aggregation != nil {
+		filters["aggregation"] = &m.Aggregation.Filter
+	}
+	if m.Filter != nil {
+		filters["filter"] = &m.Filter.alertFilterModel
+	}
+	for _, name := range []string{"threshold", "aggregation", "filter"} {
+		f := filters[name]
+		if f == nil || !f.StreamIDs.IsUnknown() {
+			continue
+		}
+		p := path.Root(name).AtName("streams")
+		if name != "filter" {
+			p = path.Root(name).AtName("filter").AtName("streams")
+		}
+		ids, diags := resolveFilterStreamList(p, f.Streams, lookup)
+		d.Append(diags...)
+		f.StreamIDs = ids
+	}
+	return d
+}
+
+// alertStreamAliases maps the well-known aliases accepted in filter.streams to Graylog's built-in stream IDs.
+var alertStreamAliases = map[string]string{
+	"default":           "000000000000000000000001",
+	"all_events":        "000000000000000000000002",
+	"all_system_events": "000000000000000000000003",
+}
+
+var streamIDRe = regexp.MustCompile(`(?i)^([0-9a-f]{24}|[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})$`)
+
+// resolveFilterStreamList resolves filter.streams entries to stream IDs. IDs pass through, aliases map to
+// built-in streams and anything else must be the exact title of exactly one stream. The result is unknown
+// while any entry is unknown, and streams are only listed when a title has to be looked up.
+func resolveFilterStreamList(p path.Path, entries []types.String, lookup func() ([]client.Stream, error)) (types.List, diag.Diagnostics) {
+	var d diag.Diagnostics
+	ids := make([]string, 0, len(entries))
+	for i, e := range entries {
+		if e.IsUnknown() {
+			return types.ListUnknown(types.StringType), d
+		}
+		v := strings.TrimSpace(e.ValueString())
+		if e.IsNull() || v == "" {
+			d.AddAttributeError(p.AtListIndex(i), "Invalid stream", "Each 'streams' entry must be a non-empty stream ID, title or alias")
+			continue
+		}
+		if id, ok := alertStreamAliases[v]; ok {
+			ids = append(ids, id)
+			continue
+		}
+		if streamIDRe.MatchString(v) {
+			ids = append(ids, v)
+			continue
+		}
+		list, err := lookup()
+		if err != nil {
+			d.AddAttributeError(p.AtListIndex(i), "Unable to resolve stream title", fmt.Sprintf("Listing streams to resolve %q failed: %s", v, err))
+			return types.ListNull(types.StringType), d
+		}
+		var matches []string
+		for _, s := range list {
+			if s.Title == v {
+				matches = append(matches, s.ID)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			d.AddAttributeError(p.AtListIndex(i), "Unknown stream", fmt.Sprintf("No stream with ID, alias or title %q; aliases are default, all_events, all_system_events.", v))
+		case 1:
+			ids = append(ids, matches[0])
+		default:
+			d.AddAttributeError(p.AtListIndex(i), "Ambiguous stream title", fmt.Sprintf("%d streams are titled %q (%s); use a stream ID instead.", len(matches), v, strings.Join(matches, ", ")))
+		}
+	}
+	if d.HasError() {
+		return types.ListNull(types.StringType), d
+	}
+	list, diags := types.ListValueFrom(context.Background(), types.StringType, ids)
+	d.Append(diags...)
+	return list, d
+}
+
+// filterStreamIDs returns the stream IDs to send to Graylog: resolved IDs when known, else the raw entries.
+func filterStreamIDs(f alertFilterModel) []string {
+	var out []string
+	if !f.StreamIDs.IsNull() && !f.StreamIDs.IsUnknown() {
+		for _, v := range f.StreamIDs.Elements() {
+			if s, ok := v.(types.String); ok && s.ValueString() != "" {
+				out = append(out, s.ValueString())
+			}
+		}
+		return out
+	}
+	for _, v := range f.Streams {
+		if !v.IsNull() && !v.IsUnknown() && strings.TrimSpace(v.ValueString()) != "" {
+			out = append(out, v.ValueString())
+		}
+	}
+	return out
+}
+
+// readFilterStreams refreshes the filter from the streams Graylog reports. Titles and aliases are kept
+// while they still resolve to the same IDs; otherwise the server IDs replace them to surface drift.
+func readFilterStreams(f *alertFilterModel, server []string) {
+	prev := filterStreamIDs(*f)
+	same := len(prev) == len(server)
+	for i := 0; same && i < len(prev); i++ {
+		same = prev[i] == server[i]
+	}
+	if !same {
+		out := make([]types.String, 0, len(server))
+		for _, s := range server {
+			out = append(out, types.StringValue(s))
+		}
+		f.Streams = out
+	}
+	f.StreamIDs, _ = types.ListValueFrom(context.Background(), types.StringType, server)
+}
+
 func (r *alertResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var dataV2 alertModelV2
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataV2)...)
@@ -227,6 +520,11 @@ func (r *alertResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Runtime validation
 	resp.Diagnostics.Append(validateAlert(ctx, &dataV2.alertModel)...)
+	resp.Diagnostics.Append(validateAlertSpecs(&dataV2)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.resolveFilterStreams(ctx, &dataV2)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -251,23 +549,33 @@ func (r *alertResource) Create(ctx context.Context, req resource.CreateRequest,
 		nids = append(nids, v.ValueString())
 	}
 
-	created, err := r.client.WithContext(ctx).CreateEventDefinition(&client.EventDefinition{
+	ed := &client.EventDefinition{
 		Title:           dataV2.Title.ValueString(),
 		Description:     dataV2.Description.ValueString(),
 		Priority:        int(dataV2.Priority.ValueInt64()),
 		Alert:           dataV2.Alert.ValueBool(),
 		Config:          cfg,
 		NotificationIDs: nids,
-	})
+	}
+	applyAlertSpecs(ed, &dataV2)
+	if !dataV2.Scheduled.IsNull() && !dataV2.Scheduled.IsUnknown() {
+		// Save an unscheduled definition without ever scheduling it
+		ed.Schedule = dataV2.Scheduled.ValueBoolPointer()
+	}
+	created, err := r.client.WithContext(ctx).CreateEventDefinition(ed)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating alert", err.Error())
 		return
 	}
-	// Keep config in state as canonical JSON for stability
-	if s, err := CanonicalizeJSONValue(cfg); err == nil {
-		dataV2.Config = types.StringValue(s)
+	// Keep config in state as canonical JSON for stability (typed blocks leave it null)
+	if !dataV2.hasTypedBlock() {
+		if s, err := CanonicalizeJSONValue(cfg); err == nil {
+			dataV2.Config = types.StringValue(s)
+		}
 	}
 	dataV2.ID = types.StringValue(created.ID)
+	// A scheduling failure still records the definition so it is tainted rather than orphaned
+	resp.Diagnostics.Append(r.applyScheduled(ctx, &dataV2)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataV2)...)
 }
 
@@ -291,8 +599,8 @@ func (r *alertResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	dataV2.Description = types.StringValue(ed.Description)
 	dataV2.Priority = types.Int64Value(int64(ed.Priority))
 	dataV2.Alert = types.BoolValue(ed.Alert)
-	// Pass-through config back to state as JSON
-	if b, err := json.Marshal(ed.Config); err == nil {
+	// Pass-through config back to state as JSON unless a typed block owns it
+	if b, err := json.Marshal(ed.Config); err == nil && !dataV2.hasTypedBlock() {
 		// Canonicalize for stable plans
 		if s, err2 := CanonicalizeJSONFromString(string(b)); err2 == nil {
 			dataV2.Config = types.StringValue(s)
@@ -315,6 +623,13 @@ func (r *alertResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if dataV2.Threshold != nil {
 		populateThresholdFromConfig(&dataV2, ed.Config)
 	}
+	if dataV2.Filter != nil {
+		populateFilterFromConfig(&dataV2, ed.Config)
+	}
+	readAlertSpecs(&dataV2, ed)
+	if sched, err := r.client.WithContext(ctx).IsEventDefinitionScheduled(dataV2.ID.ValueString()); err == nil {
+		dataV2.Scheduled = types.BoolValue(sched)
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataV2)...)
 }
 
@@ -327,6 +642,11 @@ func (r *alertResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	// Runtime validation
 	resp.Diagnostics.Append(validateAlert(ctx, &dataV2.alertModel)...)
+	resp.Diagnostics.Append(validateAlertSpecs(&dataV2)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.resolveFilterStreams(ctx, &dataV2)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -349,25 +669,64 @@ func (r *alertResource) Update(ctx context.Context, req resource.UpdateRequest,
 		nids = append(nids, v.ValueString())
 	}
 
-	_, err := r.client.WithContext(ctx).UpdateEventDefinition(dataV2.ID.ValueString(), &client.EventDefinition{
+	ed := &client.EventDefinition{
 		Title:           dataV2.Title.ValueString(),
 		Description:     dataV2.Description.ValueString(),
 		Priority:        int(dataV2.Priority.ValueInt64()),
 		Alert:           dataV2.Alert.ValueBool(),
 		Config:          cfg,
 		NotificationIDs: nids,
-	})
+	}
+	applyAlertSpecs(ed, &dataV2)
+	if !dataV2.Scheduled.IsNull() && !dataV2.Scheduled.IsUnknown() {
+		// Save an unscheduled definition without ever scheduling it
+		ed.Schedule = dataV2.Scheduled.ValueBoolPointer()
+	}
+	_, err := r.client.WithContext(ctx).UpdateEventDefinition(dataV2.ID.ValueString(), ed)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating alert", err.Error())
 		return
 	}
-	// Keep config in state as canonical JSON for stability
-	if s, err := CanonicalizeJSONValue(cfg); err == nil {
-		dataV2.Config = types.StringValue(s)
+	// Keep config in state as canonical JSON for stability (typed blocks leave it null)
+	if !dataV2.hasTypedBlock() {
+		if s, err := CanonicalizeJSONValue(cfg); err == nil {
+			dataV2.Config = types.StringValue(s)
+		}
 	}
+	// Graylog re-schedules definitions on update unless told otherwise, so the desired state is always re-applied
+	resp.Diagnostics.Append(r.applyScheduled(ctx, &dataV2)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataV2)...)
 }
 
+// applyScheduled enforces `scheduled` after create/update. When it is not configured the
+// server's state is recorded instead.
+func (r *alertResource) applyScheduled(ctx context.Context, m *alertModelV2) (d diag.Diagnostics) {
+	c := r.client.WithContext(ctx)
+	id := m.ID.ValueString()
+	if m.Scheduled.IsNull() || m.Scheduled.IsUnknown() {
+		sched, err := c.IsEventDefinitionScheduled(id)
+		if err != nil {
+			// Graylog schedules new and updated definitions by default
+			sched = true
+		}
+		m.Scheduled = types.BoolValue(sched)
+		return d
+	}
+	var err error
+	if m.Scheduled.ValueBool() {
+		// Scheduling an already scheduled definition is harmless, but skip the call when we can tell
+		if cur, cerr := c.IsEventDefinitionScheduled(id); cerr != nil || !cur {
+			err = c.ScheduleEventDefinition(id)
+		}
+	} else {
+		err = c.UnscheduleEventDefinition(id)
+	}
+	if err != nil {
+		d.AddError("Error scheduling alert", fmt.Sprintf("Event definition %s was saved but setting scheduled=%t failed: %s", id, m.Scheduled.ValueBool(), err))
+	}
+	return d
+}
+
 func (r *alertResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var dataV2 alertModelV2
 	resp.Diagnostics.Append(req.State.Get(ctx, &dataV2)...)
@@ -478,13 +837,49 @@ func buildEventConfigFromModel(ctx context.Context, m *alertModelV2) (map[string
 			hasAggregation = true
 		} else if !m.Aggregation.Threshold.Type.IsNull() && !m.Aggregation.Threshold.Type.IsUnknown() && m.Aggregation.Threshold.Type.ValueString() != "" {
 			hasAggregation = true
+		} else if !m.Aggregation.Condition.IsNull() {
+			hasAggregation = true
 		}
 	}
 	// Mutual exclusivity between typed blocks
-	if hasThreshold && hasAggregation {
-		d.AddError("Ambiguous typed configuration", "Only one typed block may be set: either 'threshold' or 'aggregation'.")
+	n := 0
+	for _, has := range []bool{hasThreshold, hasAggregation, m.Filter != nil, m.SystemNotification != nil} {
+		if has {
+			n++
+		}
+	}
+	if n > 1 {
+		d.AddError("Ambiguous typed configuration", "Only one typed block may be set: 'threshold', 'aggregation', 'filter' or 'system_notification'.")
 		return nil, d
 	}
+	if m.SystemNotification != nil {
+		return map[string]interface{}{"type": "system-notifications-v1"}, d
+	}
+	if m.Filter != nil {
+		for i, s := range m.Filter.Streams {
+			if s.IsNull() || s.IsUnknown() || strings.TrimSpace(s.ValueString()) == "" {
+				d.AddAttributeError(path.Root("filter").AtName("streams").AtListIndex(i), "Invalid stream id", "Each 'streams' entry must be a non-empty string")
+			}
+		}
+		if d.HasError() {
+			return nil, d
+		}
+		// Filter-only definitions are aggregations without series or conditions
+		cfg := map[string]interface{}{
+			"type":     "aggregation-v1",
+			"query":    m.Filter.Query.ValueString(),
+			"streams":  []string{},
+			"group_by": []string{},
+			"series":   []interface{}{},
+		}
+		if arr := filterStreamIDs(m.Filter.alertFilterModel); len(arr) > 0 {
+			cfg["streams"] = arr
+		}
+		// Graylog rejects aggregations without a window and interval; send the documented defaults
+		cfg["search_within_ms"] = int64OrDefault(m.Filter.SearchWithinMs, defaultFilterIntervalMs)
+		cfg["execute_every_ms"] = int64OrDefault(m.Filter.ExecuteEveryMs, defaultFilterIntervalMs)
+		return cfg, d
+	}
 	if hasThreshold {
 		// Validate required fields when typed threshold block is used
 		if m.Threshold == nil || m.Threshold.Threshold.Type.IsNull() || m.Threshold.Threshold.Type.IsUnknown() || m.Threshold.Threshold.Type.ValueString() == "" {
@@ -542,16 +937,8 @@ func buildEventConfigFromModel(ctx context.Context, m *alertModelV2) (map[string
 			}
 		}
 		// filter.streams → streams
-		if len(m.Threshold.Filter.Streams) > 0 {
-			arr := make([]string, 0, len(m.Threshold.Filter.Streams))
-			for _, v := range m.Threshold.Filter.Streams {
-				if !v.IsNull() && !v.IsUnknown() && strings.TrimSpace(v.ValueString()) != "" {
-					arr = append(arr, v.ValueString())
-				}
-			}
-			if len(arr) > 0 {
-				cfg["streams"] = arr
-			}
+		if arr := filterStreamIDs(m.Threshold.Filter); len(arr) > 0 {
+			cfg["streams"] = arr
 		}
 		// series
 		if len(m.Threshold.Series) > 0 {
@@ -650,16 +1037,8 @@ func buildEventConfigFromModel(ctx context.Context, m *alertModelV2) (map[string
 			}
 		}
 		// filter.streams → streams
-		if len(m.Aggregation.Filter.Streams) > 0 {
-			arr := make([]string, 0, len(m.Aggregation.Filter.Streams))
-			for _, v := range m.Aggregation.Filter.Streams {
-				if !v.IsNull() && !v.IsUnknown() && strings.TrimSpace(v.ValueString()) != "" {
-					arr = append(arr, v.ValueString())
-				}
-			}
-			if len(arr) > 0 {
-				cfg["streams"] = arr
-			}
+		if arr := filterStreamIDs(m.Aggregation.Filter); len(arr) > 0 {
+			cfg["streams"] = arr
 		}
 		if len(m.Aggregation.Series) > 0 {
 			sers := make([]map[string]interface{}, 0, len(m.Aggregation.Series))
@@ -679,6 +1058,21 @@ func buildEventConfigFromModel(ctx context.Context, m *alertModelV2) (map[string
 				cfg["series"] = sers
 			}
 		}
+		// condition tree → conditions.expression
+		if cond, ok := alertConditionFromObject(m.Aggregation.Condition); ok {
+			seriesIDs := map[string]bool{}
+			for _, s := range m.Aggregation.Series {
+				seriesIDs[s.ID.ValueString()] = true
+			}
+			d.Append(validateAlertCondition(path.Root("aggregation").AtName("condition"), cond, seriesIDs)...)
+			if m.Aggregation.Threshold.Type.ValueString() != "" {
+				d.AddAttributeError(path.Root("aggregation").AtName("threshold"), "Conflicting conditions", "Set either 'threshold' or 'condition' on 'aggregation', not both.")
+			}
+			if d.HasError() {
+				return nil, d
+			}
+			cfg["conditions"] = map[string]interface{}{"expression": renderAlertCondition(cond)}
+		}
 		// optional threshold for aggregation
 		if m.Aggregation != nil {
 			th := map[string]interface{}{}
@@ -756,13 +1150,13 @@ func populateThresholdFromConfig(m *alertModelV2, cfg map[string]interface{}) {
 		m.Threshold.GroupBy = g
 	}
 	if st, ok := cfg["streams"].([]interface{}); ok {
-		out := make([]types.String, 0, len(st))
+		out := make([]string, 0, len(st))
 		for _, v := range st {
 			if s, ok := v.(string); ok && s != "" {
-				out = append(out, types.StringValue(s))
+				out = append(out, s)
 			}
 		}
-		m.Threshold.Filter.Streams = out
+		readFilterStreams(&m.Threshold.Filter, out)
 	}
 	if ser, ok := cfg["series"].([]interface{}); ok {
 		out := make([]alertSeriesModel, 0, len(ser))
@@ -840,13 +1234,13 @@ func populateAggregationFromConfig(m *alertModelV2, cfg map[string]interface{})
 		m.Aggregation.GroupBy = g
 	}
 	if st, ok := cfg["streams"].([]interface{}); ok {
-		out := make([]types.String, 0, len(st))
+		out := make([]string, 0, len(st))
 		for _, v := range st {
 			if s, ok := v.(string); ok && s != "" {
-				out = append(out, types.StringValue(s))
+				out = append(out, s)
 			}
 		}
-		m.Aggregation.Filter.Streams = out
+		readFilterStreams(&m.Aggregation.Filter, out)
 	}
 	if ser, ok := cfg["series"].([]interface{}); ok {
 		out := make([]alertSeriesModel, 0, len(ser))
@@ -885,4 +1279,57 @@ func populateAggregationFromConfig(m *alertModelV2, cfg map[string]interface{})
 			}
 		}
 	}
+	m.Aggregation.Condition = types.ObjectNull(alertConditionAttrTypes(0))
+	if cs, ok := cfg["conditions"].(map[string]interface{}); ok {
+		if expr, ok := cs["expression"].(map[string]interface{}); ok {
+			if cond, err := parseAlertCondition(expr); err == nil {
+				if o, diags := cond.toObject(0); !diags.HasError() {
+					m.Aggregation.Condition = o
+				}
+			}
+		}
+	}
+}
+
+// defaultFilterIntervalMs is the search window and execution interval of a filter block that
+// leaves them unset, matching the Graylog UI default.
+const defaultFilterIntervalMs = 5 * 60 * 1000
+
+// populateFilterFromConfig fills the typed filter block from a filter-only aggregation config
+func populateFilterFromConfig(m *alertModelV2, cfg map[string]interface{}) {
+	if cfg == nil {
+		return
+	}
+	if t, ok := cfg["type"].(string); !ok || t != "aggregation-v1" {
+		return
+	}
+	if ser, ok := cfg["series"].([]interface{}); ok && len(ser) > 0 {
+		return
+	}
+	if q, ok := cfg["query"].(string); ok && (q != "" || !m.Filter.Query.IsNull()) {
+		m.Filter.Query = types.StringValue(q)
+	}
+	// Unset values stay null while Graylog reports the default that was sent for them
+	if sw, ok := cfg["search_within_ms"].(float64); ok && (int64(sw) != defaultFilterIntervalMs || !m.Filter.SearchWithinMs.IsNull()) {
+		m.Filter.SearchWithinMs = types.Int64Value(int64(sw))
+	}
+	if ex, ok := cfg["execute_every_ms"].(float64); ok && (int64(ex) != defaultFilterIntervalMs || !m.Filter.ExecuteEveryMs.IsNull()) {
+		m.Filter.ExecuteEveryMs = types.Int64Value(int64(ex))
+	}
+	if st, ok := cfg["streams"].([]interface{}); ok {
+		out := make([]string, 0, len(st))
+		for _, v := range st {
+			if s, ok := v.(string); ok && s != "" {
+				out = append(out, s)
+			}
+		}
+		if len(out) > 0 || m.Filter.Streams != nil {
+			readFilterStreams(&m.Filter.alertFilterModel, out)
+		}
+	}
+}
+
+// hasTypedBlock reports whether a typed configuration block (rather than `config`) defines the event.
+func (m *alertModelV2) hasTypedBlock() bool {
+	return m.Threshold != nil || m.Aggregation != nil || m.Filter != nil || m.SystemNotification != nil
 }
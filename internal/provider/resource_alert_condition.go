@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Aggregation conditions: a tree of and/or/not over series comparisons, rendered into
+// Graylog's conditions.expression ({"expr": ">", "left": {"expr": "number-ref", "ref": ...}, ...}).
+// Terraform schemas cannot recurse, so the `condition` block nests up to alertConditionDepth levels.
+
+const alertConditionDepth = 4
+
+// alertConditionOps maps operators to Graylog expression names.
+var alertConditionOps = map[string]string{
+	"and":           "&&",
+	"or":            "||",
+	"not":           "!",
+	"greater":       ">",
+	"greater_equal": ">=",
+	"lower":         "<",
+	"lower_equal":   "<=",
+	"equal":         "==",
+}
+
+var alertConditionOpNames = []string{"and", "or", "not", "greater", "greater_equal", "lower", "lower_equal", "equal"}
+
+// alertCondition is the decoded form of a `condition` block.
+type alertCondition struct {
+	Operator  string
+	SeriesRef string
+	Value     *float64
+	Children  []alertCondition
+}
+
+func (c alertCondition) isLogical() bool {
+	return c.Operator == "and" || c.Operator == "or" || c.Operator == "not"
+}
+
+func alertConditionAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"operator": schema.StringAttribute{
+			Optional:    true,
+			Description: "and | or | not (over nested condition blocks) or greater | greater_equal | lower | lower_equal | equal (series_ref against value)",
+			Validators:  []validator.String{stringvalidator.OneOf(alertConditionOpNames...)},
+		},
+		"series_ref": schema.StringAttribute{Optional: true, Description: "ID of the series compared by greater/lower/equal operators"},
+		"value":      schema.Float64Attribute{Optional: true, Description: "Value the series is compared against"},
+	}
+}
+
+func alertConditionChildBlocks(level int) map[string]schema.Block {
+	if level >= alertConditionDepth {
+		return nil
+	}
+	return map[string]schema.Block{
+		"condition": schema.ListNestedBlock{
+			Description: "Operands of and/or (two or more) and not (exactly one)",
+			NestedObject: schema.NestedBlockObject{
+				Attributes: alertConditionAttributes(),
+				Blocks:     alertConditionChildBlocks(level + 1),
+			},
+		},
+	}
+}
+
+// alertConditionBlock is the root `condition` block on `aggregation`.
+func alertConditionBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: fmt.Sprintf("Condition tree over the series (maps to conditions.expression); nests up to %d levels", alertConditionDepth),
+		Attributes:  alertConditionAttributes(),
+		Blocks:      alertConditionChildBlocks(1),
+	}
+}
+
+func alertConditionAttrTypes(level int) map[string]attr.Type {
+	t := map[string]attr.Type{
+		"operator":   types.StringType,
+		"series_ref": types.StringType,
+		"value":      types.Float64Type,
+	}
+	if level+1 < alertConditionDepth {
+		t["condition"] = types.ListType{ElemType: types.ObjectType{AttrTypes: alertConditionAttrTypes(level + 1)}}
+	}
+	return t
+}
+
+// alertConditionFromObject decodes a condition object. ok is false when the object is null
+// or contains unknown values.
+func alertConditionFromObject(o types.Object) (c alertCondition, ok bool) {
+	if o.IsNull() || o.IsUnknown() {
+		return c, false
+	}
+	attrs := o.Attributes()
+	for _, v := range attrs {
+		if v.IsUnknown() {
+			return c, false
+		}
+	}
+	if v, _ := attrs["operator"].(types.String); !v.IsNull() {
+		c.Operator = v.ValueString()
+	}
+	if v, _ := attrs["series_ref"].(types.String); !v.IsNull() {
+		c.SeriesRef = v.ValueString()
+	}
+	if v, _ := attrs["value"].(types.Float64); !v.IsNull() {
+		f := v.ValueFloat64()
+		c.Value = &f
+	}
+	if l, isList := attrs["condition"].(types.List); isList && !l.IsNull() {
+		for _, e := range l.Elements() {
+			child, childOK := alertConditionFromObject(e.(types.Object))
+			if !childOK {
+				return c, false
+			}
+			c.Children = append(c.Children, child)
+		}
+	}
+	return c, true
+}
+
+// toObject encodes the condition at the given nesting level (0 is the root block).
+func (c alertCondition) toObject(level int) (types.Object, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	vals := map[string]attr.Value{
+		"operator":   types.StringValue(c.Operator),
+		"series_ref": types.StringNull(),
+		"value":      types.Float64Null(),
+	}
+	if c.SeriesRef != "" {
+		vals["series_ref"] = types.StringValue(c.SeriesRef)
+	}
+	if c.Value != nil {
+		vals["value"] = types.Float64Value(*c.Value)
+	}
+	if level+1 < alertConditionDepth {
+		elemType := types.ObjectType{AttrTypes: alertConditionAttrTypes(level + 1)}
+		children := make([]attr.Value, 0, len(c.Children))
+		for _, ch := range c.Children {
+			o, d := ch.toObject(level + 1)
+			diags.Append(d...)
+			children = append(children, o)
+		}
+		l, d := types.ListValue(elemType, children)
+		diags.Append(d...)
+		vals["condition"] = l
+	} else if len(c.Children) > 0 {
+		diags.AddError("Condition nested too deeply", fmt.Sprintf("Graylog returned a condition deeper than %d levels; use the JSON config instead.", alertConditionDepth))
+	}
+	o, d := types.ObjectValue(alertConditionAttrTypes(level), vals)
+	diags.Append(d...)
+	return o, diags
+}
+
+// validateAlertCondition checks operator arity and that every series_ref names a declared series.
+func validateAlertCondition(p path.Path, c alertCondition, seriesIDs map[string]bool) (d diag.Diagnostics) {
+	switch {
+	case c.Operator == "":
+		d.AddAttributeError(p.AtName("operator"), "Missing operator", "Each condition needs an 'operator'.")
+	case c.isLogical():
+		if c.SeriesRef != "" || c.Value != nil {
+			d.AddAttributeError(p, "Invalid condition", "'"+c.Operator+"' combines nested condition blocks; 'series_ref' and 'value' are not allowed.")
+		}
+		if c.Operator == "not" && len(c.Children) != 1 {
+			d.AddAttributeError(p, "Invalid condition", "'not' needs exactly one nested condition block.")
+		}
+		if c.Operator != "not" && len(c.Children) < 2 {
+			d.AddAttributeError(p, "Invalid condition", "'"+c.Operator+"' needs at least two nested condition blocks.")
+		}
+	default:
+		if len(c.Children) > 0 {
+			d.AddAttributeError(p, "Invalid condition", "'"+c.Operator+"' compares a series with a value; nested condition blocks are not allowed.")
+		}
+		if c.SeriesRef == "" {
+			d.AddAttributeError(p.AtName("series_ref"), "Missing series_ref", "'"+c.Operator+"' needs 'series_ref'.")
+		} else if !seriesIDs[c.SeriesRef] {
+			d.AddAttributeError(p.AtName("series_ref"), "Unknown series", "Series '"+c.SeriesRef+"' is not declared in a 'series' block with that 'id'.")
+		}
+		if c.Value == nil {
+			d.AddAttributeError(p.AtName("value"), "Missing value", "'"+c.Operator+"' needs 'value'.")
+		}
+	}
+	for i, ch := range c.Children {
+		d.Append(validateAlertCondition(p.AtName("condition").AtListIndex(i), ch, seriesIDs)...)
+	}
+	return d
+}
+
+// renderAlertCondition builds the Graylog expression. n-ary and/or become a left-folded chain;
+// nested and/or operands are wrapped in a "group" so they parse back as separate blocks.
+func renderAlertCondition(c alertCondition) map[string]interface{} {
+	op := alertConditionOps[c.Operator]
+	switch c.Operator {
+	case "not":
+		return map[string]interface{}{"expr": op, "left": renderAlertOperand(c.Children[0])}
+	case "and", "or":
+		expr := renderAlertOperand(c.Children[0])
+		for _, ch := range c.Children[1:] {
+			expr = map[string]interface{}{"expr": op, "left": expr, "right": renderAlertOperand(ch)}
+		}
+		return expr
+	}
+	return map[string]interface{}{
+		"expr":  op,
+		"left":  map[string]interface{}{"expr": "number-ref", "ref": c.SeriesRef},
+		"right": map[string]interface{}{"expr": "number", "value": *c.Value},
+	}
+}
+
+func renderAlertOperand(c alertCondition) map[string]interface{} {
+	if c.Operator == "and" || c.Operator == "or" {
+		return map[string]interface{}{"expr": "group", "child": renderAlertCondition(c)}
+	}
+	return renderAlertCondition(c)
+}
+
+// parseAlertCondition is the inverse of renderAlertCondition.
+func parseAlertCondition(expr map[string]interface{}) (alertCondition, error) {
+	e, _ := expr["expr"].(string)
+	if e == "group" {
+		child, _ := expr["child"].(map[string]interface{})
+		return parseAlertCondition(child)
+	}
+	var op string
+	for name, v := range alertConditionOps {
+		if v == e {
+			op = name
+		}
+	}
+	switch op {
+	case "":
+		return alertCondition{}, fmt.Errorf("unsupported expression %q", e)
+	case "not":
+		left, _ := expr["left"].(map[string]interface{})
+		child, err := parseAlertCondition(left)
+		if err != nil {
+			return alertCondition{}, err
+		}
+		return alertCondition{Operator: op, Children: []alertCondition{child}}, nil
+	case "and", "or":
+		c := alertCondition{Operator: op}
+		for _, side := range []string{"left", "right"} {
+			sub, _ := expr[side].(map[string]interface{})
+			if s, _ := sub["expr"].(string); s == e {
+				// Ungrouped operand with the same operator: part of the same n-ary chain
+				flat, err := parseAlertCondition(sub)
+				if err != nil {
+					return alertCondition{}, err
+				}
+				c.Children = append(c.Children, flat.Children...)
+				continue
+			}
+			child, err := parseAlertCondition(sub)
+			if err != nil {
+				return alertCondition{}, err
+			}
+			c.Children = append(c.Children, child)
+		}
+		return c, nil
+	}
+	left, _ := expr["left"].(map[string]interface{})
+	right, _ := expr["right"].(map[string]interface{})
+	ref, _ := left["ref"].(string)
+	val, ok := right["value"].(float64)
+	if left["expr"] != "number-ref" || right["expr"] != "number" || !ok {
+		return alertCondition{}, fmt.Errorf("unsupported operands for %q", e)
+	}
+	return alertCondition{Operator: op, SeriesRef: ref, Value: &val}, nil
+}
@@ -0,0 +1,297 @@
+package provider
+
+import (
+	"sort"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Top-level parts of an Event Definition that live outside `config`:
+// custom event fields, event key, notification settings and storage handlers.
+
+// alertFieldSpecModel is one custom event field with a single value provider (template or lookup table)
+type alertFieldSpecModel struct {
+	Name            types.String `tfsdk:"name"`
+	DataType        types.String `tfsdk:"data_type"`
+	Template        types.String `tfsdk:"template"`
+	RequireValues   types.Bool   `tfsdk:"require_values"`
+	LookupTableName types.String `tfsdk:"lookup_table_name"`
+	LookupKeyField  types.String `tfsdk:"lookup_key_field"`
+}
+
+// alertNotificationSettingsModel maps to notification_settings
+type alertNotificationSettingsModel struct {
+	GracePeriodMs types.Int64 `tfsdk:"grace_period_ms"`
+	BacklogSize   types.Int64 `tfsdk:"backlog_size"`
+}
+
+// alertStorageModel is one storage handler (Graylog only ships persist-to-streams-v1)
+type alertStorageModel struct {
+	Type    types.String   `tfsdk:"type"`
+	Streams []types.String `tfsdk:"streams"`
+}
+
+const (
+	fieldProviderTemplate = "template-v1"
+	fieldProviderLookup   = "lookup-v1"
+	storagePersistStreams = "persist-to-streams-v1"
+)
+
+func alertSpecBlocks() map[string]schema.Block {
+	return map[string]schema.Block{
+		"field_spec": schema.ListNestedBlock{
+			Description: "Custom event fields, each filled by a template or a lookup table",
+			NestedObject: schema.NestedBlockObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{Required: true, Description: "Event field name"},
+					"data_type": schema.StringAttribute{
+						Optional:    true,
+						Description: "Field data type (default string)",
+						Validators:  []validator.String{stringvalidator.OneOf("string", "error")},
+					},
+					"template":          schema.StringAttribute{Optional: true, Description: "Value template, e.g. ${source.source} (template-v1 provider)"},
+					"require_values":    schema.BoolAttribute{Optional: true, Description: "Fail the template when a referenced value is missing"},
+					"lookup_table_name": schema.StringAttribute{Optional: true, Description: "Lookup table name (lookup-v1 provider)"},
+					"lookup_key_field":  schema.StringAttribute{Optional: true, Description: "Message field used as the lookup key"},
+				},
+			},
+		},
+		"notification_settings": schema.SingleNestedBlock{
+			Description: "Notification grace period and backlog",
+			Attributes: map[string]schema.Attribute{
+				"grace_period_ms": schema.Int64Attribute{Optional: true, Description: "Grace period in milliseconds between notifications (non-negative)"},
+				"backlog_size":    schema.Int64Attribute{Optional: true, Description: "Number of messages included in notifications (non-negative)"},
+			},
+		},
+		"storage": schema.ListNestedBlock{
+			Description: "Where events are stored",
+			NestedObject: schema.NestedBlockObject{
+				Attributes: map[string]schema.Attribute{
+					"type":    schema.StringAttribute{Optional: true, Description: "Storage handler type (default persist-to-streams-v1)"},
+					"streams": schema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Event stream IDs, e.g. 000000000000000000000002 (All events)"},
+				},
+			},
+		},
+	}
+}
+
+// validateAlertSpecs checks field_spec providers, key_spec references and non-negative notification settings.
+func validateAlertSpecs(m *alertModelV2) (d diag.Diagnostics) {
+	names := map[string]bool{}
+	for i, f := range m.FieldSpec {
+		p := path.Root("field_spec").AtListIndex(i)
+		if n := f.Name.ValueString(); n != "" {
+			if names[n] {
+				d.AddAttributeError(p.AtName("name"), "Duplicate field name", "Field '"+n+"' is defined more than once.")
+			}
+			names[n] = true
+		}
+		hasTemplate := !f.Template.IsNull() && !f.Template.IsUnknown()
+		hasLookup := !f.LookupTableName.IsNull() && !f.LookupTableName.IsUnknown()
+		switch {
+		case hasTemplate && hasLookup:
+			d.AddAttributeError(p, "Conflicting field providers", "Set either 'template' or 'lookup_table_name', not both.")
+		case !hasTemplate && !hasLookup && !f.Template.IsUnknown() && !f.LookupTableName.IsUnknown():
+			d.AddAttributeError(p, "Missing field provider", "Set 'template' or 'lookup_table_name'.")
+		case hasLookup && stringOrDefault(f.LookupKeyField, "") == "" && !f.LookupKeyField.IsUnknown():
+			d.AddAttributeError(p.AtName("lookup_key_field"), "Missing lookup_key_field", "'lookup_key_field' is required with 'lookup_table_name'.")
+		}
+		if !hasTemplate && !f.RequireValues.IsNull() {
+			d.AddAttributeError(p.AtName("require_values"), "Invalid require_values", "'require_values' only applies to 'template'.")
+		}
+	}
+	for i, k := range m.KeySpec {
+		if k.IsUnknown() {
+			continue
+		}
+		if !names[k.ValueString()] {
+			d.AddAttributeError(path.Root("key_spec").AtListIndex(i), "Unknown key field", "Key field '"+k.ValueString()+"' must be defined in a 'field_spec' block.")
+		}
+	}
+	if ns := m.NotificationSettings; ns != nil {
+		if !ns.GracePeriodMs.IsNull() && !ns.GracePeriodMs.IsUnknown() && ns.GracePeriodMs.ValueInt64() < 0 {
+			d.AddAttributeError(path.Root("notification_settings").AtName("grace_period_ms"), "Invalid grace_period_ms", "'grace_period_ms' must be a non-negative integer")
+		}
+		if !ns.BacklogSize.IsNull() && !ns.BacklogSize.IsUnknown() && ns.BacklogSize.ValueInt64() < 0 {
+			d.AddAttributeError(path.Root("notification_settings").AtName("backlog_size"), "Invalid backlog_size", "'backlog_size' must be a non-negative integer")
+		}
+	}
+	return d
+}
+
+// applyAlertSpecs copies the typed top-level blocks onto the API payload. Unset blocks are left
+// nil so the client's defaults apply.
+func applyAlertSpecs(ed *client.EventDefinition, m *alertModelV2) {
+	if len(m.KeySpec) > 0 {
+		ed.KeySpec = make([]string, 0, len(m.KeySpec))
+		for _, k := range m.KeySpec {
+			ed.KeySpec = append(ed.KeySpec, k.ValueString())
+		}
+	}
+	if ns := m.NotificationSettings; ns != nil {
+		ed.NotificationSettings = map[string]interface{}{
+			"grace_period_ms": ns.GracePeriodMs.ValueInt64(),
+			"backlog_size":    ns.BacklogSize.ValueInt64(),
+		}
+	}
+	if len(m.FieldSpec) > 0 {
+		ed.FieldSpec = make(map[string]interface{}, len(m.FieldSpec))
+		for _, f := range m.FieldSpec {
+			var provider map[string]interface{}
+			if !f.LookupTableName.IsNull() {
+				provider = map[string]interface{}{
+					"type":       fieldProviderLookup,
+					"table_name": f.LookupTableName.ValueString(),
+					"key_field":  f.LookupKeyField.ValueString(),
+				}
+			} else {
+				provider = map[string]interface{}{
+					"type":           fieldProviderTemplate,
+					"template":       f.Template.ValueString(),
+					"require_values": f.RequireValues.ValueBool(),
+				}
+			}
+			ed.FieldSpec[f.Name.ValueString()] = map[string]interface{}{
+				"data_type": stringOrDefault(f.DataType, "string"),
+				"providers": []interface{}{provider},
+			}
+		}
+	}
+	if len(m.Storage) > 0 {
+		ed.Storage = make([]map[string]interface{}, 0, len(m.Storage))
+		for _, s := range m.Storage {
+			streams := make([]string, 0, len(s.Streams))
+			for _, v := range s.Streams {
+				streams = append(streams, v.ValueString())
+			}
+			ed.Storage = append(ed.Storage, map[string]interface{}{
+				"type":    stringOrDefault(s.Type, storagePersistStreams),
+				"streams": streams,
+			})
+		}
+	}
+}
+
+// readAlertSpecs refreshes the typed top-level blocks from the server. Like the typed config blocks,
+// each one is only refreshed when it is set in state, and attributes the user left unset stay null
+// while the server reports Graylog's default for them.
+func readAlertSpecs(m *alertModelV2, ed *client.EventDefinition) {
+	if m.KeySpec != nil {
+		out := make([]types.String, 0, len(ed.KeySpec))
+		for _, k := range ed.KeySpec {
+			out = append(out, types.StringValue(k))
+		}
+		m.KeySpec = out
+	}
+	if ns := m.NotificationSettings; ns != nil {
+		ns.GracePeriodMs = keepNullInt64(ns.GracePeriodMs, ed.NotificationSettings["grace_period_ms"])
+		ns.BacklogSize = keepNullInt64(ns.BacklogSize, ed.NotificationSettings["backlog_size"])
+	}
+	if len(m.FieldSpec) > 0 {
+		m.FieldSpec = readFieldSpec(m.FieldSpec, ed.FieldSpec)
+	}
+	if len(m.Storage) > 0 {
+		out := make([]alertStorageModel, 0, len(ed.Storage))
+		for i, s := range ed.Storage {
+			var prev alertStorageModel
+			if i < len(m.Storage) {
+				prev = m.Storage[i]
+			}
+			tp, _ := s["type"].(string)
+			item := alertStorageModel{Type: keepNullString(prev.Type, tp, storagePersistStreams)}
+			if raw, ok := s["streams"].([]interface{}); ok {
+				item.Streams = make([]types.String, 0, len(raw))
+				for _, v := range raw {
+					if id, ok := v.(string); ok {
+						item.Streams = append(item.Streams, types.StringValue(id))
+					}
+				}
+			}
+			out = append(out, item)
+		}
+		m.Storage = out
+	}
+}
+
+// readFieldSpec converts the server's field_spec map into blocks, keeping the order of prev and
+// appending fields added outside Terraform in name order.
+func readFieldSpec(prev []alertFieldSpecModel, spec map[string]interface{}) []alertFieldSpecModel {
+	out := make([]alertFieldSpecModel, 0, len(spec))
+	seen := map[string]bool{}
+	for _, p := range prev {
+		name := p.Name.ValueString()
+		if raw, ok := spec[name].(map[string]interface{}); ok && !seen[name] {
+			out = append(out, fieldSpecFromAPI(p, name, raw))
+			seen[name] = true
+		}
+	}
+	names := make([]string, 0, len(spec))
+	for name := range spec {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if raw, ok := spec[name].(map[string]interface{}); ok {
+			out = append(out, fieldSpecFromAPI(alertFieldSpecModel{}, name, raw))
+		}
+	}
+	return out
+}
+
+func fieldSpecFromAPI(prev alertFieldSpecModel, name string, raw map[string]interface{}) alertFieldSpecModel {
+	dt, _ := raw["data_type"].(string)
+	f := alertFieldSpecModel{
+		Name:            types.StringValue(name),
+		DataType:        keepNullString(prev.DataType, dt, "string"),
+		Template:        types.StringNull(),
+		RequireValues:   types.BoolNull(),
+		LookupTableName: types.StringNull(),
+		LookupKeyField:  types.StringNull(),
+	}
+	providers, _ := raw["providers"].([]interface{})
+	if len(providers) == 0 {
+		return f
+	}
+	p, _ := providers[0].(map[string]interface{})
+	switch p["type"] {
+	case fieldProviderLookup:
+		tn, _ := p["table_name"].(string)
+		kf, _ := p["key_field"].(string)
+		f.LookupTableName = types.StringValue(tn)
+		f.LookupKeyField = types.StringValue(kf)
+	default:
+		tpl, _ := p["template"].(string)
+		f.Template = types.StringValue(tpl)
+		rv, _ := p["require_values"].(bool)
+		if !prev.RequireValues.IsNull() || rv {
+			f.RequireValues = types.BoolValue(rv)
+		}
+	}
+	return f
+}
+
+// keepNullString returns the server value, or null when the prior value was null and the server
+// reports the default.
+func keepNullString(prev types.String, v, def string) types.String {
+	if prev.IsNull() && (v == "" || v == def) {
+		return types.StringNull()
+	}
+	return types.StringValue(v)
+}
+
+// keepNullInt64 is keepNullString for JSON numbers with a zero default.
+func keepNullInt64(prev types.Int64, v interface{}) types.Int64 {
+	n, _ := v.(float64)
+	if prev.IsNull() && n == 0 {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(n))
+}
@@ -2,10 +2,16 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"reflect"
 	"testing"
 
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
 
 func Test_buildEventConfigFromModel_TypedThreshold(t *testing.T) {
@@ -198,6 +204,67 @@ func Test_buildEventConfigFromModel_Validation_Negatives(t *testing.T) {
 	}
 }
 
+func TestResolveFilterStreamList(t *testing.T) {
+	calls := 0
+	lookup := func() ([]client.Stream, error) {
+		calls++
+		return []client.Stream{
+			{ID: "5f0000000000000000000001", Title: "Errors"},
+			{ID: "5f0000000000000000000002", Title: "Dup"},
+			{ID: "5f0000000000000000000003", Title: "Dup"},
+		}, nil
+	}
+	p := path.Root("threshold").AtName("filter").AtName("streams")
+
+	// IDs and aliases never list streams
+	list, diags := resolveFilterStreamList(p, []types.String{typesString("all_events"), typesString("5f00000000000000000000aa")}, lookup)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+	var ids []string
+	list.ElementsAs(context.Background(), &ids, false)
+	if !reflect.DeepEqual(ids, []string{"000000000000000000000002", "5f00000000000000000000aa"}) || calls != 0 {
+		t.Fatalf("unexpected ids %v (lookups: %d)", ids, calls)
+	}
+
+	list, diags = resolveFilterStreamList(p, []types.String{typesString("Errors"), typesString("default")}, lookup)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+	list.ElementsAs(context.Background(), &ids, false)
+	if !reflect.DeepEqual(ids, []string{"5f0000000000000000000001", "000000000000000000000001"}) {
+		t.Fatalf("unexpected ids %v", ids)
+	}
+
+	for _, name := range []string{"Dup", "Missing"} {
+		list, diags = resolveFilterStreamList(p, []types.String{typesString(name)}, lookup)
+		if !diags.HasError() || !list.IsNull() {
+			t.Fatalf("expected error for %q, got %v", name, list)
+		}
+	}
+
+	list, diags = resolveFilterStreamList(p, []types.String{typesString("Errors"), types.StringUnknown()}, lookup)
+	if diags.HasError() || !list.IsUnknown() {
+		t.Fatalf("expected unknown result for unknown entry, got %v %+v", list, diags)
+	}
+}
+
+func TestReadFilterStreams(t *testing.T) {
+	resolved, _ := types.ListValueFrom(context.Background(), types.StringType, []string{"000000000000000000000002"})
+	f := alertFilterModel{Streams: []types.String{typesString("all_events")}, StreamIDs: resolved}
+	readFilterStreams(&f, []string{"000000000000000000000002"})
+	if len(f.Streams) != 1 || f.Streams[0].ValueString() != "all_events" {
+		t.Fatalf("alias should be kept when it still resolves to the server IDs: %v", f.Streams)
+	}
+	readFilterStreams(&f, []string{"5f0000000000000000000001"})
+	if len(f.Streams) != 1 || f.Streams[0].ValueString() != "5f0000000000000000000001" {
+		t.Fatalf("server IDs should replace streams on drift: %v", f.Streams)
+	}
+	if got := filterStreamIDs(f); !reflect.DeepEqual(got, []string{"5f0000000000000000000001"}) {
+		t.Fatalf("stream_ids not refreshed: %v", got)
+	}
+}
+
 // helpers for typed values in tests
 func typesString(s string) types.String    { return types.StringValue(s) }
 func typesInt64(v int64) types.Int64       { return types.Int64Value(v) }
@@ -228,3 +295,190 @@ func intEqual(v interface{}, want int64) bool {
 		return false
 	}
 }
+
+func TestAlertSpecs_RoundTrip(t *testing.T) {
+	m := alertModelV2{
+		FieldSpec: []alertFieldSpecModel{
+			{Name: typesString("host"), Template: typesString("${source.source}")},
+			{Name: typesString("owner"), LookupTableName: typesString("owners"), LookupKeyField: typesString("source")},
+		},
+		KeySpec:              []types.String{typesString("host")},
+		NotificationSettings: &alertNotificationSettingsModel{GracePeriodMs: typesInt64(60000)},
+		Storage:              []alertStorageModel{{Streams: []types.String{typesString("000000000000000000000002")}}},
+	}
+	if d := validateAlertSpecs(&m); d.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", d)
+	}
+	ed := &client.EventDefinition{}
+	applyAlertSpecs(ed, &m)
+	if ed.Storage[0]["type"] != storagePersistStreams || ed.NotificationSettings["grace_period_ms"] != int64(60000) {
+		t.Fatalf("unexpected payload: %+v", ed)
+	}
+
+	// Simulate the server echoing the payload back as JSON
+	raw, _ := json.Marshal(ed)
+	var back client.EventDefinition
+	if err := json.Unmarshal(raw, &back); err != nil {
+		t.Fatal(err)
+	}
+	read := m
+	read.NotificationSettings = &alertNotificationSettingsModel{GracePeriodMs: m.NotificationSettings.GracePeriodMs}
+	readAlertSpecs(&read, &back)
+	if !reflect.DeepEqual(read.FieldSpec, m.FieldSpec) {
+		t.Fatalf("field_spec drift:\n got %+v\nwant %+v", read.FieldSpec, m.FieldSpec)
+	}
+	if !read.NotificationSettings.BacklogSize.IsNull() || read.NotificationSettings.GracePeriodMs.ValueInt64() != 60000 {
+		t.Fatalf("notification_settings drift: %+v", read.NotificationSettings)
+	}
+	if !reflect.DeepEqual(read.Storage, m.Storage) || !reflect.DeepEqual(read.KeySpec, m.KeySpec) {
+		t.Fatalf("storage/key_spec drift: %+v %+v", read.Storage, read.KeySpec)
+	}
+}
+
+func TestValidateAlertSpecs_Errors(t *testing.T) {
+	m := alertModelV2{
+		FieldSpec: []alertFieldSpecModel{
+			{Name: typesString("a"), Template: typesString("x"), LookupTableName: typesString("t")},
+			{Name: typesString("b")},
+		},
+		KeySpec: []types.String{typesString("missing")},
+	}
+	if d := validateAlertSpecs(&m); d.ErrorsCount() != 3 {
+		t.Fatalf("expected 3 errors, got %+v", d)
+	}
+}
+
+func Test_buildEventConfigFromModel_FilterAndSystemNotification(t *testing.T) {
+	m := alertModelV2{Filter: &alertFilterBlockModel{Query: typesString("action:login")}}
+	m.Filter.Streams = []types.String{typesString("all_events")}
+	m.Filter.StreamIDs, _ = types.ListValueFrom(context.Background(), types.StringType, []string{"000000000000000000000002"})
+	cfg, diags := buildEventConfigFromModel(context.Background(), &m)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+	if cfg["type"] != "aggregation-v1" || len(cfg["series"].([]interface{})) != 0 {
+		t.Fatalf("expected filter-only aggregation, got %+v", cfg)
+	}
+	if st := cfg["streams"].([]string); len(st) != 1 || st[0] != "000000000000000000000002" {
+		t.Fatalf("expected resolved stream IDs, got %+v", cfg["streams"])
+	}
+	if cfg["search_within_ms"] != int64(defaultFilterIntervalMs) || cfg["execute_every_ms"] != int64(defaultFilterIntervalMs) {
+		t.Fatalf("expected default window and interval, got %+v", cfg)
+	}
+
+	// Read back: the filter block is refreshed, aggregations with series are ignored
+	raw, _ := json.Marshal(cfg)
+	var back map[string]interface{}
+	_ = json.Unmarshal(raw, &back)
+	read := alertModelV2{Filter: &alertFilterBlockModel{}}
+	populateFilterFromConfig(&read, back)
+	if read.Filter.Query.ValueString() != "action:login" || len(read.Filter.Streams) != 1 {
+		t.Fatalf("filter not populated: %+v", read.Filter)
+	}
+	if !read.Filter.SearchWithinMs.IsNull() || !read.Filter.ExecuteEveryMs.IsNull() {
+		t.Fatalf("defaults should read back as null: %+v", read.Filter)
+	}
+	back["execute_every_ms"] = float64(60000)
+	populateFilterFromConfig(&read, back)
+	if read.Filter.ExecuteEveryMs.ValueInt64() != 60000 || !read.Filter.SearchWithinMs.IsNull() {
+		t.Fatalf("changed interval not read back: %+v", read.Filter)
+	}
+
+	ms := alertModelV2{SystemNotification: &alertSystemNotificationModel{}}
+	if cfg, _ := buildEventConfigFromModel(context.Background(), &ms); cfg["type"] != "system-notifications-v1" {
+		t.Fatalf("unexpected system notification config: %+v", cfg)
+	}
+
+	both := alertModelV2{SystemNotification: &alertSystemNotificationModel{}, Filter: &alertFilterBlockModel{}}
+	if _, diags := buildEventConfigFromModel(context.Background(), &both); !diags.HasError() {
+		t.Fatalf("expected error for two typed blocks")
+	}
+}
+
+func TestUpgradeAlertStateV6JSON(t *testing.T) {
+	v6 := `{"id":"e1","title":"t","threshold":{"query":"*","filter":{"streams":["5f0000000000000000000001"]}},"aggregation":null}`
+	out, err := upgradeAlertStateV6JSON([]byte(v6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var st map[string]interface{}
+	_ = json.Unmarshal(out, &st)
+	filter := st["threshold"].(map[string]interface{})["filter"].(map[string]interface{})
+	if ids, ok := filter["stream_ids"].([]interface{}); !ok || len(ids) != 1 || ids[0] != "5f0000000000000000000001" {
+		t.Fatalf("stream_ids not seeded from streams: %+v", filter)
+	}
+	if fs, ok := st["field_spec"].([]interface{}); !ok || len(fs) != 0 {
+		t.Fatalf("expected empty field_spec, got %+v", st["field_spec"])
+	}
+}
+
+func TestAlertResource_UpgradeStateV6(t *testing.T) {
+	ctx := context.Background()
+	r := &alertResource{}
+	var sr resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &sr)
+	v6 := `{"id":"e1","title":"t","config":"{}","threshold":{"query":"*","filter":{"streams":["5f0000000000000000000001"]}}}`
+	req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(v6)}}
+	resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: sr.Schema}}
+	r.UpgradeState(ctx)[6].StateUpgrader(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", resp.Diagnostics)
+	}
+	if _, err := resp.DynamicValue.Unmarshal(sr.Schema.Type().TerraformType(ctx)); err != nil {
+		t.Fatalf("upgraded state does not match the v7 schema: %v", err)
+	}
+}
+
+func TestAlertCondition_RenderParseRoundTrip(t *testing.T) {
+	v := func(f float64) *float64 { return &f }
+	cond := alertCondition{Operator: "and", Children: []alertCondition{
+		{Operator: "greater", SeriesRef: "count", Value: v(100)},
+		{Operator: "lower", SeriesRef: "avg", Value: v(5)},
+		{Operator: "or", Children: []alertCondition{
+			{Operator: "equal", SeriesRef: "count", Value: v(0)},
+			{Operator: "not", Children: []alertCondition{{Operator: "greater_equal", SeriesRef: "avg", Value: v(1)}}},
+		}},
+	}}
+	if d := validateAlertCondition(path.Root("aggregation").AtName("condition"), cond, map[string]bool{"count": true, "avg": true}); d.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", d)
+	}
+	expr := renderAlertCondition(cond)
+	if expr["expr"] != "&&" {
+		t.Fatalf("expected && at the root, got %+v", expr)
+	}
+	if right := expr["right"].(map[string]interface{}); right["expr"] != "group" {
+		t.Fatalf("nested or should be grouped, got %+v", right)
+	}
+
+	// Through JSON, as Graylog returns it
+	raw, _ := json.Marshal(expr)
+	var back map[string]interface{}
+	_ = json.Unmarshal(raw, &back)
+	parsed, err := parseAlertCondition(back)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, cond) {
+		t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", parsed, cond)
+	}
+
+	o, diags := parsed.toObject(0)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+	fromState, ok := alertConditionFromObject(o)
+	if !ok || !reflect.DeepEqual(fromState, cond) {
+		t.Fatalf("object round trip mismatch: %+v", fromState)
+	}
+}
+
+func TestValidateAlertCondition_Errors(t *testing.T) {
+	v := 1.0
+	cond := alertCondition{Operator: "and", Children: []alertCondition{
+		{Operator: "greater", SeriesRef: "missing", Value: &v},
+	}}
+	d := validateAlertCondition(path.Root("aggregation").AtName("condition"), cond, map[string]bool{"count": true})
+	if d.ErrorsCount() != 2 {
+		t.Fatalf("expected arity and series_ref errors, got %+v", d)
+	}
+}
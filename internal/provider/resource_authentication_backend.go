@@ -0,0 +1,484 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Authentication service backends (Graylog 4+), the replacement of the legacy LDAP settings.
+type authBackendResource struct{ client *client.Client }
+
+type authBackendModel struct {
+	ID              types.String          `tfsdk:"id"`
+	Title           types.String          `tfsdk:"title"`
+	Description     types.String          `tfsdk:"description"`
+	DefaultRoles    []types.String        `tfsdk:"default_roles"`
+	Active          types.Bool            `tfsdk:"active"`
+	LDAP            *authBackendLDAPModel `tfsdk:"ldap"`
+	ActiveDirectory *authBackendLDAPModel `tfsdk:"active_directory"`
+	Timeouts        timeouts.Value        `tfsdk:"timeouts"`
+}
+
+// authBackendLDAPModel is shared by the ldap and active_directory blocks; only the defaults differ.
+type authBackendLDAPModel struct {
+	Servers               []types.String `tfsdk:"servers"`
+	TransportSecurity     types.String   `tfsdk:"transport_security"`
+	VerifyCertificates    types.Bool     `tfsdk:"verify_certificates"`
+	SystemUserDN          types.String   `tfsdk:"system_user_dn"`
+	SystemUserPassword    types.String   `tfsdk:"system_user_password"`
+	UserSearchBase        types.String   `tfsdk:"user_search_base"`
+	UserSearchPattern     types.String   `tfsdk:"user_search_pattern"`
+	UserNameAttribute     types.String   `tfsdk:"user_name_attribute"`
+	UserFullNameAttribute types.String   `tfsdk:"user_full_name_attribute"`
+	UserUniqueIDAttribute types.String   `tfsdk:"user_unique_id_attribute"`
+	EmailAttributes       []types.String `tfsdk:"email_attributes"`
+}
+
+// authBackendDefaults are the Graylog UI defaults per backend type.
+type authBackendDefaults struct {
+	configType, searchPattern, nameAttr, fullNameAttr, uniqueIDAttr string
+}
+
+var (
+	authBackendLDAPDefaults = authBackendDefaults{
+		configType:    "ldap",
+		searchPattern: "(&(|(objectClass=inetOrgPerson))(|(uid={0})(mail={0})))",
+		nameAttr:      "uid",
+		fullNameAttr:  "cn",
+		uniqueIDAttr:  "entryUUID",
+	}
+	authBackendADDefaults = authBackendDefaults{
+		configType:    "active-directory",
+		searchPattern: "(&(objectClass=user)(|(sAMAccountName={0})(userPrincipalName={0})))",
+		nameAttr:      "userPrincipalName",
+		fullNameAttr:  "displayName",
+		uniqueIDAttr:  "objectGUID",
+	}
+)
+
+func NewAuthBackendResource() resource.Resource { return &authBackendResource{} }
+
+func (r *authBackendResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "graylog_authentication_backend"
+}
+
+func authBackendLDAPBlock(desc string, d authBackendDefaults) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: desc,
+		Attributes: map[string]schema.Attribute{
+			"servers": schema.ListAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "Directory servers as host:port, tried in order",
+				Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+			},
+			"transport_security": schema.StringAttribute{
+				Optional:    true,
+				Description: "none | tls | start_tls (default tls)",
+				Validators:  []validator.String{stringvalidator.OneOf("none", "tls", "start_tls")},
+			},
+			"verify_certificates":      schema.BoolAttribute{Optional: true, Description: "Verify server certificates (default true)"},
+			"system_user_dn":           schema.StringAttribute{Optional: true, Description: "Bind DN of the system user; anonymous bind when unset"},
+			"system_user_password":     schema.StringAttribute{Optional: true, Sensitive: true, Description: "Password of the system user"},
+			"user_search_base":         schema.StringAttribute{Required: true, Description: "Base DN for user searches"},
+			"user_search_pattern":      schema.StringAttribute{Optional: true, Description: "User search filter, {0} is the login name (default " + d.searchPattern + ")"},
+			"user_name_attribute":      schema.StringAttribute{Optional: true, Description: "Attribute used as the Graylog user name (default " + d.nameAttr + ")"},
+			"user_full_name_attribute": schema.StringAttribute{Optional: true, Description: "Attribute used as the full name (default " + d.fullNameAttr + ")"},
+			"user_unique_id_attribute": schema.StringAttribute{Optional: true, Description: "Attribute that uniquely identifies a user (default " + d.uniqueIDAttr + ")"},
+			"email_attributes":         schema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Attributes holding the email address, first match wins (Graylog 5+)"},
+		},
+	}
+}
+
+func (r *authBackendResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Graylog authentication service backend (LDAP or Active Directory, Graylog 4+).",
+		Attributes: map[string]schema.Attribute{
+			"id":            schema.StringAttribute{Computed: true, Description: "Backend ID"},
+			"title":         schema.StringAttribute{Required: true, Description: "Title"},
+			"description":   schema.StringAttribute{Optional: true, Description: "Description"},
+			"default_roles": schema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Roles (names or IDs) assigned to users created through this backend"},
+			"active":        schema.BoolAttribute{Optional: true, Description: "Make this the active authentication backend; false deactivates it, unset leaves activation alone"},
+			"timeouts":      timeouts.Attributes(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+		},
+		Blocks: map[string]schema.Block{
+			"ldap":             authBackendLDAPBlock("LDAP backend (config type ldap)", authBackendLDAPDefaults),
+			"active_directory": authBackendLDAPBlock("Active Directory backend (config type active-directory)", authBackendADDefaults),
+		},
+	}
+}
+
+func (r *authBackendResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{resourcevalidator.ExactlyOneOf(path.MatchRoot("ldap"), path.MatchRoot("active_directory"))}
+}
+
+func (r *authBackendResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	for _, name := range []string{"ldap", "active_directory"} {
+		p := path.Root(name).AtName("servers")
+		var servers types.List
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p, &servers)...)
+		if servers.IsNull() || servers.IsUnknown() {
+			continue
+		}
+		for i, v := range servers.Elements() {
+			s, _ := v.(types.String)
+			if s.IsNull() || s.IsUnknown() {
+				continue
+			}
+			if _, _, err := splitServer(s.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(p.AtListIndex(i), "Invalid server", "Servers must be host:port with a port between 1 and 65535: "+err.Error())
+			}
+		}
+	}
+}
+
+func (r *authBackendResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*client.Client)
+}
+
+// block returns the configured typed block with its defaults and schema path.
+func (m *authBackendModel) block() (*authBackendLDAPModel, authBackendDefaults, path.Path) {
+	if m.ActiveDirectory != nil {
+		return m.ActiveDirectory, authBackendADDefaults, path.Root("active_directory")
+	}
+	return m.LDAP, authBackendLDAPDefaults, path.Root("ldap")
+}
+
+func splitServer(s string) (string, int64, error) {
+	host, port, err := net.SplitHostPort(s)
+	if err != nil {
+		return "", 0, err
+	}
+	n, err := strconv.ParseInt(port, 10, 64)
+	if err != nil || n < 1 || n > 65535 {
+		return "", 0, errors.New("invalid port " + strconv.Quote(port))
+	}
+	return host, n, nil
+}
+
+func (m *authBackendLDAPModel) toConfig(d authBackendDefaults) map[string]interface{} {
+	servers := make([]map[string]interface{}, 0, len(m.Servers))
+	for _, s := range m.Servers {
+		host, port, _ := splitServer(s.ValueString())
+		servers = append(servers, map[string]interface{}{"host": host, "port": port})
+	}
+	cfg := map[string]interface{}{
+		"type":                     d.configType,
+		"servers":                  servers,
+		"transport_security":       stringOrDefault(m.TransportSecurity, "tls"),
+		"verify_certificates":      boolOrDefault(m.VerifyCertificates, true),
+		"system_user_dn":           stringOrDefault(m.SystemUserDN, ""),
+		"user_search_base":         m.UserSearchBase.ValueString(),
+		"user_search_pattern":      stringOrDefault(m.UserSearchPattern, d.searchPattern),
+		"user_name_attribute":      stringOrDefault(m.UserNameAttribute, d.nameAttr),
+		"user_full_name_attribute": stringOrDefault(m.UserFullNameAttribute, d.fullNameAttr),
+		"user_unique_id_attribute": stringOrDefault(m.UserUniqueIDAttribute, d.uniqueIDAttr),
+	}
+	putEncryptedIfSet(cfg, "system_user_password", m.SystemUserPassword)
+	if m.EmailAttributes != nil {
+		cfg["email_attributes"] = stringValues(m.EmailAttributes)
+	}
+	return cfg
+}
+
+// fromConfig refreshes the block; the receiver holds the prior state. The password is encrypted
+// on the server and keeps the configured value.
+func (m *authBackendLDAPModel) fromConfig(d authBackendDefaults, cfg map[string]interface{}) {
+	if raw, ok := cfg["servers"].([]interface{}); ok {
+		servers := make([]types.String, 0, len(raw))
+		for _, v := range raw {
+			srv, _ := v.(map[string]interface{})
+			port := cfgInt64(srv, "port")
+			servers = append(servers, types.StringValue(net.JoinHostPort(toString(srv["host"]), strconv.FormatInt(port.ValueInt64(), 10))))
+		}
+		m.Servers = servers
+	}
+	m.TransportSecurity = readCfgString(m.TransportSecurity, cfg, "transport_security", "tls")
+	m.VerifyCertificates = readCfgBool(m.VerifyCertificates, cfg, "verify_certificates", true)
+	m.SystemUserDN = readCfgString(m.SystemUserDN, cfg, "system_user_dn", "")
+	m.UserSearchBase = readCfgString(m.UserSearchBase, cfg, "user_search_base", "")
+	m.UserSearchPattern = readCfgString(m.UserSearchPattern, cfg, "user_search_pattern", d.searchPattern)
+	m.UserNameAttribute = readCfgString(m.UserNameAttribute, cfg, "user_name_attribute", d.nameAttr)
+	m.UserFullNameAttribute = readCfgString(m.UserFullNameAttribute, cfg, "user_full_name_attribute", d.fullNameAttr)
+	m.UserUniqueIDAttribute = readCfgString(m.UserUniqueIDAttribute, cfg, "user_unique_id_attribute", d.uniqueIDAttr)
+	m.EmailAttributes = readCfgStrings(m.EmailAttributes, cfg, "email_attributes")
+}
+
+// resolveRoleIDs maps default_roles entries (names or IDs) to role IDs.
+func resolveRoleIDs(entries []types.String, roles map[string]string) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	ids := map[string]bool{}
+	for _, id := range roles {
+		ids[id] = true
+	}
+	out := make([]string, 0, len(entries))
+	for i, e := range entries {
+		v := e.ValueString()
+		switch {
+		case roles[v] != "":
+			out = append(out, roles[v])
+		case ids[v]:
+			out = append(out, v)
+		default:
+			diags.AddAttributeError(path.Root("default_roles").AtListIndex(i), "Unknown role", "Role '"+v+"' does not exist.")
+		}
+	}
+	return out, diags
+}
+
+// readDefaultRoles keeps the configured names/IDs while they resolve to the server's role IDs and
+// otherwise reports role names (or IDs of roles that no longer exist).
+func readDefaultRoles(prev []types.String, server []string, roles map[string]string) []types.String {
+	if len(server) == 0 {
+		if prev == nil {
+			return nil
+		}
+		return []types.String{}
+	}
+	if resolved, diags := resolveRoleIDs(prev, roles); !diags.HasError() && len(resolved) == len(server) {
+		same := true
+		for i := range resolved {
+			if resolved[i] != server[i] {
+				same = false
+			}
+		}
+		if same {
+			return prev
+		}
+	}
+	names := make(map[string]string, len(roles))
+	for name, id := range roles {
+		names[id] = name
+	}
+	out := make([]types.String, 0, len(server))
+	for _, id := range server {
+		if n, ok := names[id]; ok {
+			out = append(out, types.StringValue(n))
+		} else {
+			out = append(out, types.StringValue(id))
+		}
+	}
+	return out
+}
+
+// toBackend builds the API object from the plan.
+func (r *authBackendResource) toBackend(c *client.Client, data *authBackendModel) (*client.AuthBackend, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	roleIDs := []string{}
+	if len(data.DefaultRoles) > 0 {
+		roles, err := c.ListRoleIDs()
+		if err != nil {
+			diags.AddError("Unable to list roles", err.Error())
+			return nil, diags
+		}
+		roleIDs, diags = resolveRoleIDs(data.DefaultRoles, roles)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+	m, d, _ := data.block()
+	return &client.AuthBackend{
+		Title:        data.Title.ValueString(),
+		Description:  data.Description.ValueString(),
+		DefaultRoles: roleIDs,
+		Config:       m.toConfig(d),
+	}, diags
+}
+
+// applyActive activates or deactivates the backend according to `active` (null: leave alone).
+func applyActive(c *client.Client, id string, active types.Bool) error {
+	if active.IsNull() || active.IsUnknown() {
+		return nil
+	}
+	current, err := c.GetActiveAuthBackendID()
+	if err != nil {
+		return err
+	}
+	switch {
+	case active.ValueBool() && current != id:
+		return c.SetActiveAuthBackend(id)
+	case !active.ValueBool() && current == id:
+		return c.SetActiveAuthBackend("")
+	}
+	return nil
+}
+
+func (r *authBackendResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data authBackendModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	createTimeout, diags := data.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	c := r.client.WithContext(ctx)
+
+	b, diags := r.toBackend(c, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	created, err := c.CreateAuthBackend(b)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating authentication backend", err.Error())
+		return
+	}
+	data.ID = types.StringValue(created.ID)
+	// Save state before activation so a failure taints the backend instead of orphaning it
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if err := applyActive(c, created.ID, data.Active); err != nil {
+		resp.Diagnostics.AddError("Error activating authentication backend", err.Error())
+	}
+}
+
+func (r *authBackendResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data authBackendModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	c := r.client.WithContext(ctx)
+	b, err := c.GetAuthBackend(data.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading authentication backend", err.Error())
+		return
+	}
+	data.Title = types.StringValue(b.Title)
+	if !data.Description.IsNull() || b.Description != "" {
+		data.Description = types.StringValue(b.Description)
+	}
+	if len(b.DefaultRoles) > 0 {
+		roles, err := c.ListRoleIDs()
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to list roles", err.Error())
+			return
+		}
+		data.DefaultRoles = readDefaultRoles(data.DefaultRoles, b.DefaultRoles, roles)
+	} else {
+		data.DefaultRoles = readDefaultRoles(data.DefaultRoles, nil, nil)
+	}
+
+	// The config type decides the block (also on import, where no block is set yet)
+	switch t, _ := b.Config["type"].(string); t {
+	case authBackendADDefaults.configType:
+		if data.ActiveDirectory == nil {
+			data.ActiveDirectory, data.LDAP = data.LDAP, nil
+		}
+		if data.ActiveDirectory == nil {
+			data.ActiveDirectory = &authBackendLDAPModel{}
+		}
+		data.ActiveDirectory.fromConfig(authBackendADDefaults, b.Config)
+	case authBackendLDAPDefaults.configType:
+		if data.LDAP == nil {
+			data.LDAP, data.ActiveDirectory = data.ActiveDirectory, nil
+		}
+		if data.LDAP == nil {
+			data.LDAP = &authBackendLDAPModel{}
+		}
+		data.LDAP.fromConfig(authBackendLDAPDefaults, b.Config)
+	default:
+		resp.Diagnostics.AddError("Unsupported authentication backend", "Backend type '"+t+"' is not supported by graylog_authentication_backend (ldap and active-directory are).")
+		return
+	}
+
+	if !data.Active.IsNull() {
+		active, err := c.GetActiveAuthBackendID()
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading active authentication backend", err.Error())
+			return
+		}
+		data.Active = types.BoolValue(active == data.ID.ValueString())
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *authBackendResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state authBackendModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = state.ID
+	updateTimeout, diags := data.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	c := r.client.WithContext(ctx)
+
+	b, diags := r.toBackend(c, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if _, err := c.UpdateAuthBackend(data.ID.ValueString(), b); err != nil {
+		resp.Diagnostics.AddError("Error updating authentication backend", err.Error())
+		return
+	}
+	if err := applyActive(c, data.ID.ValueString(), data.Active); err != nil {
+		resp.Diagnostics.AddError("Error activating authentication backend", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *authBackendResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data authBackendModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, 3*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	c := r.client.WithContext(ctx)
+
+	// Graylog refuses to delete the active backend
+	if active, err := c.GetActiveAuthBackendID(); err == nil && active == data.ID.ValueString() {
+		if err := c.SetActiveAuthBackend(""); err != nil {
+			resp.Diagnostics.AddError("Error deactivating authentication backend", err.Error())
+			return
+		}
+	}
+	if err := c.DeleteAuthBackend(data.ID.ValueString()); err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError("Error deleting authentication backend", err.Error())
+	}
+}
+
+func (r *authBackendResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
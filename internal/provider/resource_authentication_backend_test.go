@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAuthBackendLDAPModel_ToConfig(t *testing.T) {
+	m := &authBackendLDAPModel{
+		Servers:            []types.String{types.StringValue("dc1.example.com:636"), types.StringValue("[::1]:389")},
+		SystemUserDN:       types.StringValue("cn=graylog,dc=example,dc=com"),
+		SystemUserPassword: types.StringValue("s3cret"),
+		UserSearchBase:     types.StringValue("dc=example,dc=com"),
+	}
+	cfg := m.toConfig(authBackendADDefaults)
+	if cfg["type"] != "active-directory" || cfg["transport_security"] != "tls" || cfg["verify_certificates"] != true {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	if cfg["user_name_attribute"] != "userPrincipalName" || cfg["user_unique_id_attribute"] != "objectGUID" {
+		t.Fatalf("expected AD defaults, got %+v", cfg)
+	}
+	servers := cfg["servers"].([]map[string]interface{})
+	if servers[0]["host"] != "dc1.example.com" || servers[0]["port"] != int64(636) || servers[1]["host"] != "::1" {
+		t.Fatalf("unexpected servers: %+v", servers)
+	}
+	if pw, ok := cfg["system_user_password"].(map[string]interface{}); !ok || pw["set_value"] != "s3cret" {
+		t.Fatalf("expected encrypted password, got %+v", cfg["system_user_password"])
+	}
+	if _, ok := cfg["email_attributes"]; ok {
+		t.Fatalf("unset email_attributes must not be sent: %+v", cfg)
+	}
+}
+
+func TestAuthBackendLDAPModel_FromConfigKeepsNulls(t *testing.T) {
+	m := &authBackendLDAPModel{
+		Servers:            []types.String{types.StringValue("ldap.example.com:636")},
+		TransportSecurity:  types.StringNull(),
+		VerifyCertificates: types.BoolNull(),
+		SystemUserPassword: types.StringValue("s3cret"),
+		UserSearchBase:     types.StringValue("ou=users,dc=example,dc=com"),
+		UserSearchPattern:  types.StringNull(),
+		UserNameAttribute:  types.StringNull(),
+	}
+	m.fromConfig(authBackendLDAPDefaults, map[string]interface{}{
+		"type":                 "ldap",
+		"servers":              []interface{}{map[string]interface{}{"host": "ldap2.example.com", "port": float64(636)}},
+		"transport_security":   "tls",
+		"verify_certificates":  true,
+		"system_user_password": map[string]interface{}{"is_set": true},
+		"user_search_base":     "ou=users,dc=example,dc=com",
+		"user_search_pattern":  authBackendLDAPDefaults.searchPattern,
+		"user_name_attribute":  "mail",
+	})
+	if len(m.Servers) != 1 || m.Servers[0].ValueString() != "ldap2.example.com:636" {
+		t.Fatalf("unexpected servers: %v", m.Servers)
+	}
+	if !m.TransportSecurity.IsNull() || !m.VerifyCertificates.IsNull() || !m.UserSearchPattern.IsNull() {
+		t.Fatalf("defaults must stay null: %+v", m)
+	}
+	if m.UserNameAttribute.ValueString() != "mail" || m.SystemUserPassword.ValueString() != "s3cret" {
+		t.Fatalf("unexpected values: %+v", m)
+	}
+}
+
+func TestAuthBackendDefaultRoles(t *testing.T) {
+	roles := map[string]string{"Reader": "r1", "Admin": "r2"}
+	ids, diags := resolveRoleIDs([]types.String{types.StringValue("Reader"), types.StringValue("r2")}, roles)
+	if diags.HasError() || len(ids) != 2 || ids[0] != "r1" || ids[1] != "r2" {
+		t.Fatalf("unexpected ids: %v %v", ids, diags)
+	}
+	if _, diags := resolveRoleIDs([]types.String{types.StringValue("Nope")}, roles); !diags.HasError() {
+		t.Fatal("expected unknown role error")
+	}
+	prev := []types.String{types.StringValue("Reader")}
+	if got := readDefaultRoles(prev, []string{"r1"}, roles); got[0].ValueString() != "Reader" {
+		t.Fatalf("expected configured name kept, got %v", got)
+	}
+	if got := readDefaultRoles(prev, []string{"r2", "r9"}, roles); len(got) != 2 || got[0].ValueString() != "Admin" || got[1].ValueString() != "r9" {
+		t.Fatalf("expected drift reported as names, got %v", got)
+	}
+	if got := readDefaultRoles(nil, nil, roles); got != nil {
+		t.Fatalf("expected null default_roles, got %v", got)
+	}
+}
+
+func TestSplitServer(t *testing.T) {
+	if h, p, err := splitServer("ldap.example.com:389"); err != nil || h != "ldap.example.com" || p != 389 {
+		t.Fatalf("unexpected result: %s %d %v", h, p, err)
+	}
+	for _, bad := range []string{"ldap.example.com", "ldap:0", "ldap:x"} {
+		if _, _, err := splitServer(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -23,6 +25,13 @@ type eventNotificationModel struct {
 	Description types.String   `tfsdk:"description"`
 	Config      types.String   `tfsdk:"config"`
 	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+
+	Email     *notificationEmailModel     `tfsdk:"email"`
+	HTTP      *notificationHTTPModel      `tfsdk:"http"`
+	Slack     *notificationSlackModel     `tfsdk:"slack"`
+	Teams     *notificationTeamsModel     `tfsdk:"teams"`
+	PagerDuty *notificationPagerDutyModel `tfsdk:"pagerduty"`
+	Script    *notificationScriptModel    `tfsdk:"script"`
 }
 
 func NewEventNotificationResource() resource.Resource { return &eventNotificationResource{} }
@@ -34,16 +43,130 @@ func (r *eventNotificationResource) Metadata(_ context.Context, _ resource.Metad
 func (r *eventNotificationResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Version:     1,
-		Description: "Manages Graylog Event Notification (email/http/slack/pagerduty).",
+		Description: "Manages Graylog Event Notification (email/http/slack/teams/pagerduty/script).",
 		Attributes: map[string]schema.Attribute{
 			"id":          schema.StringAttribute{Computed: true, Description: "Notification ID"},
 			"title":       schema.StringAttribute{Required: true, Description: "Title"},
-			"type":        schema.StringAttribute{Required: true, Description: "Type (email/http/slack/pagerduty)"},
+			"type":        schema.StringAttribute{Optional: true, Computed: true, Description: "Type (email/http/slack/teams/pagerduty/script); derived from the typed block or config.type when omitted"},
 			"description": schema.StringAttribute{Optional: true, Description: "Description"},
-			"config":      schema.StringAttribute{Required: true, Description: "JSON-encoded config object for the notification type."},
+			"config":      schema.StringAttribute{Optional: true, Description: "JSON-encoded config object for the notification type. Conflicts with the typed blocks."},
 			"timeouts":    timeouts.Attributes(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
 		},
+		Blocks: typedNotificationBlocks(),
+	}
+}
+
+func (r *eventNotificationResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	exprs := []path.Expression{path.MatchRoot("config")}
+	for _, name := range typedNotificationBlockNames {
+		exprs = append(exprs, path.MatchRoot(name))
+	}
+	return []resource.ConfigValidator{resourcevalidator.ExactlyOneOf(exprs...)}
+}
+
+// ModifyPlan derives `type` from the typed block (or config.type) so it is known at plan time.
+func (r *eventNotificationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var data eventNotificationModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateEventNotification(&data)...)
+	if resp.Diagnostics.HasError() || !data.Type.IsUnknown() {
+		return
+	}
+	if t := eventNotificationType(&data); t != "" {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("type"), t)...)
+	}
+}
+
+// eventNotificationType returns the short type implied by the typed block or by config.type.
+func eventNotificationType(data *eventNotificationModel) string {
+	if b := data.typedBlock(); b != nil {
+		return b.shortType()
+	}
+	if data.Config.IsNull() || data.Config.IsUnknown() {
+		return ""
+	}
+	var cfg map[string]interface{}
+	if json.Unmarshal([]byte(data.Config.ValueString()), &cfg) != nil {
+		return ""
 	}
+	t, _ := cfg["type"].(string)
+	return client.NotificationShortType(t)
+}
+
+// validateEventNotification checks that `type` is set or derivable and matches the typed block.
+func validateEventNotification(data *eventNotificationModel) (diags diag.Diagnostics) {
+	if data.Type.IsUnknown() || (data.Config.IsUnknown() && data.typedBlock() == nil) {
+		return
+	}
+	if b := data.typedBlock(); b != nil {
+		if !data.Type.IsNull() && client.NotificationShortType(data.Type.ValueString()) != b.shortType() {
+			diags.AddAttributeError(path.Root("type"), "Type does not match notification block", "The '"+b.shortType()+"' block implies type '"+b.shortType()+"'; remove 'type' or set it to that value.")
+		}
+		return
+	}
+	if data.Type.IsNull() && eventNotificationType(data) == "" {
+		diags.AddAttributeError(path.Root("type"), "Missing type", "Set 'type', a 'type' key in 'config', or use a typed notification block.")
+	}
+	return
+}
+
+// notificationConfig builds the config to send: the typed block's config, or the parsed JSON config
+// (in which case data.Config is canonicalized).
+func notificationConfig(data *eventNotificationModel) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if b := data.typedBlock(); b != nil {
+		if data.Type.IsNull() || data.Type.IsUnknown() {
+			data.Type = types.StringValue(b.shortType())
+		}
+		return b.toConfig(), diags
+	}
+	var cfg map[string]interface{}
+	if err := json.Unmarshal([]byte(data.Config.ValueString()), &cfg); err != nil {
+		diags.AddAttributeError(path.Root("config"), "Invalid JSON", err.Error())
+		return nil, diags
+	}
+	// Canonicalize JSON to eliminate spurious diffs
+	if canon, err := CanonicalizeJSONValue(cfg); err == nil {
+		data.Config = types.StringValue(canon)
+	}
+	if data.Type.IsNull() || data.Type.IsUnknown() {
+		data.Type = types.StringValue(eventNotificationType(data))
+	}
+	return cfg, diags
+}
+
+// projectNotificationConfig narrows the server config to the keys of the prior JSON config, so
+// server-side defaults and the added config.type do not show up as a diff. Encrypted values
+// ({"is_set": true}) keep the configured secret. Without a prior config (import) the full config is used.
+func projectNotificationConfig(prior types.String, server map[string]interface{}) map[string]interface{} {
+	var prev map[string]interface{}
+	if prior.IsNull() || prior.IsUnknown() || json.Unmarshal([]byte(prior.ValueString()), &prev) != nil || prev == nil {
+		return server
+	}
+	out := make(map[string]interface{}, len(prev))
+	for k, pv := range prev {
+		sv, ok := server[k]
+		if !ok {
+			out[k] = pv
+			continue
+		}
+		if enc, isMap := sv.(map[string]interface{}); isMap {
+			if _, encrypted := enc["is_set"]; encrypted {
+				if _, prevIsMap := pv.(map[string]interface{}); !prevIsMap {
+					out[k] = pv
+					continue
+				}
+			}
+		}
+		out[k] = sv
+	}
+	return out
 }
 
 func (r *eventNotificationResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
@@ -65,16 +188,11 @@ func (r *eventNotificationResource) Create(ctx context.Context, req resource.Cre
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	// Parse config
-	var cfg map[string]interface{}
-	if err := json.Unmarshal([]byte(data.Config.ValueString()), &cfg); err != nil {
-		resp.Diagnostics.AddAttributeError(path.Root("config"), "Invalid JSON", err.Error())
+	cfg, diags := notificationConfig(&data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	// Canonicalize JSON to eliminate spurious diffs
-	if canon, err := CanonicalizeJSONValue(cfg); err == nil {
-		data.Config = types.StringValue(canon)
-	}
 	createTimeout, diags := data.Timeouts.Create(ctx, 3*time.Minute)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -113,37 +231,45 @@ func (r *eventNotificationResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 	data.Title = types.StringValue(got.Title)
-	data.Type = types.StringValue(got.Type)
+	// Keep the configured spelling (e.g. a full config.type discriminator) while it names the same type
+	if got.Type != "" && client.NotificationShortType(data.Type.ValueString()) != got.Type {
+		data.Type = types.StringValue(got.Type)
+	}
 	data.Description = types.StringValue(got.Description)
-	if canon, err := CanonicalizeJSONValue(got.Config); err == nil {
-		data.Config = types.StringValue(canon)
-	} else if b, err2 := json.Marshal(got.Config); err2 == nil { // fallback
-		data.Config = types.StringValue(string(b))
+	if block := data.typedBlock(); block != nil && (got.Type == "" || block.shortType() == got.Type) {
+		// Typed block: refresh it from the server config and leave `config` unset
+		block.fromConfig(got.Config)
+		data.Config = types.StringNull()
+	} else {
+		cfg := projectNotificationConfig(data.Config, got.Config)
+		if canon, err := CanonicalizeJSONValue(cfg); err == nil {
+			data.Config = types.StringValue(canon)
+		} else if b, err2 := json.Marshal(cfg); err2 == nil { // fallback
+			data.Config = types.StringValue(string(b))
+		}
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *eventNotificationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data eventNotificationModel
+	var data, state eventNotificationModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.ID = state.ID
 	// Capability gating: Event Notifications must be supported
 	caps := r.client.GetCapabilities()
 	resp.Diagnostics.Append(ensureFeature(ctx, r.client, caps.EventNotifications, "event_notifications", "try Graylog 6/7 or appropriate image")...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	var cfg map[string]interface{}
-	if err := json.Unmarshal([]byte(data.Config.ValueString()), &cfg); err != nil {
-		resp.Diagnostics.AddAttributeError(path.Root("config"), "Invalid JSON", err.Error())
+	cfg, diags := notificationConfig(&data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	// Canonicalize JSON
-	if canon, err := CanonicalizeJSONValue(cfg); err == nil {
-		data.Config = types.StringValue(canon)
-	}
 	updateTimeout, diags := data.Timeouts.Update(ctx, 3*time.Minute)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEventNotificationResource_TypedBlockModelMatchesSchema(t *testing.T) {
+	ctx := context.Background()
+	var sresp resource.SchemaResponse
+	NewEventNotificationResource().Schema(ctx, resource.SchemaRequest{}, &sresp)
+	if sresp.Diagnostics.HasError() {
+		t.Fatalf("schema diagnostics: %v", sresp.Diagnostics)
+	}
+	objType := sresp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	slackType := objType.AttributeTypes["slack"].(tftypes.Object)
+
+	state := tfsdk.State{
+		Schema: sresp.Schema,
+		Raw: nullObject(objType, map[string]tftypes.Value{
+			"title": tftypes.NewValue(tftypes.String, "slack"),
+			"slack": nullObject(slackType, map[string]tftypes.Value{
+				"webhook_url": tftypes.NewValue(tftypes.String, "https://hooks.slack.com/services/x"),
+				"channel":     tftypes.NewValue(tftypes.String, "#alerts"),
+			}),
+		}),
+	}
+	var m eventNotificationModel
+	if diags := state.Get(ctx, &m); diags.HasError() {
+		t.Fatalf("model does not match schema: %v", diags)
+	}
+	if b := m.typedBlock(); b == nil || b.shortType() != "slack" {
+		t.Fatalf("unexpected typed block: %#v", b)
+	}
+}
+
+func TestTypedNotificationBlocks_ToConfig(t *testing.T) {
+	h := &notificationHTTPModel{
+		URL:       types.StringValue("https://example.com/hook"),
+		APIKey:    types.StringValue("X-Token"),
+		APISecret: types.StringValue("s3cret"),
+	}
+	cfg := h.toConfig()
+	if cfg["url"] != "https://example.com/hook" || cfg["skip_tls_verification"] != false || cfg["api_key"] != "X-Token" {
+		t.Fatalf("unexpected http config: %+v", cfg)
+	}
+	if sec, ok := cfg["api_secret"].(map[string]interface{}); !ok || sec["set_value"] != "s3cret" {
+		t.Fatalf("expected api_secret as encrypted value, got %+v", cfg["api_secret"])
+	}
+	if _, ok := cfg["basic_auth"]; ok {
+		t.Fatalf("unset basic_auth must not be sent: %+v", cfg)
+	}
+
+	e := &notificationEmailModel{Subject: types.StringValue("Alert"), EmailRecipients: []types.String{types.StringValue("ops@example.com")}}
+	cfg = e.toConfig()
+	if cfg["time_zone"] != "UTC" || len(cfg["user_recipients"].([]string)) != 0 || cfg["email_recipients"].([]string)[0] != "ops@example.com" {
+		t.Fatalf("unexpected email config: %+v", cfg)
+	}
+
+	s := &notificationScriptModel{ScriptPath: types.StringValue("/usr/local/bin/alert.sh")}
+	if cfg = s.toConfig(); cfg["script_timeout"] != int64(notificationScriptTimeoutDefault) {
+		t.Fatalf("unexpected script config: %+v", cfg)
+	}
+
+	// Teams v1 fields are only sent when used
+	tm := &notificationTeamsModel{WebhookURL: types.StringValue("https://teams/x"), AdaptiveCard: types.StringValue("{}")}
+	if cfg = tm.toConfig(); cfg["adaptive_card"] != "{}" || cfg["color"] != nil || cfg["custom_message"] != nil {
+		t.Fatalf("unexpected teams config: %+v", cfg)
+	}
+}
+
+func TestTypedNotificationBlocks_FromConfigKeepsNullsAndSecrets(t *testing.T) {
+	m := &notificationSlackModel{
+		WebhookURL:    types.StringValue("https://hooks.slack.com/services/x"),
+		Channel:       types.StringValue("#alerts"),
+		Color:         types.StringNull(),
+		NotifyChannel: types.BoolNull(),
+		BacklogSize:   types.Int64Null(),
+		TimeZone:      types.StringNull(),
+		IconEmoji:     types.StringNull(),
+	}
+	m.fromConfig(map[string]interface{}{
+		"type":           "slack-notification-v1",
+		"webhook_url":    "https://hooks.slack.com/services/x",
+		"channel":        "#ops",
+		"color":          notificationColorDefault,
+		"notify_channel": false,
+		"backlog_size":   float64(5),
+		"time_zone":      "UTC",
+	})
+	if m.Channel.ValueString() != "#ops" {
+		t.Fatalf("expected drift on channel, got %s", m.Channel)
+	}
+	if !m.Color.IsNull() || !m.NotifyChannel.IsNull() || !m.TimeZone.IsNull() {
+		t.Fatalf("defaults must stay null: %+v", m)
+	}
+	if m.BacklogSize.ValueInt64() != 5 {
+		t.Fatalf("expected backlog_size 5, got %s", m.BacklogSize)
+	}
+	if !m.IconEmoji.IsNull() {
+		t.Fatalf("missing key must keep prior value, got %s", m.IconEmoji)
+	}
+
+	h := &notificationHTTPModel{URL: types.StringValue("https://example.com"), BasicAuth: types.StringValue("u:p")}
+	h.fromConfig(map[string]interface{}{"url": "https://example.com", "basic_auth": map[string]interface{}{"is_set": true}})
+	if h.BasicAuth.ValueString() != "u:p" {
+		t.Fatalf("basic_auth must keep the configured value, got %s", h.BasicAuth)
+	}
+}
+
+func TestProjectNotificationConfig(t *testing.T) {
+	server := map[string]interface{}{
+		"type":                  "http-notification-v1",
+		"url":                   "https://example.com/new",
+		"api_secret":            map[string]interface{}{"is_set": true},
+		"skip_tls_verification": false,
+	}
+	got := projectNotificationConfig(types.StringValue(`{"api_secret":"s3cret","url":"https://example.com"}`), server)
+	if len(got) != 2 || got["url"] != "https://example.com/new" || got["api_secret"] != "s3cret" {
+		t.Fatalf("unexpected projection: %+v", got)
+	}
+	if full := projectNotificationConfig(types.StringNull(), server); len(full) != len(server) {
+		t.Fatalf("import must use the full config, got %+v", full)
+	}
+}
+
+func TestValidateEventNotification(t *testing.T) {
+	m := &eventNotificationModel{Type: types.StringValue("email"), Config: types.StringNull(), Slack: &notificationSlackModel{}}
+	if d := validateEventNotification(m); !d.HasError() {
+		t.Fatalf("expected type/block mismatch error")
+	}
+	m = &eventNotificationModel{Type: types.StringNull(), Config: types.StringValue(`{"url":"https://example.com"}`)}
+	if d := validateEventNotification(m); !d.HasError() {
+		t.Fatalf("expected missing type error")
+	}
+	m.Config = types.StringValue(`{"type":"http-notification-v1","url":"https://example.com"}`)
+	if d := validateEventNotification(m); d.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", d)
+	}
+	if got := eventNotificationType(m); got != "http" {
+		t.Fatalf("expected type derived from config.type, got %q", got)
+	}
+}
@@ -0,0 +1,374 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Typed notification blocks. Attribute names match Graylog config keys; the config.type discriminator
+// is filled in by the client for the server's version (see client.NotificationConfigType).
+// Attributes left unset are sent with Graylog's default and stay null in state while the server reports
+// that default. Secrets are write-only: Graylog masks or encrypts them, so the configured value is kept.
+
+var typedNotificationBlockNames = []string{"email", "http", "slack", "teams", "pagerduty", "script"}
+
+// typedNotificationBlock is implemented by every typed notification block model.
+type typedNotificationBlock interface {
+	shortType() string
+	toConfig() map[string]interface{}
+	// fromConfig refreshes the block from the server config; the receiver holds the prior state.
+	fromConfig(cfg map[string]interface{})
+}
+
+type notificationEmailModel struct {
+	Sender           types.String   `tfsdk:"sender"`
+	Subject          types.String   `tfsdk:"subject"`
+	ReplyTo          types.String   `tfsdk:"reply_to"`
+	BodyTemplate     types.String   `tfsdk:"body_template"`
+	HTMLBodyTemplate types.String   `tfsdk:"html_body_template"`
+	EmailRecipients  []types.String `tfsdk:"email_recipients"`
+	UserRecipients   []types.String `tfsdk:"user_recipients"`
+	TimeZone         types.String   `tfsdk:"time_zone"`
+}
+
+type notificationHTTPModel struct {
+	URL                 types.String `tfsdk:"url"`
+	SkipTLSVerification types.Bool   `tfsdk:"skip_tls_verification"`
+	BasicAuth           types.String `tfsdk:"basic_auth"`
+	APIKeyAsHeader      types.Bool   `tfsdk:"api_key_as_header"`
+	APIKey              types.String `tfsdk:"api_key"`
+	APISecret           types.String `tfsdk:"api_secret"`
+}
+
+type notificationSlackModel struct {
+	WebhookURL    types.String `tfsdk:"webhook_url"`
+	Channel       types.String `tfsdk:"channel"`
+	Color         types.String `tfsdk:"color"`
+	CustomMessage types.String `tfsdk:"custom_message"`
+	UserName      types.String `tfsdk:"user_name"`
+	NotifyChannel types.Bool   `tfsdk:"notify_channel"`
+	LinkNames     types.Bool   `tfsdk:"link_names"`
+	IconURL       types.String `tfsdk:"icon_url"`
+	IconEmoji     types.String `tfsdk:"icon_emoji"`
+	BacklogSize   types.Int64  `tfsdk:"backlog_size"`
+	TimeZone      types.String `tfsdk:"time_zone"`
+}
+
+type notificationTeamsModel struct {
+	WebhookURL    types.String `tfsdk:"webhook_url"`
+	Color         types.String `tfsdk:"color"`
+	CustomMessage types.String `tfsdk:"custom_message"`
+	AdaptiveCard  types.String `tfsdk:"adaptive_card"`
+	IconURL       types.String `tfsdk:"icon_url"`
+	BacklogSize   types.Int64  `tfsdk:"backlog_size"`
+	TimeZone      types.String `tfsdk:"time_zone"`
+}
+
+type notificationPagerDutyModel struct {
+	RoutingKey     types.String `tfsdk:"routing_key"`
+	CustomIncident types.Bool   `tfsdk:"custom_incident"`
+	KeyPrefix      types.String `tfsdk:"key_prefix"`
+	ClientName     types.String `tfsdk:"client_name"`
+	ClientURL      types.String `tfsdk:"client_url"`
+}
+
+type notificationScriptModel struct {
+	ScriptPath      types.String `tfsdk:"script_path"`
+	ScriptArgs      types.String `tfsdk:"script_args"`
+	ScriptTimeout   types.Int64  `tfsdk:"script_timeout"`
+	ScriptSendStdin types.Bool   `tfsdk:"script_send_stdin"`
+}
+
+const (
+	notificationColorDefault         = "#FF0000"
+	notificationScriptTimeoutDefault = 10000
+)
+
+// ---- email ----
+
+func (m *notificationEmailModel) shortType() string { return "email" }
+func (m *notificationEmailModel) toConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"sender":             stringOrDefault(m.Sender, ""),
+		"subject":            m.Subject.ValueString(),
+		"reply_to":           stringOrDefault(m.ReplyTo, ""),
+		"body_template":      stringOrDefault(m.BodyTemplate, ""),
+		"html_body_template": stringOrDefault(m.HTMLBodyTemplate, ""),
+		"email_recipients":   stringValues(m.EmailRecipients),
+		"user_recipients":    stringValues(m.UserRecipients),
+		"time_zone":          stringOrDefault(m.TimeZone, "UTC"),
+	}
+}
+func (m *notificationEmailModel) fromConfig(cfg map[string]interface{}) {
+	m.Sender = readCfgString(m.Sender, cfg, "sender", "")
+	m.Subject = readCfgString(m.Subject, cfg, "subject", "")
+	m.ReplyTo = readCfgString(m.ReplyTo, cfg, "reply_to", "")
+	m.BodyTemplate = readCfgString(m.BodyTemplate, cfg, "body_template", "")
+	m.HTMLBodyTemplate = readCfgString(m.HTMLBodyTemplate, cfg, "html_body_template", "")
+	m.EmailRecipients = readCfgStrings(m.EmailRecipients, cfg, "email_recipients")
+	m.UserRecipients = readCfgStrings(m.UserRecipients, cfg, "user_recipients")
+	m.TimeZone = readCfgString(m.TimeZone, cfg, "time_zone", "UTC")
+}
+
+// ---- http ----
+
+func (m *notificationHTTPModel) shortType() string { return "http" }
+func (m *notificationHTTPModel) toConfig() map[string]interface{} {
+	cfg := map[string]interface{}{
+		"url":                   m.URL.ValueString(),
+		"skip_tls_verification": boolOrDefault(m.SkipTLSVerification, false),
+		"api_key_as_header":     boolOrDefault(m.APIKeyAsHeader, false),
+	}
+	putStringIfSet(cfg, "api_key", m.APIKey)
+	putEncryptedIfSet(cfg, "basic_auth", m.BasicAuth)
+	putEncryptedIfSet(cfg, "api_secret", m.APISecret)
+	return cfg
+}
+func (m *notificationHTTPModel) fromConfig(cfg map[string]interface{}) {
+	m.URL = readCfgString(m.URL, cfg, "url", "")
+	m.SkipTLSVerification = readCfgBool(m.SkipTLSVerification, cfg, "skip_tls_verification", false)
+	m.APIKeyAsHeader = readCfgBool(m.APIKeyAsHeader, cfg, "api_key_as_header", false)
+	m.APIKey = readCfgString(m.APIKey, cfg, "api_key", "")
+	// basic_auth and api_secret are encrypted values ({"is_set": true}), keep the configured ones
+}
+
+// ---- slack ----
+
+func (m *notificationSlackModel) shortType() string { return "slack" }
+func (m *notificationSlackModel) toConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"webhook_url":    m.WebhookURL.ValueString(),
+		"channel":        m.Channel.ValueString(),
+		"color":          stringOrDefault(m.Color, notificationColorDefault),
+		"custom_message": stringOrDefault(m.CustomMessage, ""),
+		"user_name":      stringOrDefault(m.UserName, ""),
+		"notify_channel": boolOrDefault(m.NotifyChannel, false),
+		"link_names":     boolOrDefault(m.LinkNames, false),
+		"icon_url":       stringOrDefault(m.IconURL, ""),
+		"icon_emoji":     stringOrDefault(m.IconEmoji, ""),
+		"backlog_size":   int64OrDefault(m.BacklogSize, 0),
+		"time_zone":      stringOrDefault(m.TimeZone, "UTC"),
+	}
+}
+func (m *notificationSlackModel) fromConfig(cfg map[string]interface{}) {
+	m.Channel = readCfgString(m.Channel, cfg, "channel", "")
+	m.Color = readCfgString(m.Color, cfg, "color", notificationColorDefault)
+	m.CustomMessage = readCfgString(m.CustomMessage, cfg, "custom_message", "")
+	m.UserName = readCfgString(m.UserName, cfg, "user_name", "")
+	m.NotifyChannel = readCfgBool(m.NotifyChannel, cfg, "notify_channel", false)
+	m.LinkNames = readCfgBool(m.LinkNames, cfg, "link_names", false)
+	m.IconURL = readCfgString(m.IconURL, cfg, "icon_url", "")
+	m.IconEmoji = readCfgString(m.IconEmoji, cfg, "icon_emoji", "")
+	m.BacklogSize = readCfgInt64(m.BacklogSize, cfg, "backlog_size", 0)
+	m.TimeZone = readCfgString(m.TimeZone, cfg, "time_zone", "UTC")
+}
+
+// ---- teams ----
+
+func (m *notificationTeamsModel) shortType() string { return "teams" }
+func (m *notificationTeamsModel) toConfig() map[string]interface{} {
+	cfg := map[string]interface{}{
+		"webhook_url":  m.WebhookURL.ValueString(),
+		"icon_url":     stringOrDefault(m.IconURL, ""),
+		"backlog_size": int64OrDefault(m.BacklogSize, 0),
+		"time_zone":    stringOrDefault(m.TimeZone, "UTC"),
+	}
+	// custom_message/color belong to teams-notification-v1 (Graylog 5), adaptive_card to v2 (Graylog 6+)
+	putStringIfSet(cfg, "custom_message", m.CustomMessage)
+	putStringIfSet(cfg, "adaptive_card", m.AdaptiveCard)
+	if !m.CustomMessage.IsNull() || !m.Color.IsNull() {
+		cfg["color"] = stringOrDefault(m.Color, notificationColorDefault)
+	}
+	return cfg
+}
+func (m *notificationTeamsModel) fromConfig(cfg map[string]interface{}) {
+	m.Color = readCfgString(m.Color, cfg, "color", notificationColorDefault)
+	m.CustomMessage = readCfgString(m.CustomMessage, cfg, "custom_message", "")
+	m.AdaptiveCard = readCfgString(m.AdaptiveCard, cfg, "adaptive_card", "")
+	m.IconURL = readCfgString(m.IconURL, cfg, "icon_url", "")
+	m.BacklogSize = readCfgInt64(m.BacklogSize, cfg, "backlog_size", 0)
+	m.TimeZone = readCfgString(m.TimeZone, cfg, "time_zone", "UTC")
+}
+
+// ---- pagerduty ----
+
+func (m *notificationPagerDutyModel) shortType() string { return "pagerduty" }
+func (m *notificationPagerDutyModel) toConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"routing_key":     m.RoutingKey.ValueString(),
+		"custom_incident": boolOrDefault(m.CustomIncident, false),
+		"key_prefix":      stringOrDefault(m.KeyPrefix, "Graylog"),
+		"client_name":     stringOrDefault(m.ClientName, "Graylog"),
+		"client_url":      stringOrDefault(m.ClientURL, ""),
+	}
+}
+func (m *notificationPagerDutyModel) fromConfig(cfg map[string]interface{}) {
+	m.CustomIncident = readCfgBool(m.CustomIncident, cfg, "custom_incident", false)
+	m.KeyPrefix = readCfgString(m.KeyPrefix, cfg, "key_prefix", "Graylog")
+	m.ClientName = readCfgString(m.ClientName, cfg, "client_name", "Graylog")
+	m.ClientURL = readCfgString(m.ClientURL, cfg, "client_url", "")
+}
+
+// ---- script ----
+
+func (m *notificationScriptModel) shortType() string { return "script" }
+func (m *notificationScriptModel) toConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"script_path":       m.ScriptPath.ValueString(),
+		"script_args":       stringOrDefault(m.ScriptArgs, ""),
+		"script_timeout":    int64OrDefault(m.ScriptTimeout, notificationScriptTimeoutDefault),
+		"script_send_stdin": boolOrDefault(m.ScriptSendStdin, false),
+	}
+}
+func (m *notificationScriptModel) fromConfig(cfg map[string]interface{}) {
+	m.ScriptPath = readCfgString(m.ScriptPath, cfg, "script_path", "")
+	m.ScriptArgs = readCfgString(m.ScriptArgs, cfg, "script_args", "")
+	m.ScriptTimeout = readCfgInt64(m.ScriptTimeout, cfg, "script_timeout", notificationScriptTimeoutDefault)
+	m.ScriptSendStdin = readCfgBool(m.ScriptSendStdin, cfg, "script_send_stdin", false)
+}
+
+// typedBlock returns the configured typed block, or nil when `config` is used.
+func (m *eventNotificationModel) typedBlock() typedNotificationBlock {
+	switch {
+	case m.Email != nil:
+		return m.Email
+	case m.HTTP != nil:
+		return m.HTTP
+	case m.Slack != nil:
+		return m.Slack
+	case m.Teams != nil:
+		return m.Teams
+	case m.PagerDuty != nil:
+		return m.PagerDuty
+	case m.Script != nil:
+		return m.Script
+	}
+	return nil
+}
+
+// ---- helpers and schema ----
+
+// putEncryptedIfSet sends a secret as a Graylog encrypted value.
+func putEncryptedIfSet(cfg map[string]interface{}, key string, v types.String) {
+	if !v.IsNull() && !v.IsUnknown() && v.ValueString() != "" {
+		cfg[key] = map[string]interface{}{"set_value": v.ValueString()}
+	}
+}
+
+func stringValues(vals []types.String) []string {
+	out := make([]string, 0, len(vals))
+	for _, v := range vals {
+		out = append(out, v.ValueString())
+	}
+	return out
+}
+
+func notificationTimeZoneAttr() schema.StringAttribute {
+	return schema.StringAttribute{Optional: true, Description: "Time zone used to render timestamps in templates (default UTC)"}
+}
+
+func notificationBacklogAttr() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional:    true,
+		Description: "Number of backlog messages included in the notification (default 0)",
+		Validators:  []validator.Int64{int64validator.AtLeast(0)},
+	}
+}
+
+// typedNotificationBlocks returns the schema blocks for typed notification configuration.
+func typedNotificationBlocks() map[string]schema.Block {
+	return map[string]schema.Block{
+		"email": schema.SingleNestedBlock{
+			Description: "Email notification (email-notification-v1)",
+			Attributes: map[string]schema.Attribute{
+				"sender":             schema.StringAttribute{Optional: true, Description: "Sender address (default: the server's transport_email_from_email)"},
+				"subject":            schema.StringAttribute{Required: true, Description: "Subject template"},
+				"reply_to":           schema.StringAttribute{Optional: true, Description: "Reply-To address"},
+				"body_template":      schema.StringAttribute{Optional: true, Description: "Plain text body template"},
+				"html_body_template": schema.StringAttribute{Optional: true, Description: "HTML body template"},
+				"email_recipients":   schema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Recipient email addresses"},
+				"user_recipients":    schema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Recipient Graylog user names"},
+				"time_zone":          notificationTimeZoneAttr(),
+			},
+		},
+		"http": schema.SingleNestedBlock{
+			Description: "HTTP notification (http-notification-v1); the event is POSTed as JSON",
+			Attributes: map[string]schema.Attribute{
+				"url":                   schema.StringAttribute{Required: true, Description: "Target URL; must be allowed by the server's URL whitelist"},
+				"skip_tls_verification": schema.BoolAttribute{Optional: true, Description: "Skip TLS certificate verification (default false)"},
+				"basic_auth":            schema.StringAttribute{Optional: true, Sensitive: true, Description: "Basic authentication credentials as user:password"},
+				"api_key_as_header":     schema.BoolAttribute{Optional: true, Description: "Send api_key/api_secret as a header instead of a query parameter (default false)"},
+				"api_key":               schema.StringAttribute{Optional: true, Description: "API key name"},
+				"api_secret": schema.StringAttribute{
+					Optional:    true,
+					Sensitive:   true,
+					Description: "API key value; requires api_key",
+					Validators:  []validator.String{stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("api_key"))},
+				},
+			},
+		},
+		"slack": schema.SingleNestedBlock{
+			Description: "Slack notification (slack-notification-v1)",
+			Attributes: map[string]schema.Attribute{
+				"webhook_url":    schema.StringAttribute{Required: true, Sensitive: true, Description: "Slack incoming webhook URL"},
+				"channel":        schema.StringAttribute{Required: true, Description: "Channel, e.g. #alerts or @user"},
+				"color":          schema.StringAttribute{Optional: true, Description: "Attachment color (default " + notificationColorDefault + ")"},
+				"custom_message": schema.StringAttribute{Optional: true, Description: "Message template"},
+				"user_name":      schema.StringAttribute{Optional: true, Description: "User name the message is posted as"},
+				"notify_channel": schema.BoolAttribute{Optional: true, Description: "Notify all channel members with @channel (default false)"},
+				"link_names":     schema.BoolAttribute{Optional: true, Description: "Link channel and user names (default false)"},
+				"icon_url":       schema.StringAttribute{Optional: true, Description: "Icon URL"},
+				"icon_emoji":     schema.StringAttribute{Optional: true, Description: "Icon emoji, e.g. :warning:"},
+				"backlog_size":   notificationBacklogAttr(),
+				"time_zone":      notificationTimeZoneAttr(),
+			},
+		},
+		"teams": schema.SingleNestedBlock{
+			Description: "Microsoft Teams notification (teams-notification-v1 on Graylog 5, teams-notification-v2 on Graylog 6+)",
+			Attributes: map[string]schema.Attribute{
+				"webhook_url":    schema.StringAttribute{Required: true, Sensitive: true, Description: "Teams webhook URL"},
+				"color":          schema.StringAttribute{Optional: true, Description: "Message card color, Graylog 5 only (default " + notificationColorDefault + ")"},
+				"custom_message": schema.StringAttribute{Optional: true, Description: "Message template, Graylog 5 only"},
+				"adaptive_card":  schema.StringAttribute{Optional: true, Description: "Adaptive card JSON template, Graylog 6+ only"},
+				"icon_url":       schema.StringAttribute{Optional: true, Description: "Icon URL"},
+				"backlog_size":   notificationBacklogAttr(),
+				"time_zone":      notificationTimeZoneAttr(),
+			},
+		},
+		"pagerduty": schema.SingleNestedBlock{
+			Description: "PagerDuty notification (pagerduty-notification-v2, Events API v2)",
+			Attributes: map[string]schema.Attribute{
+				"routing_key":     schema.StringAttribute{Required: true, Sensitive: true, Description: "Integration routing key"},
+				"custom_incident": schema.BoolAttribute{Optional: true, Description: "Use a custom incident key built from key_prefix (default false)"},
+				"key_prefix":      schema.StringAttribute{Optional: true, Description: "Incident key prefix (default Graylog)"},
+				"client_name":     schema.StringAttribute{Optional: true, Description: "Client name shown in PagerDuty (default Graylog)"},
+				"client_url":      schema.StringAttribute{Optional: true, Description: "Client URL shown in PagerDuty"},
+			},
+		},
+		"script": schema.SingleNestedBlock{
+			Description: "Script notification (script-notification-v1); the script must be allowed by the server's script whitelist",
+			Attributes: map[string]schema.Attribute{
+				"script_path": schema.StringAttribute{Required: true, Description: "Path of the script on the Graylog nodes"},
+				"script_args": schema.StringAttribute{Optional: true, Description: "Space separated script arguments"},
+				"script_timeout": schema.Int64Attribute{
+					Optional:    true,
+					Description: "Script timeout in milliseconds (default 10000)",
+					Validators:  []validator.Int64{int64validator.AtLeast(1)},
+				},
+				"script_send_stdin": schema.BoolAttribute{Optional: true, Description: "Send the event as JSON on standard input (default false)"},
+			},
+		},
+	}
+}
+
+// typedBlockPath returns the schema path of the configured typed block.
+func (m *eventNotificationModel) typedBlockPath() path.Path {
+	if b := m.typedBlock(); b != nil {
+		return path.Root(b.shortType())
+	}
+	return path.Root("config")
+}
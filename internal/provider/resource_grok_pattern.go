@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type grokPatternResource struct{ client *client.Client }
+
+type grokPatternModel struct {
+	ID         types.String   `tfsdk:"id"`
+	Name       types.String   `tfsdk:"name"`
+	Pattern    types.String   `tfsdk:"pattern"`
+	TestSample types.String   `tfsdk:"test_sample"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewGrokPatternResource() resource.Resource { return &grokPatternResource{} }
+
+func (r *grokPatternResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "graylog_grok_pattern"
+}
+
+func (r *grokPatternResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a single Graylog grok pattern. The pattern is validated with the server-side grok test endpoint before it is saved.",
+		Attributes: map[string]schema.Attribute{
+			"id":          schema.StringAttribute{Computed: true, Description: "Grok pattern ID"},
+			"name":        schema.StringAttribute{Required: true, Description: "Pattern name used as %{NAME} in other patterns and extractors"},
+			"pattern":     schema.StringAttribute{Required: true, Description: "Grok pattern expression"},
+			"test_sample": schema.StringAttribute{Optional: true, Description: "Optional sample message; when set, the pattern must match it during validation"},
+			"timeouts":    timeouts.Attributes(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+		},
+	}
+}
+
+func (r *grokPatternResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*client.Client)
+}
+
+func (r *grokPatternResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data grokPatternModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Server-side validation
+	resp.Diagnostics.Append(validateGrokPattern(r.client.WithContext(ctx), path.Root("pattern"), data.Name.ValueString(), data.Pattern.ValueString(), data.TestSample.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.WithContext(ctx).CreateGrokPattern(&client.GrokPattern{
+		Name:    data.Name.ValueString(),
+		Pattern: data.Pattern.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating grok pattern", err.Error())
+		return
+	}
+	data.ID = types.StringValue(created.ID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *grokPatternResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data grokPatternModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	p, err := r.client.WithContext(ctx).GetGrokPattern(data.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading grok pattern", err.Error())
+		return
+	}
+	data.Name = types.StringValue(p.Name)
+	data.Pattern = types.StringValue(p.Pattern)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *grokPatternResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state grokPatternModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = state.ID
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Server-side validation
+	resp.Diagnostics.Append(validateGrokPattern(r.client.WithContext(ctx), path.Root("pattern"), data.Name.ValueString(), data.Pattern.ValueString(), data.TestSample.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.client.WithContext(ctx).UpdateGrokPattern(data.ID.ValueString(), &client.GrokPattern{
+		Name:    data.Name.ValueString(),
+		Pattern: data.Pattern.ValueString(),
+	}); err != nil {
+		resp.Diagnostics.AddError("Error updating grok pattern", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *grokPatternResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data grokPatternModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, 3*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if err := r.client.WithContext(ctx).DeleteGrokPattern(data.ID.ValueString()); err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return
+		}
+		resp.Diagnostics.AddError("Error deleting grok pattern", err.Error())
+	}
+}
+
+// ImportState accepts a pattern ID or a pattern name (optionally prefixed with "name:").
+func (r *grokPatternResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	val := strings.TrimSpace(req.ID)
+	if val == "" {
+		resp.Diagnostics.AddError("Empty import ID", "Provide a grok pattern ID or name.")
+		return
+	}
+	isHex24 := regexp.MustCompile(`(?i)^[0-9a-f]{24}$`).MatchString
+	if isHex24(val) {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), val)...) //nolint:errcheck
+		return
+	}
+	const prefix = "name:"
+	if strings.HasPrefix(strings.ToLower(val), prefix) {
+		val = strings.TrimSpace(val[len(prefix):])
+	}
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Client is nil; cannot resolve grok pattern by name during import.")
+		return
+	}
+	list, err := r.client.WithContext(ctx).ListGrokPatterns()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to list grok patterns for import", err.Error())
+		return
+	}
+	for _, p := range list {
+		if p.Name == val {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), p.ID)...) //nolint:errcheck
+			return
+		}
+	}
+	resp.Diagnostics.AddError("Grok pattern not found by name", "No grok pattern found with name: "+val+".")
+}
+
+// validateGrokPattern compiles the pattern through the server-side grok test endpoint and,
+// when sample is non-empty, requires the pattern to match it.
+func validateGrokPattern(c *client.Client, p path.Path, name, pattern, sample string) (d diag.Diagnostics) {
+	if strings.TrimSpace(name) == "" {
+		d.AddAttributeError(path.Root("name"), "Invalid name", "Grok pattern name must be a non-empty string.")
+	}
+	if strings.TrimSpace(pattern) == "" {
+		d.AddAttributeError(p, "Invalid pattern", "Grok pattern must be a non-empty string.")
+	}
+	if d.HasError() {
+		return
+	}
+	res, err := c.TestGrokPattern(&client.GrokPattern{Name: name, Pattern: pattern}, sample)
+	if err != nil {
+		d.AddAttributeError(p, "Invalid grok pattern", fmt.Sprintf("Graylog rejected grok pattern %q: %s", name, err.Error()))
+		return
+	}
+	// Some versions answer 200 with error_message instead of 400
+	if msg, _ := res["error_message"].(string); msg != "" {
+		d.AddAttributeError(p, "Invalid grok pattern", fmt.Sprintf("Graylog rejected grok pattern %q: %s", name, msg))
+		return
+	}
+	if sample != "" && !grokTestMatched(res) {
+		d.AddAttributeError(p, "Grok pattern does not match test sample", fmt.Sprintf("Pattern %q did not match the configured test_sample.", name))
+	}
+	return
+}
+
+// grokTestMatched interprets a grok test response: {"matched": bool, "matches": [...]}.
+func grokTestMatched(res map[string]any) bool {
+	if m, ok := res["matched"].(bool); ok {
+		return m
+	}
+	matches, _ := res["matches"].([]any)
+	return len(matches) > 0
+}
@@ -0,0 +1,73 @@
+//go:build acceptance
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccGrokPattern_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + `
+resource "graylog_grok_pattern" "p" {
+  name        = "TF_ACC_WORD"
+  pattern     = "[a-z]+"
+  test_sample = "hello"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("graylog_grok_pattern.p", "id"),
+					resource.TestCheckResourceAttr("graylog_grok_pattern.p", "pattern", "[a-z]+"),
+				),
+			},
+			{
+				ResourceName:            "graylog_grok_pattern.p",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts", "test_sample"},
+			},
+		},
+	})
+}
+
+func TestAccGrokPatterns_library(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + `
+resource "graylog_grok_patterns" "lib" {
+  patterns = {
+    TF_ACC_HOST = "[a-z0-9.-]+"
+    TF_ACC_LINE = "%{TF_ACC_HOST:host} %{INT:code}"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_grok_patterns.lib", "id", "TF_ACC_HOST,TF_ACC_LINE"),
+					resource.TestCheckResourceAttrSet("graylog_grok_patterns.lib", "ids.TF_ACC_LINE"),
+				),
+			},
+			{
+				Config: testAccProviderConfig() + `
+resource "graylog_grok_patterns" "lib" {
+  patterns = {
+    TF_ACC_HOST = "[a-zA-Z0-9.-]+"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_grok_patterns.lib", "patterns.%", "1"),
+					resource.TestCheckResourceAttr("graylog_grok_patterns.lib", "patterns.TF_ACC_HOST", "[a-zA-Z0-9.-]+"),
+				),
+			},
+		},
+	})
+}
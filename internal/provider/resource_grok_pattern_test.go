@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGrokPatternResource_New(t *testing.T) {
+	if NewGrokPatternResource() == nil || NewGrokPatternsResource() == nil {
+		t.Fatal("expected non-nil resources")
+	}
+}
+
+func TestGrokTestMatched(t *testing.T) {
+	if !grokTestMatched(map[string]any{"matched": true}) {
+		t.Fatal("expected matched=true")
+	}
+	if grokTestMatched(map[string]any{"matched": false, "matches": []any{map[string]any{}}}) {
+		t.Fatal("explicit matched=false must win")
+	}
+	if !grokTestMatched(map[string]any{"matches": []any{map[string]any{"name": "X"}}}) {
+		t.Fatal("expected non-empty matches to count as a match")
+	}
+	if grokTestMatched(map[string]any{}) {
+		t.Fatal("expected empty response to be no match")
+	}
+}
+
+func TestExpandGrokRefs(t *testing.T) {
+	batch := map[string]string{
+		"A": `%{B:b} %{INT}`,
+		"B": `\w+`,
+		"X": `%{Y}`,
+		"Y": `%{X}`,
+	}
+	if got := expandGrokRefs(batch["A"], batch, "A"); got != `(?:\w+) %{INT}` {
+		t.Fatalf("unexpected expansion: %s", got)
+	}
+	// Cycles stay unexpanded and terminate
+	if got := expandGrokRefs(batch["X"], batch, "X"); !strings.Contains(got, "%{X}") {
+		t.Fatalf("expected cyclic reference to be kept, got %s", got)
+	}
+}
+
+func TestGrokDependencyOrder(t *testing.T) {
+	batch := map[string]string{
+		"A_LINE": `%{Z_HOST} %{M_USER}`,
+		"M_USER": `%{Z_HOST}@\w+`,
+		"Z_HOST": `[a-z.]+`,
+	}
+	want := []string{"Z_HOST", "M_USER", "A_LINE"}
+	if got := grokDependencyOrder(batch); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
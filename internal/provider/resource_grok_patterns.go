@@ -0,0 +1,354 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type grokPatternsResource struct{ client *client.Client }
+
+type grokPatternsModel struct {
+	ID       types.String            `tfsdk:"id"`
+	Patterns map[string]types.String `tfsdk:"patterns"`
+	IDs      types.Map               `tfsdk:"ids"`
+	Timeouts timeouts.Value          `tfsdk:"timeouts"`
+}
+
+// grokRefRe matches %{NAME}, %{NAME:field} and %{NAME:field:type} references.
+var grokRefRe = regexp.MustCompile(`%\{(\w+)(?::[^:}]+)?(?::\w+)?\}`)
+
+func NewGrokPatternsResource() resource.Resource { return &grokPatternsResource{} }
+
+func (r *grokPatternsResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "graylog_grok_patterns"
+}
+
+func (r *grokPatternsResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a library of Graylog grok patterns as one map (name => pattern). Only patterns listed in the map are managed; other patterns on the server are left untouched.",
+		Attributes: map[string]schema.Attribute{
+			"id":       schema.StringAttribute{Computed: true, Description: "Synthetic ID: comma-separated sorted pattern names"},
+			"patterns": schema.MapAttribute{Required: true, ElementType: types.StringType, Description: "Map of grok pattern name to pattern expression"},
+			"ids":      schema.MapAttribute{Computed: true, ElementType: types.StringType, Description: "Map of grok pattern name to Graylog pattern ID"},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+		},
+	}
+}
+
+func (r *grokPatternsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*client.Client)
+}
+
+func (r *grokPatternsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data grokPatternsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	synced, diags := r.sync(ctx, &data, nil)
+	resp.Diagnostics.Append(diags...)
+	// Patterns created before a failure are written to state rather than orphaned
+	if !synced || len(data.Patterns) == 0 {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *grokPatternsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data grokPatternsModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	list, err := r.client.WithContext(ctx).ListGrokPatterns()
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing grok patterns", err.Error())
+		return
+	}
+	byName := make(map[string]client.GrokPattern, len(list))
+	for _, p := range list {
+		byName[p.Name] = p
+	}
+	// Keep only managed names; patterns deleted outside Terraform drop out of the map (drift)
+	patterns := map[string]types.String{}
+	ids := map[string]string{}
+	for name := range data.Patterns {
+		if p, ok := byName[name]; ok {
+			patterns[name] = types.StringValue(p.Pattern)
+			ids[name] = p.ID
+		}
+	}
+	if len(patterns) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.Patterns = patterns
+	resp.Diagnostics.Append(setGrokPatternIDs(ctx, &data, ids)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *grokPatternsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state grokPatternsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	synced, diags := r.sync(ctx, &data, state.Patterns)
+	resp.Diagnostics.Append(diags...)
+	if !synced {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *grokPatternsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data grokPatternsModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, 3*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	list, err := r.client.WithContext(ctx).ListGrokPatterns()
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing grok patterns", err.Error())
+		return
+	}
+	for _, p := range list {
+		if _, ok := data.Patterns[p.Name]; !ok {
+			continue
+		}
+		if err := r.client.WithContext(ctx).DeleteGrokPattern(p.ID); err != nil && !errors.Is(err, client.ErrNotFound) {
+			resp.Diagnostics.AddError("Error deleting grok pattern "+p.Name, err.Error())
+			return
+		}
+	}
+}
+
+// ImportState takes a comma-separated list of pattern names to manage, e.g. "MY_HOST,MY_USER".
+func (r *grokPatternsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	names := map[string]types.String{}
+	for _, n := range strings.Split(req.ID, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			names[n] = types.StringValue("")
+		}
+	}
+	if len(names) == 0 {
+		resp.Diagnostics.AddError("Invalid import ID", "Expected a comma-separated list of grok pattern names, e.g. 'MY_HOST,MY_USER'.")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), grokPatternsID(names))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("patterns"), names)...)
+}
+
+// sync makes the managed patterns on the server equal to data.Patterns.
+// prior holds previously managed names (nil on create); names dropped from the map are deleted.
+// Names that are new to the map must not exist on the server yet, so built-in or otherwise managed
+// patterns are never taken over. synced reports whether data matches the server afterwards; when a
+// change fails partway, data is narrowed to the patterns managed so far.
+func (r *grokPatternsResource) sync(ctx context.Context, data *grokPatternsModel, prior map[string]types.String) (synced bool, d diag.Diagnostics) {
+	c := r.client.WithContext(ctx)
+	want := make(map[string]string, len(data.Patterns))
+	for name, p := range data.Patterns {
+		want[name] = p.ValueString()
+	}
+
+	// Validate every pattern on the server first; references to other patterns in this map are
+	// expanded inline because they may not exist in Graylog yet.
+	for _, name := range sortedKeys(want) {
+		expanded := expandGrokRefs(want[name], want, name)
+		d.Append(validateGrokPattern(c, path.Root("patterns").AtMapKey(name), name, expanded, "")...)
+	}
+	if d.HasError() {
+		return
+	}
+
+	list, err := c.ListGrokPatterns()
+	if err != nil {
+		d.AddError("Error listing grok patterns", err.Error())
+		return
+	}
+	existing := make(map[string]client.GrokPattern, len(list))
+	for _, p := range list {
+		existing[p.Name] = p
+	}
+	var taken []string
+	for _, name := range sortedKeys(want) {
+		if _, managed := prior[name]; !managed {
+			if _, ok := existing[name]; ok {
+				taken = append(taken, name)
+			}
+		}
+	}
+	if len(taken) > 0 {
+		d.AddAttributeError(path.Root("patterns"), "Grok patterns already exist",
+			"Graylog already has the patterns "+strings.Join(taken, ", ")+". Pick other names, or import them with `terraform import` using the ID \""+strings.Join(taken, ",")+"\" to manage them.")
+		return
+	}
+
+	// done tracks the managed patterns as they are on the server
+	done := map[string]types.String{}
+	ids := map[string]string{}
+	for name, v := range prior {
+		if cur, ok := existing[name]; ok {
+			done[name], ids[name] = v, cur.ID
+		}
+	}
+	defer func() {
+		synced = true
+		if d.HasError() {
+			data.Patterns = done
+		}
+		d.Append(setGrokPatternIDs(ctx, data, ids)...)
+	}()
+
+	// Create/update in dependency order so that referenced patterns exist first
+	for _, name := range grokDependencyOrder(want) {
+		pattern := want[name]
+		if cur, ok := existing[name]; ok {
+			if cur.Pattern != pattern {
+				if _, err := c.UpdateGrokPattern(cur.ID, &client.GrokPattern{Name: name, Pattern: pattern}); err != nil {
+					d.AddError("Error updating grok pattern "+name, err.Error())
+					return
+				}
+			}
+			done[name] = types.StringValue(pattern)
+			continue
+		}
+		created, err := c.CreateGrokPattern(&client.GrokPattern{Name: name, Pattern: pattern})
+		if err != nil {
+			d.AddError("Error creating grok pattern "+name, err.Error())
+			return
+		}
+		done[name], ids[name] = types.StringValue(pattern), created.ID
+	}
+
+	for name := range prior {
+		if _, keep := want[name]; keep {
+			continue
+		}
+		if cur, ok := existing[name]; ok {
+			if err := c.DeleteGrokPattern(cur.ID); err != nil && !errors.Is(err, client.ErrNotFound) {
+				d.AddError("Error deleting grok pattern "+name, err.Error())
+				return
+			}
+		}
+		delete(done, name)
+		delete(ids, name)
+	}
+	return
+}
+
+func setGrokPatternIDs(ctx context.Context, data *grokPatternsModel, ids map[string]string) diag.Diagnostics {
+	m, diags := types.MapValueFrom(ctx, types.StringType, ids)
+	data.IDs = m
+	data.ID = types.StringValue(grokPatternsID(data.Patterns))
+	return diags
+}
+
+func grokPatternsID(patterns map[string]types.String) string {
+	names := make([]string, 0, len(patterns))
+	for n := range patterns {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// expandGrokRefs inlines references to patterns defined in batch so the result can be compiled
+// by Graylog before those patterns exist. Cycles are left unexpanded (Graylog will reject them).
+func expandGrokRefs(pattern string, batch map[string]string, self string) string {
+	seen := map[string]bool{self: true}
+	var expand func(string, int) string
+	expand = func(p string, depth int) string {
+		if depth > 32 {
+			return p
+		}
+		return grokRefRe.ReplaceAllStringFunc(p, func(ref string) string {
+			name := grokRefRe.FindStringSubmatch(ref)[1]
+			body, ok := batch[name]
+			if !ok || seen[name] {
+				return ref
+			}
+			seen[name] = true
+			defer delete(seen, name)
+			return "(?:" + expand(body, depth+1) + ")"
+		})
+	}
+	return expand(pattern, 0)
+}
+
+// grokDependencyOrder returns names so that patterns referenced by others in the batch come first.
+func grokDependencyOrder(batch map[string]string) []string {
+	var out []string
+	state := map[string]int{} // 0 = new, 1 = visiting, 2 = done
+	var visit func(string)
+	visit = func(name string) {
+		if state[name] != 0 {
+			return
+		}
+		state[name] = 1
+		for _, m := range grokRefRe.FindAllStringSubmatch(batch[name], -1) {
+			if _, ok := batch[m[1]]; ok {
+				visit(m[1])
+			}
+		}
+		state[name] = 2
+		out = append(out, name)
+	}
+	for _, name := range sortedKeys(batch) {
+		visit(name)
+	}
+	return out
+}
@@ -4,30 +4,50 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type inputResource struct{ client *client.Client }
 
 type inputModel struct {
-	ID            types.String   `tfsdk:"id"`
-	Title         types.String   `tfsdk:"title"`
-	Type          types.String   `tfsdk:"type"`
-	Global        types.Bool     `tfsdk:"global"`
-	Node          types.String   `tfsdk:"node"`
-	Configuration types.String   `tfsdk:"configuration"`
-	Extractors    types.String   `tfsdk:"extractors"`
-	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+	ID            types.String            `tfsdk:"id"`
+	Title         types.String            `tfsdk:"title"`
+	Type          types.String            `tfsdk:"type"`
+	Global        types.Bool              `tfsdk:"global"`
+	Node          types.String            `tfsdk:"node"`
+	Configuration types.String            `tfsdk:"configuration"`
+	Extractors    types.String            `tfsdk:"extractors"`
+	StaticFields  map[string]types.String `tfsdk:"static_fields"`
+	DesiredState  types.String            `tfsdk:"desired_state"`
+	NodeStates    types.Map               `tfsdk:"node_states"`
+	SyslogUDP     *inputSyslogUDPModel    `tfsdk:"syslog_udp"`
+	SyslogTCP     *inputSyslogTCPModel    `tfsdk:"syslog_tcp"`
+	GELFUDP       *inputGELFUDPModel      `tfsdk:"gelf_udp"`
+	GELFTCP       *inputGELFTCPModel      `tfsdk:"gelf_tcp"`
+	GELFHTTP      *inputGELFHTTPModel     `tfsdk:"gelf_http"`
+	Beats         *inputBeatsModel        `tfsdk:"beats"`
+	RawTCP        *inputRawTCPModel       `tfsdk:"raw_tcp"`
+	Kafka         *inputKafkaModel        `tfsdk:"kafka"`
+	Timeouts      timeouts.Value          `tfsdk:"timeouts"`
 }
 
+// staticFieldKeyRe mirrors Graylog's rule for message field names.
+var staticFieldKeyRe = regexp.MustCompile(`^[\w.\-@]+$`)
+
 func NewInputResource() resource.Resource { return &inputResource{} }
 
 func (r *inputResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -41,7 +61,7 @@ func (r *inputResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 		Attributes: map[string]schema.Attribute{
 			"id":    schema.StringAttribute{Computed: true, Description: "The unique identifier of the input"},
 			"title": schema.StringAttribute{Required: true, Description: "The title of the input"},
-			"type":  schema.StringAttribute{Required: true, Description: "The input type (e.g., org.graylog2.inputs.syslog.udp.SyslogUDPInput)"},
+			"type":  schema.StringAttribute{Optional: true, Computed: true, Description: "The input type (e.g., org.graylog2.inputs.syslog.udp.SyslogUDPInput). Derived from the typed block when one is used."},
 			"global": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
@@ -54,24 +74,99 @@ func (r *inputResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 			// Use JSON-encoded strings to represent free-form objects to satisfy framework limitations.
 			"configuration": schema.StringAttribute{
 				Optional:    true,
-				Description: "JSON-encoded object with input configuration (free-form).",
+				Description: "JSON-encoded object with input configuration (free-form). Conflicts with the typed configuration blocks.",
 			},
 			"extractors": schema.StringAttribute{
 				Optional:    true,
 				Description: "JSON-encoded list of extractor objects (free-form).",
 			},
+			"static_fields": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Static fields added to every message received by this input (e.g. env, team)",
+			},
+			"desired_state": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Runtime state enforced on apply: running | stopped. When unset, the observed state is reported (running, stopped or failed).",
+				Validators:  []validator.String{stringvalidator.OneOf("running", "stopped")},
+			},
+			"node_states": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Runtime state of the input per node ID (e.g. RUNNING, FAILED, STOPPED)",
+			},
 			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
 		},
+		Blocks: typedInputBlocks(),
 	}
 }
 
+func (r *inputResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	exprs := []path.Expression{path.MatchRoot("configuration")}
+	for _, name := range typedInputBlockNames {
+		exprs = append(exprs, path.MatchRoot(name))
+	}
+	return []resource.ConfigValidator{resourcevalidator.Conflicting(exprs...)}
+}
+
+// ModifyPlan derives `type` from the typed configuration block so the class is known at plan time.
+func (r *inputResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var data inputModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if b := data.typedBlock(); b != nil && data.Type.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("type"), b.class())...)
+	}
+	// Check the JSON configuration against the server's input type catalog
+	if r.client == nil || data.typedBlock() != nil || data.Type.IsUnknown() || data.Type.IsNull() || data.Configuration.IsUnknown() {
+		return
+	}
+	// Only new or changed configurations are checked; unchanged plans need no catalog round-trip
+	if !req.State.Raw.IsNull() {
+		var state inputModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state.Type.Equal(data.Type) && state.Configuration.Equal(data.Configuration) {
+			return
+		}
+	}
+	info, err := r.client.WithContext(ctx).GetInputType(data.Type.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(path.Root("type"), "Unknown input type", "Input type '"+data.Type.ValueString()+"' is not available on this Graylog server; see the graylog_input_types data source for valid classes.")
+			return
+		}
+		resp.Diagnostics.AddWarning("Unable to fetch input type catalog", "Configuration keys were not checked at plan time: "+err.Error())
+		return
+	}
+	resp.Diagnostics.Append(validateInput(ctx, &data, info)...)
+}
+
 // validateInput performs runtime validation of the input model and appends diagnostics on issues.
-func validateInput(ctx context.Context, data *inputModel) (diags diag.Diagnostics) {
+// When catalog is non-nil the JSON configuration is also checked against the type's requested configuration.
+func validateInput(ctx context.Context, data *inputModel, catalog *client.InputTypeInfo) (diags diag.Diagnostics) {
 	if data.Title.IsNull() || data.Title.IsUnknown() || data.Title.ValueString() == "" {
 		diags.AddAttributeError(path.Root("title"), "Invalid title", "Attribute 'title' must be a non-empty string.")
 	}
-	if data.Type.IsNull() || data.Type.IsUnknown() || data.Type.ValueString() == "" {
-		diags.AddAttributeError(path.Root("type"), "Invalid type", "Attribute 'type' must be a non-empty string with a Graylog input class name.")
+	if b := data.typedBlock(); b != nil {
+		if !data.Type.IsNull() && !data.Type.IsUnknown() && data.Type.ValueString() != b.class() {
+			diags.AddAttributeError(path.Root("type"), "Type does not match configuration block", "The typed configuration block implies type '"+b.class()+"'; remove 'type' or set it to that class.")
+		}
+		if tls := tlsSettings(b); tls != nil {
+			if ca := stringOrDefault(tls.TLSClientAuth, "disabled"); ca != "disabled" && stringOrDefault(tls.TLSClientAuthCertFile, "") == "" {
+				diags.AddAttributeError(data.typedBlockPath().AtName("tls_client_auth_cert_file"), "Missing tls_client_auth_cert_file", "tls_client_auth = '"+ca+"' requires tls_client_auth_cert_file.")
+			}
+		}
+	} else if data.Type.IsNull() || data.Type.IsUnknown() || data.Type.ValueString() == "" {
+		diags.AddAttributeError(path.Root("type"), "Invalid type", "Attribute 'type' must be a non-empty string with a Graylog input class name (or use a typed configuration block).")
 	}
 	// Cross-field: global/node
 	if !data.Global.IsUnknown() && !data.Global.IsNull() && !data.Global.ValueBool() {
@@ -80,9 +175,76 @@ func validateInput(ctx context.Context, data *inputModel) (diags diag.Diagnostic
 		}
 	}
 	// Extractors: optional JSON string
+	for k := range data.StaticFields {
+		if !staticFieldKeyRe.MatchString(k) {
+			diags.AddAttributeError(path.Root("static_fields").AtMapKey(k), "Invalid static field name", "Static field names may only contain letters, digits, '_', '.', '-' and '@'.")
+		}
+	}
+	if catalog != nil && data.typedBlock() == nil && !data.Configuration.IsUnknown() {
+		cfg := map[string]interface{}{}
+		if s := data.Configuration.ValueString(); s != "" {
+			if err := json.Unmarshal([]byte(s), &cfg); err != nil {
+				diags.AddAttributeError(path.Root("configuration"), "Invalid configuration JSON", err.Error())
+				return
+			}
+		}
+		diags.Append(validateInputConfigAgainstCatalog(catalog, cfg, path.Root("configuration"))...)
+	}
 	return
 }
 
+// validateInputConfigAgainstCatalog warns about configuration keys the input type does not know
+// and flags required fields that are missing. Graylog silently drops unknown keys, so a misspelled
+// key only surfaces as a missing field when the input is created; unknown keys stay warnings because
+// catalogs differ between versions and plugins.
+func validateInputConfigAgainstCatalog(info *client.InputTypeInfo, cfg map[string]interface{}, p path.Path) (diags diag.Diagnostics) {
+	keys := make([]string, 0, len(cfg))
+	for k := range cfg {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if _, ok := info.RequestedConfiguration[k]; !ok {
+			diags.AddAttributeWarning(p, "Unknown configuration key", "Input type '"+info.Type+"' has no configuration field '"+k+"'. Known fields: "+strings.Join(inputConfigFieldNames(info), ", ")+".")
+		}
+	}
+	for _, name := range inputConfigFieldNames(info) {
+		f := info.RequestedConfiguration[name]
+		if f.IsOptional {
+			continue
+		}
+		if v, ok := cfg[name]; !ok || v == nil {
+			detail := "Input type '" + info.Type + "' requires configuration field '" + name + "'"
+			if f.DefaultValue != nil {
+				detail += " (Graylog default: " + inputConfigDefaultString(f.DefaultValue) + ")"
+			}
+			diags.AddAttributeError(p, "Missing required configuration field", detail+".")
+		}
+	}
+	return
+}
+
+func inputConfigFieldNames(info *client.InputTypeInfo) []string {
+	names := make([]string, 0, len(info.RequestedConfiguration))
+	for k := range info.RequestedConfiguration {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// inputConfigDefaultString renders a catalog default value: strings as-is, everything else as JSON.
+func inputConfigDefaultString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
 func (r *inputResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -98,7 +260,7 @@ func (r *inputResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Runtime validation
-	resp.Diagnostics.Append(validateInput(ctx, &data)...)
+	resp.Diagnostics.Append(validateInput(ctx, &data, nil)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -112,9 +274,13 @@ func (r *inputResource) Create(ctx context.Context, req resource.CreateRequest,
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
-	// Convert configuration (JSON string) into map[string]interface{}
+	// Convert configuration (typed block or JSON string) into map[string]interface{}
 	config := make(map[string]interface{})
-	if !data.Configuration.IsNull() && !data.Configuration.IsUnknown() && data.Configuration.ValueString() != "" {
+	block := data.typedBlock()
+	if block != nil {
+		config = block.toConfig()
+		data.Type = types.StringValue(block.class())
+	} else if !data.Configuration.IsNull() && !data.Configuration.IsUnknown() && data.Configuration.ValueString() != "" {
 		if err := json.Unmarshal([]byte(data.Configuration.ValueString()), &config); err != nil {
 			resp.Diagnostics.AddAttributeError(path.Root("configuration"), "Invalid configuration JSON", err.Error())
 			return
@@ -138,6 +304,13 @@ func (r *inputResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 	data.ID = types.StringValue(created.ID)
+	if block != nil {
+		block.fromConfig(config)
+	}
+	if err := syncInputStaticFields(r.client.WithContext(ctx), created.ID, nil, data.StaticFields); err != nil {
+		resp.Diagnostics.AddError("Error setting input static fields", err.Error())
+		return
+	}
 	// Create extractors if provided (JSON list of maps)
 	if !data.Extractors.IsNull() && !data.Extractors.IsUnknown() && data.Extractors.ValueString() != "" {
 		var extractorObjs []map[string]interface{}
@@ -149,18 +322,12 @@ func (r *inputResource) Create(ctx context.Context, req resource.CreateRequest,
 		if canon, err := CanonicalizeJSONValue(toAnySlice(extractorObjs)); err == nil {
 			data.Extractors = types.StringValue(canon)
 		}
-		for _, ex := range extractorObjs {
-			// Support either top-level extractor map or nested under "data"
-			payload, ok := ex["data"].(map[string]interface{})
-			if !ok || payload == nil {
-				payload = ex
-			}
-			if _, err := r.client.WithContext(ctx).CreateInputExtractor(created.ID, payload); err != nil {
-				resp.Diagnostics.AddError("Error creating input extractor", err.Error())
-				return
-			}
+		if err := createInputExtractors(r.client.WithContext(ctx), created.ID, extractorObjs); err != nil {
+			resp.Diagnostics.AddError("Error creating input extractor", err.Error())
+			return
 		}
 	}
+	resp.Diagnostics.Append(r.applyDesiredState(ctx, &data)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -184,9 +351,23 @@ func (r *inputResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	data.Type = types.StringValue(in.Type)
 	data.Global = types.BoolValue(in.Global)
 	data.Node = types.StringValue(in.Node)
+	if len(in.StaticFields) > 0 || data.StaticFields != nil {
+		data.StaticFields = make(map[string]types.String, len(in.StaticFields))
+		for k, v := range in.StaticFields {
+			data.StaticFields[k] = types.StringValue(v)
+		}
+	}
 
-	// Set configuration back as canonical JSON string
-	if in.Configuration != nil {
+	// Typed block: read known keys back into the block and leave `configuration` unset
+	if block := data.typedBlock(); block != nil {
+		block.fromConfig(in.Configuration)
+		if data.Kafka != nil {
+			if f := kafkaFormatForClass(in.Type); f != "" {
+				data.Kafka.Format = types.StringValue(f)
+			}
+		}
+	} else if in.Configuration != nil {
+		// Set configuration back as canonical JSON string
 		if canon, err := CanonicalizeJSONValue(in.Configuration); err == nil {
 			data.Configuration = types.StringValue(canon)
 		} else if b, err2 := json.Marshal(in.Configuration); err2 == nil {
@@ -194,29 +375,43 @@ func (r *inputResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		}
 	}
 
-	// Read extractors
-	if exList, err := r.client.WithContext(ctx).ListInputExtractors(data.ID.ValueString()); err == nil {
-		if len(exList) == 0 {
-			data.Extractors = types.StringNull()
-		} else if canon, err2 := CanonicalizeJSONValue(toAnySlice(exList)); err2 == nil {
-			data.Extractors = types.StringValue(canon)
+	// Read extractors only when managed through this attribute (graylog_input_extractor manages them otherwise)
+	if !data.Extractors.IsNull() {
+		if exList, err := r.client.WithContext(ctx).ListInputExtractors(data.ID.ValueString()); err == nil {
+			if len(exList) == 0 {
+				data.Extractors = types.StringNull()
+			} else if canon, err2 := CanonicalizeJSONValue(toAnySlice(exList)); err2 == nil {
+				data.Extractors = types.StringValue(canon)
+			}
+		} else {
+			resp.Diagnostics.AddWarning("Unable to read input extractors", err.Error())
 		}
+	}
+
+	// Runtime state: report what is observed so a failed bind or a manual stop shows up as drift
+	states, err := r.client.WithContext(ctx).ListClusterInputStates()
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to read input states", err.Error())
 	} else {
-		resp.Diagnostics.AddWarning("Unable to read input extractors", err.Error())
+		nodeStates := inputNodeStates(states, data.ID.ValueString())
+		data.DesiredState = types.StringValue(observedInputState(nodeStates))
+		resp.Diagnostics.Append(setInputNodeStates(ctx, &data, nodeStates)...)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *inputResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data inputModel
+	var data, state inputModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.ID = state.ID
 
 	// Runtime validation
-	resp.Diagnostics.Append(validateInput(ctx, &data)...)
+	resp.Diagnostics.Append(validateInput(ctx, &data, nil)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -230,9 +425,13 @@ func (r *inputResource) Update(ctx context.Context, req resource.UpdateRequest,
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
 	defer cancel()
 
-	// Convert configuration JSON to map
+	// Convert configuration (typed block or JSON) to map
 	config := make(map[string]interface{})
-	if !data.Configuration.IsNull() && !data.Configuration.IsUnknown() && data.Configuration.ValueString() != "" {
+	block := data.typedBlock()
+	if block != nil {
+		config = block.toConfig()
+		data.Type = types.StringValue(block.class())
+	} else if !data.Configuration.IsNull() && !data.Configuration.IsUnknown() && data.Configuration.ValueString() != "" {
 		if err := json.Unmarshal([]byte(data.Configuration.ValueString()), &config); err != nil {
 			resp.Diagnostics.AddAttributeError(path.Root("configuration"), "Invalid configuration JSON", err.Error())
 			return
@@ -249,18 +448,33 @@ func (r *inputResource) Update(ctx context.Context, req resource.UpdateRequest,
 		Node:          data.Node.ValueString(),
 		Configuration: config,
 	}
-	_, err := r.client.WithContext(ctx).UpdateInput(data.ID.ValueString(), in)
+	updated, err := r.client.WithContext(ctx).UpdateInput(data.ID.ValueString(), in)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating input", err.Error())
 		return
 	}
+	if block != nil {
+		block.fromConfig(config)
+	}
+	// The PUT response may omit static fields; fetch the current set to reconcile against
+	current := updated.StaticFields
+	if cur, gerr := r.client.WithContext(ctx).GetInput(data.ID.ValueString()); gerr == nil {
+		current = cur.StaticFields
+	}
+	if err := syncInputStaticFields(r.client.WithContext(ctx), data.ID.ValueString(), current, data.StaticFields); err != nil {
+		resp.Diagnostics.AddError("Error updating input static fields", err.Error())
+		return
+	}
 	// Reconcile extractors: delete all existing and recreate from desired list
-	// (simple strategy to keep provider logic maintainable)
-	if exExisting, err := r.client.WithContext(ctx).ListInputExtractors(data.ID.ValueString()); err == nil {
-		for _, ex := range exExisting {
-			if id, ok := ex["id"].(string); ok && id != "" {
-				if derr := r.client.WithContext(ctx).DeleteInputExtractor(data.ID.ValueString(), id); derr != nil {
-					resp.Diagnostics.AddWarning("Failed to delete existing extractor", derr.Error())
+	// (simple strategy to keep provider logic maintainable). Skipped when the attribute
+	// was never used, so extractors managed by graylog_input_extractor are left alone.
+	if !data.Extractors.IsNull() || !state.Extractors.IsNull() {
+		if exExisting, err := r.client.WithContext(ctx).ListInputExtractors(data.ID.ValueString()); err == nil {
+			for _, ex := range exExisting {
+				if id, ok := ex["id"].(string); ok && id != "" {
+					if derr := r.client.WithContext(ctx).DeleteInputExtractor(data.ID.ValueString(), id); derr != nil {
+						resp.Diagnostics.AddWarning("Failed to delete existing extractor", derr.Error())
+					}
 				}
 			}
 		}
@@ -274,18 +488,140 @@ func (r *inputResource) Update(ctx context.Context, req resource.UpdateRequest,
 		if canon, err := CanonicalizeJSONValue(toAnySlice(extractorObjs)); err == nil {
 			data.Extractors = types.StringValue(canon)
 		}
-		for _, ex := range extractorObjs {
-			payload, ok := ex["data"].(map[string]interface{})
-			if !ok || payload == nil {
-				payload = ex
+		if cerr := createInputExtractors(r.client.WithContext(ctx), data.ID.ValueString(), extractorObjs); cerr != nil {
+			resp.Diagnostics.AddError("Error creating input extractor", cerr.Error())
+			return
+		}
+	}
+	resp.Diagnostics.Append(r.applyDesiredState(ctx, &data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// applyDesiredState starts or stops the input according to desired_state and records node states.
+// An unset desired_state is filled with the observed state instead of being enforced.
+func (r *inputResource) applyDesiredState(ctx context.Context, data *inputModel) (d diag.Diagnostics) {
+	c := r.client.WithContext(ctx)
+	id := data.ID.ValueString()
+	states, err := c.ListClusterInputStates()
+	if err != nil {
+		d.AddWarning("Unable to read input states", err.Error())
+		states = map[string][]client.InputState{}
+	}
+	nodeStates := inputNodeStates(states, id)
+	observed := observedInputState(nodeStates)
+
+	if !data.DesiredState.IsNull() && !data.DesiredState.IsUnknown() {
+		desired := data.DesiredState.ValueString()
+		if desired != observed {
+			switch desired {
+			case "stopped":
+				err = c.StopInput(id)
+			case "running":
+				err = c.StartInput(id)
 			}
-			if _, cerr := r.client.WithContext(ctx).CreateInputExtractor(data.ID.ValueString(), payload); cerr != nil {
-				resp.Diagnostics.AddError("Error creating input extractor", cerr.Error())
+			if err != nil && !errors.Is(err, client.ErrNotFound) {
+				d.AddAttributeError(path.Root("desired_state"), "Error changing input state", err.Error())
 				return
 			}
+			if states, err = c.ListClusterInputStates(); err == nil {
+				nodeStates = inputNodeStates(states, id)
+			}
 		}
+	} else {
+		data.DesiredState = types.StringValue(observed)
 	}
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	d.Append(setInputNodeStates(ctx, data, nodeStates)...)
+	return
+}
+
+// inputNodeStates extracts the state of one input from per-node state lists.
+func inputNodeStates(states map[string][]client.InputState, inputID string) map[string]string {
+	out := map[string]string{}
+	for node, list := range states {
+		for _, st := range list {
+			if st.InputID() == inputID {
+				out[node] = strings.ToUpper(st.State)
+			}
+		}
+	}
+	return out
+}
+
+// observedInputState folds node states into running | failed | stopped.
+func observedInputState(nodeStates map[string]string) string {
+	failed := false
+	for _, st := range nodeStates {
+		switch st {
+		case "RUNNING", "STARTING", "CREATED", "INITIALIZED":
+			return "running"
+		case "FAILED", "FAILING":
+			failed = true
+		}
+	}
+	if failed {
+		return "failed"
+	}
+	return "stopped"
+}
+
+func setInputNodeStates(ctx context.Context, data *inputModel, nodeStates map[string]string) diag.Diagnostics {
+	m, diags := types.MapValueFrom(ctx, types.StringType, nodeStates)
+	data.NodeStates = m
+	return diags
+}
+
+// createInputExtractors creates extractors from the JSON list (top-level maps or nested under "data")
+// and then fixes their execution order to match the list.
+func createInputExtractors(c *client.Client, inputID string, extractorObjs []map[string]interface{}) error {
+	ids := make([]string, 0, len(extractorObjs))
+	for _, ex := range extractorObjs {
+		payload, ok := ex["data"].(map[string]interface{})
+		if !ok || payload == nil {
+			payload = ex
+		}
+		created, err := c.CreateInputExtractor(inputID, payload)
+		if err != nil {
+			return err
+		}
+		if id := firstNonEmpty(toString(created["extractor_id"]), toString(created["id"])); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) < 2 {
+		return nil
+	}
+	return c.OrderInputExtractors(inputID, ids)
+}
+
+// syncInputStaticFields makes the input's static fields equal to desired: stale keys are removed,
+// new or changed ones are (re)added.
+func syncInputStaticFields(c *client.Client, inputID string, current map[string]string, desired map[string]types.String) error {
+	for k, v := range current {
+		if d, ok := desired[k]; ok && d.ValueString() == v {
+			continue
+		}
+		if err := c.DeleteInputStaticField(inputID, k); err != nil && !errors.Is(err, client.ErrNotFound) {
+			return err
+		}
+	}
+	for _, k := range sortedStaticFieldKeys(desired) {
+		if v, ok := current[k]; ok && v == desired[k].ValueString() {
+			continue
+		}
+		if err := c.AddInputStaticField(inputID, k, desired[k].ValueString()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortedStaticFieldKeys(m map[string]types.String) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // toAnySlice converts []map[string]interface{} to []interface{} for canonical serialization
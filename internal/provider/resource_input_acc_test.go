@@ -41,3 +41,111 @@ resource "graylog_input" "syslog_udp" {
 		},
 	})
 }
+
+func TestAccInput_staticFields(t *testing.T) {
+	cfg := func(fields string) string {
+		return testAccProviderConfig() + `
+resource "graylog_input" "sf" {
+  title  = "acc-static-fields"
+  type   = "org.graylog2.inputs.gelf.udp.GELFUDPInput"
+  global = true
+
+  configuration = jsonencode({
+    bind_address = "0.0.0.0"
+    port         = 12299
+  })
+
+  static_fields = ` + fields + `
+}
+`
+	}
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ExpectNonEmptyPlan: true,
+				Config:             cfg(`{ env = "prod", team = "sre" }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_input.sf", "static_fields.%", "2"),
+					resource.TestCheckResourceAttr("graylog_input.sf", "static_fields.env", "prod"),
+				),
+			},
+			{
+				ExpectNonEmptyPlan: true,
+				Config:             cfg(`{ env = "stage" }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_input.sf", "static_fields.%", "1"),
+					resource.TestCheckResourceAttr("graylog_input.sf", "static_fields.env", "stage"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInput_desiredState(t *testing.T) {
+	cfg := func(state string) string {
+		return testAccProviderConfig() + `
+resource "graylog_input" "ds" {
+  title  = "acc-desired-state"
+  type   = "org.graylog2.inputs.syslog.udp.SyslogUDPInput"
+  global = true
+
+  configuration = jsonencode({
+    bind_address = "0.0.0.0"
+    port         = 1598
+  })
+
+  desired_state = "` + state + `"
+}
+`
+	}
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ExpectNonEmptyPlan: true,
+				Config:             cfg("stopped"),
+				Check:              resource.TestCheckResourceAttr("graylog_input.ds", "desired_state", "stopped"),
+			},
+			{
+				ExpectNonEmptyPlan: true,
+				Config:             cfg("running"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_input.ds", "desired_state", "running"),
+					resource.TestCheckResourceAttrSet("graylog_input.ds", "node_states.%"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInput_typedSyslogTCP(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + `
+resource "graylog_input" "typed" {
+  title  = "acc-typed-syslog-tcp"
+  global = true
+
+  syslog_tcp {
+    port               = 1601
+    store_full_message = true
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_input.typed", "type", "org.graylog2.inputs.syslog.tcp.SyslogTCPInput"),
+					resource.TestCheckResourceAttr("graylog_input.typed", "syslog_tcp.port", "1601"),
+					resource.TestCheckResourceAttr("graylog_input.typed", "syslog_tcp.bind_address", "0.0.0.0"),
+					resource.TestCheckResourceAttr("graylog_input.typed", "syslog_tcp.tls_client_auth", "disabled"),
+					resource.TestCheckNoResourceAttr("graylog_input.typed", "configuration"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,397 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type inputExtractorResource struct{ client *client.Client }
+
+type inputExtractorModel struct {
+	ID              types.String                   `tfsdk:"id"`
+	InputID         types.String                   `tfsdk:"input_id"`
+	Title           types.String                   `tfsdk:"title"`
+	Type            types.String                   `tfsdk:"type"`
+	SourceField     types.String                   `tfsdk:"source_field"`
+	TargetField     types.String                   `tfsdk:"target_field"`
+	CursorStrategy  types.String                   `tfsdk:"cursor_strategy"`
+	ConditionType   types.String                   `tfsdk:"condition_type"`
+	ConditionValue  types.String                   `tfsdk:"condition_value"`
+	ExtractorConfig types.String                   `tfsdk:"extractor_config"`
+	Order           types.Int64                    `tfsdk:"order"`
+	Converters      []inputExtractorConverterModel `tfsdk:"converter"`
+	Timeouts        timeouts.Value                 `tfsdk:"timeouts"`
+}
+
+type inputExtractorConverterModel struct {
+	Type   types.String `tfsdk:"type"`
+	Config types.String `tfsdk:"config"`
+}
+
+var inputExtractorTypes = []string{"copy_input", "grok", "json", "regex", "regex_replace", "split_and_index", "substring", "lookup_table"}
+
+func NewInputExtractorResource() resource.Resource { return &inputExtractorResource{} }
+
+func (r *inputExtractorResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "graylog_input_extractor"
+}
+
+func (r *inputExtractorResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a single extractor of a Graylog input. Changes are applied in place, so the extractor ID and its position are preserved. Do not combine with the `extractors` attribute of graylog_input for the same input.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: "Extractor ID"},
+			"input_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "ID of the input the extractor belongs to",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"title": schema.StringAttribute{Required: true, Description: "Extractor title"},
+			"type": schema.StringAttribute{
+				Required:    true,
+				Description: "Extractor type: copy_input | grok | json | regex | regex_replace | split_and_index | substring | lookup_table",
+				Validators:  []validator.String{stringvalidator.OneOf(inputExtractorTypes...)},
+			},
+			"source_field": schema.StringAttribute{Required: true, Description: "Message field to extract from"},
+			"target_field": schema.StringAttribute{Optional: true, Computed: true, Description: "Field to write the result to (not used by grok/json extractors)"},
+			"cursor_strategy": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "copy | cut (default copy)",
+				Validators:  []validator.String{stringvalidator.OneOf("copy", "cut")},
+			},
+			"condition_type": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Run the extractor only if the source field matches: none | string | regex (default none)",
+				Validators:  []validator.String{stringvalidator.OneOf("none", "string", "regex")},
+			},
+			"condition_value": schema.StringAttribute{Optional: true, Computed: true, Description: "Substring or regular expression for condition_type"},
+			"extractor_config": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "JSON-encoded type-specific configuration, e.g. {\"grok_pattern\":\"%{IP:client}\"} or {\"regex_value\":\"^(\\\\w+)\"}",
+			},
+			"order":    schema.Int64Attribute{Optional: true, Computed: true, Description: "Execution order among the input's extractors"},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+		},
+		Blocks: map[string]schema.Block{
+			"converter": schema.ListNestedBlock{
+				Description: "Converters applied to the extracted value",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"type":   schema.StringAttribute{Required: true, Description: "Converter type (e.g. numeric, date, hash, lowercase, split_and_count, csv, tokenizer, lookup_table)"},
+						"config": schema.StringAttribute{Optional: true, Computed: true, Description: "JSON-encoded converter configuration"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *inputExtractorResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*client.Client)
+}
+
+func (r *inputExtractorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data inputExtractorModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload, diags := buildInputExtractorPayload(&data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	inputID := data.InputID.ValueString()
+	if _, ok := payload["order"]; !ok {
+		// Append after the existing extractors instead of Graylog's default position 0
+		if list, err := r.client.WithContext(ctx).ListInputExtractors(inputID); err == nil {
+			payload["order"] = len(list)
+		}
+	}
+	created, err := r.client.WithContext(ctx).CreateInputExtractor(inputID, payload)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating input extractor", err.Error())
+		return
+	}
+	id := firstNonEmpty(toString(created["extractor_id"]), toString(created["id"]))
+	if id == "" {
+		resp.Diagnostics.AddError("Error creating input extractor", "Graylog did not return an extractor ID.")
+		return
+	}
+	data.ID = types.StringValue(id)
+
+	ex, err := r.client.WithContext(ctx).GetInputExtractor(inputID, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading created input extractor", err.Error())
+		return
+	}
+	applyInputExtractor(&data, ex)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *inputExtractorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data inputExtractorModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ex, err := r.client.WithContext(ctx).GetInputExtractor(data.InputID.ValueString(), data.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading input extractor", err.Error())
+		return
+	}
+	applyInputExtractor(&data, ex)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *inputExtractorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state inputExtractorModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = state.ID
+	// Keep the current position unless order is set explicitly
+	if data.Order.IsUnknown() {
+		data.Order = state.Order
+	}
+
+	payload, diags := buildInputExtractorPayload(&data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	inputID := data.InputID.ValueString()
+	if _, err := r.client.WithContext(ctx).UpdateInputExtractor(inputID, data.ID.ValueString(), payload); err != nil {
+		resp.Diagnostics.AddError("Error updating input extractor", err.Error())
+		return
+	}
+	ex, err := r.client.WithContext(ctx).GetInputExtractor(inputID, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading updated input extractor", err.Error())
+		return
+	}
+	applyInputExtractor(&data, ex)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *inputExtractorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data inputExtractorModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, 3*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if err := r.client.WithContext(ctx).DeleteInputExtractor(data.InputID.ValueString(), data.ID.ValueString()); err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return
+		}
+		resp.Diagnostics.AddError("Error deleting input extractor", err.Error())
+	}
+}
+
+// ImportState accepts "<input_id>/<extractor_id>".
+func (r *inputExtractorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(strings.TrimSpace(req.ID), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError("Invalid import ID", "Expected '<input_id>/<extractor_id>'.")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("input_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
+
+// buildInputExtractorPayload converts the model into Graylog's create/update extractor request.
+func buildInputExtractorPayload(m *inputExtractorModel) (map[string]interface{}, diag.Diagnostics) {
+	var d diag.Diagnostics
+	cfg := map[string]interface{}{}
+	if !m.ExtractorConfig.IsNull() && !m.ExtractorConfig.IsUnknown() && strings.TrimSpace(m.ExtractorConfig.ValueString()) != "" {
+		if err := json.Unmarshal([]byte(m.ExtractorConfig.ValueString()), &cfg); err != nil {
+			d.AddAttributeError(path.Root("extractor_config"), "Invalid extractor_config JSON", err.Error())
+		}
+	}
+
+	// Graylog expects converters as a map: type => config
+	converters := map[string]interface{}{}
+	for i, cv := range m.Converters {
+		t := cv.Type.ValueString()
+		if _, dup := converters[t]; dup {
+			d.AddAttributeError(path.Root("converter").AtListIndex(i).AtName("type"), "Duplicate converter", "Converter type '"+t+"' is listed more than once.")
+			continue
+		}
+		cc := map[string]interface{}{}
+		if !cv.Config.IsNull() && !cv.Config.IsUnknown() && strings.TrimSpace(cv.Config.ValueString()) != "" {
+			if err := json.Unmarshal([]byte(cv.Config.ValueString()), &cc); err != nil {
+				d.AddAttributeError(path.Root("converter").AtListIndex(i).AtName("config"), "Invalid converter config JSON", err.Error())
+			}
+		}
+		converters[t] = cc
+	}
+
+	condType := stringOrDefault(m.ConditionType, "none")
+	condValue := stringOrDefault(m.ConditionValue, "")
+	if condType != "none" && condValue == "" {
+		d.AddAttributeError(path.Root("condition_value"), "Missing condition_value", "Attribute 'condition_value' is required when condition_type is '"+condType+"'.")
+	}
+	if condType == "regex" && condValue != "" {
+		if _, err := regexp.Compile(condValue); err != nil {
+			d.AddAttributeError(path.Root("condition_value"), "Invalid regular expression", err.Error())
+		}
+	}
+	if d.HasError() {
+		return nil, d
+	}
+
+	payload := map[string]interface{}{
+		"title":            m.Title.ValueString(),
+		"extractor_type":   m.Type.ValueString(),
+		"cut_or_copy":      stringOrDefault(m.CursorStrategy, "copy"),
+		"source_field":     m.SourceField.ValueString(),
+		"target_field":     stringOrDefault(m.TargetField, ""),
+		"extractor_config": cfg,
+		"converters":       converters,
+		"condition_type":   condType,
+		"condition_value":  condValue,
+	}
+	if !m.Order.IsNull() && !m.Order.IsUnknown() {
+		payload["order"] = m.Order.ValueInt64()
+	}
+	return payload, d
+}
+
+// applyInputExtractor stores the server representation of an extractor in the model.
+func applyInputExtractor(m *inputExtractorModel, ex map[string]interface{}) {
+	m.Title = cfgString(ex, "title")
+	m.Type = types.StringValue(strings.ToLower(toString(ex["type"])))
+	m.SourceField = cfgString(ex, "source_field")
+	m.TargetField = types.StringValue(toString(ex["target_field"]))
+	m.CursorStrategy = types.StringValue(strings.ToLower(firstNonEmpty(toString(ex["cursor_strategy"]), "copy")))
+	m.ConditionType = types.StringValue(strings.ToLower(firstNonEmpty(toString(ex["condition_type"]), "none")))
+	m.ConditionValue = types.StringValue(toString(ex["condition_value"]))
+	m.Order = cfgInt64(ex, "order")
+	if m.Order.IsNull() {
+		m.Order = types.Int64Value(0)
+	}
+
+	cfg, _ := ex["extractor_config"].(map[string]interface{})
+	if cfg == nil {
+		cfg = map[string]interface{}{}
+	}
+	m.ExtractorConfig = jsonStateValue(m.ExtractorConfig, cfg)
+
+	var serverConverters []map[string]interface{}
+	if list, ok := ex["converters"].([]interface{}); ok {
+		for _, item := range list {
+			if cm, ok := item.(map[string]interface{}); ok {
+				serverConverters = append(serverConverters, cm)
+			}
+		}
+	}
+	// Graylog may report converter types in a different case; keep the configured spelling
+	prior := make(map[string]inputExtractorConverterModel, len(m.Converters))
+	order := make(map[string]int, len(m.Converters))
+	for i, cv := range m.Converters {
+		prior[strings.ToLower(cv.Type.ValueString())] = cv
+		order[strings.ToLower(cv.Type.ValueString())] = i
+	}
+	var converters []inputExtractorConverterModel
+	for _, cm := range serverConverters {
+		t := toString(cm["type"])
+		cc, _ := cm["config"].(map[string]interface{})
+		if cc == nil {
+			cc = map[string]interface{}{}
+		}
+		cv := inputExtractorConverterModel{Type: types.StringValue(t), Config: types.StringUnknown()}
+		if p, ok := prior[strings.ToLower(t)]; ok {
+			cv.Type = p.Type
+			cv.Config = p.Config
+		}
+		cv.Config = jsonStateValue(cv.Config, cc)
+		converters = append(converters, cv)
+	}
+	// Graylog stores converters as a map; follow the configured order, unknown types go last by name
+	sort.SliceStable(converters, func(i, j int) bool {
+		ti, tj := strings.ToLower(converters[i].Type.ValueString()), strings.ToLower(converters[j].Type.ValueString())
+		oi, iok := order[ti]
+		oj, jok := order[tj]
+		switch {
+		case iok && jok:
+			return oi < oj
+		case iok != jok:
+			return iok
+		}
+		return ti < tj
+	})
+	m.Converters = converters
+}
+
+// jsonStateValue renders server JSON for state: keys managed in prior are kept, otherwise the full
+// canonical server object is stored.
+func jsonStateValue(prior types.String, server map[string]interface{}) types.String {
+	if !prior.IsNull() && !prior.IsUnknown() && strings.TrimSpace(prior.ValueString()) != "" {
+		if s, err := normalizeJSONForState(prior.ValueString(), server); err == nil {
+			return types.StringValue(s)
+		}
+	}
+	s, err := CanonicalizeJSONValue(server)
+	if err != nil {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
@@ -0,0 +1,84 @@
+//go:build acceptance
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func testAccInputExtractorConfig(regex string) string {
+	return testAccProviderConfig() + `
+resource "graylog_input" "raw" {
+  title  = "acc-extractor-input"
+  type   = "org.graylog2.inputs.raw.udp.RawUDPInput"
+  global = true
+  configuration = jsonencode({
+    bind_address = "0.0.0.0"
+    port         = 5599
+  })
+}
+
+resource "graylog_input_extractor" "status" {
+  input_id         = graylog_input.raw.id
+  title            = "status"
+  type             = "regex"
+  source_field     = "message"
+  target_field     = "status"
+  extractor_config = jsonencode({ regex_value = "` + regex + `" })
+
+  converter {
+    type = "numeric"
+  }
+}
+`
+}
+
+func TestAccInputExtractor_updateInPlace(t *testing.T) {
+	var firstID string
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ExpectNonEmptyPlan: true,
+				Config:             testAccInputExtractorConfig(`status=(\\d+)`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("graylog_input_extractor.status", "id"),
+					resource.TestCheckResourceAttr("graylog_input_extractor.status", "cursor_strategy", "copy"),
+					resource.TestCheckResourceAttr("graylog_input_extractor.status", "converter.0.type", "numeric"),
+					resource.TestCheckResourceAttrWith("graylog_input_extractor.status", "id", func(v string) error {
+						firstID = v
+						return nil
+					}),
+				),
+			},
+			{
+				ExpectNonEmptyPlan: true,
+				Config:             testAccInputExtractorConfig(`code=(\\d+)`),
+				Check: resource.TestCheckResourceAttrWith("graylog_input_extractor.status", "id", func(v string) error {
+					if v != firstID {
+						return fmt.Errorf("extractor was recreated: %s != %s", v, firstID)
+					}
+					return nil
+				}),
+			},
+			{
+				ResourceName: "graylog_input_extractor.status",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["graylog_input_extractor.status"]
+					if !ok {
+						return "", fmt.Errorf("extractor resource not found in state")
+					}
+					return rs.Primary.Attributes["input_id"] + "/" + rs.Primary.ID, nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+		},
+	})
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestInputExtractorResource_New(t *testing.T) {
+	if NewInputExtractorResource() == nil {
+		t.Fatal("expected non-nil resource")
+	}
+}
+
+func TestBuildInputExtractorPayload(t *testing.T) {
+	m := &inputExtractorModel{
+		Title:           types.StringValue("status"),
+		Type:            types.StringValue("regex"),
+		SourceField:     types.StringValue("message"),
+		TargetField:     types.StringValue("status"),
+		CursorStrategy:  types.StringUnknown(),
+		ConditionType:   types.StringValue("string"),
+		ConditionValue:  types.StringValue("HTTP"),
+		ExtractorConfig: types.StringValue(`{"regex_value":"status=(\\d+)"}`),
+		Order:           types.Int64Value(2),
+		Converters: []inputExtractorConverterModel{
+			{Type: types.StringValue("numeric"), Config: types.StringNull()},
+		},
+	}
+	p, diags := buildInputExtractorPayload(m)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if p["extractor_type"] != "regex" || p["cut_or_copy"] != "copy" || p["order"] != int64(2) {
+		t.Fatalf("unexpected payload: %+v", p)
+	}
+	conv, _ := p["converters"].(map[string]interface{})
+	if _, ok := conv["numeric"]; !ok {
+		t.Fatalf("expected converters keyed by type, got %+v", p["converters"])
+	}
+	cfg, _ := p["extractor_config"].(map[string]interface{})
+	if cfg["regex_value"] != `status=(\d+)` {
+		t.Fatalf("unexpected extractor_config: %+v", cfg)
+	}
+}
+
+func TestBuildInputExtractorPayload_Invalid(t *testing.T) {
+	m := &inputExtractorModel{
+		Title:           types.StringValue("x"),
+		Type:            types.StringValue("grok"),
+		SourceField:     types.StringValue("message"),
+		ConditionType:   types.StringValue("regex"),
+		ConditionValue:  types.StringValue("("),
+		ExtractorConfig: types.StringValue(`{not json`),
+		Converters: []inputExtractorConverterModel{
+			{Type: types.StringValue("date")},
+			{Type: types.StringValue("date")},
+		},
+	}
+	_, diags := buildInputExtractorPayload(m)
+	if diags.ErrorsCount() != 3 {
+		t.Fatalf("expected 3 errors (config JSON, duplicate converter, regex), got %d: %v", diags.ErrorsCount(), diags)
+	}
+}
+
+func TestApplyInputExtractor_KeepsManagedKeys(t *testing.T) {
+	m := &inputExtractorModel{
+		ExtractorConfig: types.StringValue(`{"grok_pattern":"%{IP:client}"}`),
+		Converters: []inputExtractorConverterModel{
+			{Type: types.StringValue("NUMERIC"), Config: types.StringNull()},
+		},
+	}
+	applyInputExtractor(m, map[string]interface{}{
+		"title":            "ip",
+		"type":             "GROK",
+		"source_field":     "message",
+		"cursor_strategy":  "copy",
+		"condition_type":   "none",
+		"order":            float64(3),
+		"extractor_config": map[string]interface{}{"grok_pattern": "%{IP:client}", "named_captures_only": true},
+		"converters":       []interface{}{map[string]interface{}{"type": "numeric", "config": map[string]interface{}{}}},
+	})
+	if m.ExtractorConfig.ValueString() != `{"grok_pattern":"%{IP:client}"}` {
+		t.Fatalf("expected server defaults to be dropped, got %s", m.ExtractorConfig.ValueString())
+	}
+	if m.Type.ValueString() != "grok" || m.Order.ValueInt64() != 3 || m.TargetField.ValueString() != "" {
+		t.Fatalf("unexpected model: %+v", m)
+	}
+	if len(m.Converters) != 1 || m.Converters[0].Type.ValueString() != "NUMERIC" || m.Converters[0].Config.ValueString() != "{}" {
+		t.Fatalf("unexpected converters: %+v", m.Converters)
+	}
+}
+
+func TestApplyInputExtractor_ConvertersFollowConfiguredOrder(t *testing.T) {
+	m := &inputExtractorModel{
+		ExtractorConfig: types.StringNull(),
+		Converters: []inputExtractorConverterModel{
+			{Type: types.StringValue("lowercase"), Config: types.StringNull()},
+			{Type: types.StringValue("hash"), Config: types.StringNull()},
+		},
+	}
+	applyInputExtractor(m, map[string]interface{}{
+		"type": "regex",
+		"converters": []interface{}{
+			map[string]interface{}{"type": "numeric"},
+			map[string]interface{}{"type": "hash"},
+			map[string]interface{}{"type": "lowercase"},
+		},
+	})
+	var got []string
+	for _, cv := range m.Converters {
+		got = append(got, cv.Type.ValueString())
+	}
+	if strings.Join(got, ",") != "lowercase,hash,numeric" {
+		t.Fatalf("unexpected converter order: %v", got)
+	}
+}
@@ -1,7 +1,11 @@
 package provider
 
 import (
+	"context"
 	"testing"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func TestInputResource_New(t *testing.T) {
@@ -10,3 +14,40 @@ func TestInputResource_New(t *testing.T) {
 		t.Fatal("expected non-nil resource")
 	}
 }
+
+func TestValidateInput_StaticFieldNames(t *testing.T) {
+	data := &inputModel{
+		Title: types.StringValue("syslog"),
+		Type:  types.StringValue("org.graylog2.inputs.syslog.udp.SyslogUDPInput"),
+		StaticFields: map[string]types.String{
+			"env":        types.StringValue("prod"),
+			"team.owner": types.StringValue("sre"),
+			"bad key":    types.StringValue("x"),
+		},
+	}
+	diags := validateInput(context.Background(), data, nil)
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected exactly one error for 'bad key', got %d: %v", diags.ErrorsCount(), diags)
+	}
+}
+
+func TestObservedInputState(t *testing.T) {
+	states := map[string][]client.InputState{
+		"node-a": {{ID: "in1", State: "failed"}, {ID: "other", State: "RUNNING"}},
+		"node-b": {{ID: "in1", State: "FAILED"}},
+	}
+	nodeStates := inputNodeStates(states, "in1")
+	if len(nodeStates) != 2 || nodeStates["node-a"] != "FAILED" {
+		t.Fatalf("unexpected node states: %v", nodeStates)
+	}
+	if got := observedInputState(nodeStates); got != "failed" {
+		t.Fatalf("expected failed, got %s", got)
+	}
+	nodeStates["node-b"] = "RUNNING"
+	if got := observedInputState(nodeStates); got != "running" {
+		t.Fatalf("expected running when any node runs the input, got %s", got)
+	}
+	if got := observedInputState(map[string]string{}); got != "stopped" {
+		t.Fatalf("expected stopped without node states, got %s", got)
+	}
+}
@@ -0,0 +1,580 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Typed configuration blocks for common input types. Attribute names match Graylog configuration keys;
+// each block maps to one input class and is mutually exclusive with the JSON `configuration`.
+
+const (
+	inputClassSyslogUDP = "org.graylog2.inputs.syslog.udp.SyslogUDPInput"
+	inputClassSyslogTCP = "org.graylog2.inputs.syslog.tcp.SyslogTCPInput"
+	inputClassGELFUDP   = "org.graylog2.inputs.gelf.udp.GELFUDPInput"
+	inputClassGELFTCP   = "org.graylog2.inputs.gelf.tcp.GELFTCPInput"
+	inputClassGELFHTTP  = "org.graylog2.inputs.gelf.http.GELFHttpInput"
+	inputClassBeats     = "org.graylog.plugins.beats.Beats2Input"
+	inputClassRawTCP    = "org.graylog2.inputs.raw.tcp.RawTCPInput"
+)
+
+// inputKafkaClasses maps kafka.format to the input class.
+var inputKafkaClasses = map[string]string{
+	"raw":    "org.graylog2.inputs.raw.kafka.RawKafkaInput",
+	"gelf":   "org.graylog2.inputs.gelf.kafka.GELFKafkaInput",
+	"syslog": "org.graylog2.inputs.syslog.kafka.SyslogKafkaInput",
+}
+
+var typedInputBlockNames = []string{"syslog_udp", "syslog_tcp", "gelf_udp", "gelf_tcp", "gelf_http", "beats", "raw_tcp", "kafka"}
+
+const (
+	udpRecvBufferDefault = 262144
+	tcpRecvBufferDefault = 1048576
+)
+
+// typedInputBlock is implemented by every typed input block model.
+type typedInputBlock interface {
+	class() string
+	toConfig() map[string]interface{}
+	fromConfig(cfg map[string]interface{})
+}
+
+// ---- shared parts ----
+
+type inputNetworkModel struct {
+	BindAddress         types.String `tfsdk:"bind_address"`
+	Port                types.Int64  `tfsdk:"port"`
+	RecvBufferSize      types.Int64  `tfsdk:"recv_buffer_size"`
+	NumberWorkerThreads types.Int64  `tfsdk:"number_worker_threads"`
+	OverrideSource      types.String `tfsdk:"override_source"`
+}
+
+type inputTLSModel struct {
+	TLSEnable             types.Bool   `tfsdk:"tls_enable"`
+	TLSCertFile           types.String `tfsdk:"tls_cert_file"`
+	TLSKeyFile            types.String `tfsdk:"tls_key_file"`
+	TLSKeyPassword        types.String `tfsdk:"tls_key_password"`
+	TLSClientAuth         types.String `tfsdk:"tls_client_auth"`
+	TLSClientAuthCertFile types.String `tfsdk:"tls_client_auth_cert_file"`
+	TCPKeepalive          types.Bool   `tfsdk:"tcp_keepalive"`
+}
+
+// inputFramingModel covers delimiter-based TCP transports (syslog/gelf/raw TCP)
+type inputFramingModel struct {
+	UseNullDelimiter types.Bool  `tfsdk:"use_null_delimiter"`
+	MaxMessageSize   types.Int64 `tfsdk:"max_message_size"`
+}
+
+type inputSyslogModel struct {
+	ForceRDNS            types.Bool   `tfsdk:"force_rdns"`
+	AllowOverrideDate    types.Bool   `tfsdk:"allow_override_date"`
+	StoreFullMessage     types.Bool   `tfsdk:"store_full_message"`
+	ExpandStructuredData types.Bool   `tfsdk:"expand_structured_data"`
+	Timezone             types.String `tfsdk:"timezone"`
+}
+
+type inputGELFModel struct {
+	DecompressSizeLimit types.Int64 `tfsdk:"decompress_size_limit"`
+}
+
+func (m *inputNetworkModel) put(cfg map[string]interface{}, recvDefault int64) {
+	cfg["bind_address"] = stringOrDefault(m.BindAddress, "0.0.0.0")
+	cfg["port"] = m.Port.ValueInt64()
+	cfg["recv_buffer_size"] = int64OrDefault(m.RecvBufferSize, recvDefault)
+	putInt64IfSet(cfg, "number_worker_threads", m.NumberWorkerThreads)
+	putStringIfSet(cfg, "override_source", m.OverrideSource)
+}
+
+func (m *inputNetworkModel) read(cfg map[string]interface{}) {
+	m.BindAddress = cfgString(cfg, "bind_address")
+	m.Port = cfgInt64(cfg, "port")
+	m.RecvBufferSize = cfgInt64(cfg, "recv_buffer_size")
+	m.NumberWorkerThreads = cfgInt64(cfg, "number_worker_threads")
+	m.OverrideSource = cfgString(cfg, "override_source")
+}
+
+func (m *inputTLSModel) put(cfg map[string]interface{}) {
+	cfg["tls_enable"] = boolOrDefault(m.TLSEnable, false)
+	cfg["tls_client_auth"] = stringOrDefault(m.TLSClientAuth, "disabled")
+	cfg["tcp_keepalive"] = boolOrDefault(m.TCPKeepalive, false)
+	putStringIfSet(cfg, "tls_cert_file", m.TLSCertFile)
+	putStringIfSet(cfg, "tls_key_file", m.TLSKeyFile)
+	putStringIfSet(cfg, "tls_key_password", m.TLSKeyPassword)
+	putStringIfSet(cfg, "tls_client_auth_cert_file", m.TLSClientAuthCertFile)
+}
+
+func (m *inputTLSModel) read(cfg map[string]interface{}) {
+	m.TLSEnable = cfgBool(cfg, "tls_enable")
+	m.TLSCertFile = cfgString(cfg, "tls_cert_file")
+	m.TLSKeyFile = cfgString(cfg, "tls_key_file")
+	// tls_key_password is write-only (Graylog masks it), keep the configured value
+	m.TLSClientAuth = cfgString(cfg, "tls_client_auth")
+	m.TLSClientAuthCertFile = cfgString(cfg, "tls_client_auth_cert_file")
+	m.TCPKeepalive = cfgBool(cfg, "tcp_keepalive")
+}
+
+func (m *inputFramingModel) put(cfg map[string]interface{}, nullDelimiterDefault bool) {
+	cfg["use_null_delimiter"] = boolOrDefault(m.UseNullDelimiter, nullDelimiterDefault)
+	cfg["max_message_size"] = int64OrDefault(m.MaxMessageSize, 2097152)
+}
+
+func (m *inputFramingModel) read(cfg map[string]interface{}) {
+	m.UseNullDelimiter = cfgBool(cfg, "use_null_delimiter")
+	m.MaxMessageSize = cfgInt64(cfg, "max_message_size")
+}
+
+func (m *inputSyslogModel) put(cfg map[string]interface{}) {
+	cfg["force_rdns"] = boolOrDefault(m.ForceRDNS, false)
+	cfg["allow_override_date"] = boolOrDefault(m.AllowOverrideDate, true)
+	cfg["store_full_message"] = boolOrDefault(m.StoreFullMessage, false)
+	cfg["expand_structured_data"] = boolOrDefault(m.ExpandStructuredData, false)
+	putStringIfSet(cfg, "timezone", m.Timezone)
+}
+
+func (m *inputSyslogModel) read(cfg map[string]interface{}) {
+	m.ForceRDNS = cfgBool(cfg, "force_rdns")
+	m.AllowOverrideDate = cfgBool(cfg, "allow_override_date")
+	m.StoreFullMessage = cfgBool(cfg, "store_full_message")
+	m.ExpandStructuredData = cfgBool(cfg, "expand_structured_data")
+	m.Timezone = cfgString(cfg, "timezone")
+}
+
+func (m *inputGELFModel) put(cfg map[string]interface{}) {
+	cfg["decompress_size_limit"] = int64OrDefault(m.DecompressSizeLimit, 8388608)
+}
+
+func (m *inputGELFModel) read(cfg map[string]interface{}) {
+	m.DecompressSizeLimit = cfgInt64(cfg, "decompress_size_limit")
+}
+
+// ---- blocks ----
+
+type inputSyslogUDPModel struct {
+	inputNetworkModel
+	inputSyslogModel
+}
+
+func (m *inputSyslogUDPModel) class() string { return inputClassSyslogUDP }
+func (m *inputSyslogUDPModel) toConfig() map[string]interface{} {
+	cfg := map[string]interface{}{}
+	m.inputNetworkModel.put(cfg, udpRecvBufferDefault)
+	m.inputSyslogModel.put(cfg)
+	return cfg
+}
+func (m *inputSyslogUDPModel) fromConfig(cfg map[string]interface{}) {
+	m.inputNetworkModel.read(cfg)
+	m.inputSyslogModel.read(cfg)
+}
+
+type inputSyslogTCPModel struct {
+	inputNetworkModel
+	inputTLSModel
+	inputFramingModel
+	inputSyslogModel
+}
+
+func (m *inputSyslogTCPModel) class() string { return inputClassSyslogTCP }
+func (m *inputSyslogTCPModel) toConfig() map[string]interface{} {
+	cfg := map[string]interface{}{}
+	m.inputNetworkModel.put(cfg, tcpRecvBufferDefault)
+	m.inputTLSModel.put(cfg)
+	m.inputFramingModel.put(cfg, false)
+	m.inputSyslogModel.put(cfg)
+	return cfg
+}
+func (m *inputSyslogTCPModel) fromConfig(cfg map[string]interface{}) {
+	m.inputNetworkModel.read(cfg)
+	m.inputTLSModel.read(cfg)
+	m.inputFramingModel.read(cfg)
+	m.inputSyslogModel.read(cfg)
+}
+
+type inputGELFUDPModel struct {
+	inputNetworkModel
+	inputGELFModel
+}
+
+func (m *inputGELFUDPModel) class() string { return inputClassGELFUDP }
+func (m *inputGELFUDPModel) toConfig() map[string]interface{} {
+	cfg := map[string]interface{}{}
+	m.inputNetworkModel.put(cfg, udpRecvBufferDefault)
+	m.inputGELFModel.put(cfg)
+	return cfg
+}
+func (m *inputGELFUDPModel) fromConfig(cfg map[string]interface{}) {
+	m.inputNetworkModel.read(cfg)
+	m.inputGELFModel.read(cfg)
+}
+
+type inputGELFTCPModel struct {
+	inputNetworkModel
+	inputTLSModel
+	inputFramingModel
+	inputGELFModel
+}
+
+func (m *inputGELFTCPModel) class() string { return inputClassGELFTCP }
+func (m *inputGELFTCPModel) toConfig() map[string]interface{} {
+	cfg := map[string]interface{}{}
+	m.inputNetworkModel.put(cfg, tcpRecvBufferDefault)
+	m.inputTLSModel.put(cfg)
+	// GELF over TCP is null-byte delimited
+	m.inputFramingModel.put(cfg, true)
+	m.inputGELFModel.put(cfg)
+	return cfg
+}
+func (m *inputGELFTCPModel) fromConfig(cfg map[string]interface{}) {
+	m.inputNetworkModel.read(cfg)
+	m.inputTLSModel.read(cfg)
+	m.inputFramingModel.read(cfg)
+	m.inputGELFModel.read(cfg)
+}
+
+type inputGELFHTTPModel struct {
+	inputNetworkModel
+	inputTLSModel
+	inputGELFModel
+	EnableCORS        types.Bool  `tfsdk:"enable_cors"`
+	IdleWriterTimeout types.Int64 `tfsdk:"idle_writer_timeout"`
+	MaxChunkSize      types.Int64 `tfsdk:"max_chunk_size"`
+}
+
+func (m *inputGELFHTTPModel) class() string { return inputClassGELFHTTP }
+func (m *inputGELFHTTPModel) toConfig() map[string]interface{} {
+	cfg := map[string]interface{}{}
+	m.inputNetworkModel.put(cfg, tcpRecvBufferDefault)
+	m.inputTLSModel.put(cfg)
+	m.inputGELFModel.put(cfg)
+	cfg["enable_cors"] = boolOrDefault(m.EnableCORS, true)
+	cfg["idle_writer_timeout"] = int64OrDefault(m.IdleWriterTimeout, 60)
+	cfg["max_chunk_size"] = int64OrDefault(m.MaxChunkSize, 65536)
+	return cfg
+}
+func (m *inputGELFHTTPModel) fromConfig(cfg map[string]interface{}) {
+	m.inputNetworkModel.read(cfg)
+	m.inputTLSModel.read(cfg)
+	m.inputGELFModel.read(cfg)
+	m.EnableCORS = cfgBool(cfg, "enable_cors")
+	m.IdleWriterTimeout = cfgInt64(cfg, "idle_writer_timeout")
+	m.MaxChunkSize = cfgInt64(cfg, "max_chunk_size")
+}
+
+type inputBeatsModel struct {
+	inputNetworkModel
+	inputTLSModel
+	NoBeatsPrefix types.Bool `tfsdk:"no_beats_prefix"`
+}
+
+func (m *inputBeatsModel) class() string { return inputClassBeats }
+func (m *inputBeatsModel) toConfig() map[string]interface{} {
+	cfg := map[string]interface{}{}
+	m.inputNetworkModel.put(cfg, tcpRecvBufferDefault)
+	m.inputTLSModel.put(cfg)
+	cfg["no_beats_prefix"] = boolOrDefault(m.NoBeatsPrefix, false)
+	return cfg
+}
+func (m *inputBeatsModel) fromConfig(cfg map[string]interface{}) {
+	m.inputNetworkModel.read(cfg)
+	m.inputTLSModel.read(cfg)
+	m.NoBeatsPrefix = cfgBool(cfg, "no_beats_prefix")
+}
+
+type inputRawTCPModel struct {
+	inputNetworkModel
+	inputTLSModel
+	inputFramingModel
+}
+
+func (m *inputRawTCPModel) class() string { return inputClassRawTCP }
+func (m *inputRawTCPModel) toConfig() map[string]interface{} {
+	cfg := map[string]interface{}{}
+	m.inputNetworkModel.put(cfg, tcpRecvBufferDefault)
+	m.inputTLSModel.put(cfg)
+	m.inputFramingModel.put(cfg, false)
+	return cfg
+}
+func (m *inputRawTCPModel) fromConfig(cfg map[string]interface{}) {
+	m.inputNetworkModel.read(cfg)
+	m.inputTLSModel.read(cfg)
+	m.inputFramingModel.read(cfg)
+}
+
+type inputKafkaModel struct {
+	Format           types.String `tfsdk:"format"`
+	BootstrapServer  types.String `tfsdk:"bootstrap_server"`
+	TopicFilter      types.String `tfsdk:"topic_filter"`
+	GroupID          types.String `tfsdk:"group_id"`
+	OffsetReset      types.String `tfsdk:"offset_reset"`
+	Threads          types.Int64  `tfsdk:"threads"`
+	FetchMinBytes    types.Int64  `tfsdk:"fetch_min_bytes"`
+	FetchWaitMax     types.Int64  `tfsdk:"fetch_wait_max"`
+	CustomProperties types.String `tfsdk:"custom_properties"`
+	OverrideSource   types.String `tfsdk:"override_source"`
+}
+
+func (m *inputKafkaModel) class() string {
+	return inputKafkaClasses[stringOrDefault(m.Format, "raw")]
+}
+func (m *inputKafkaModel) toConfig() map[string]interface{} {
+	cfg := map[string]interface{}{
+		"legacy_mode":       false,
+		"bootstrap_server":  m.BootstrapServer.ValueString(),
+		"topic_filter":      m.TopicFilter.ValueString(),
+		"group_id":          stringOrDefault(m.GroupID, "graylog2"),
+		"offset_reset":      stringOrDefault(m.OffsetReset, "largest"),
+		"threads":           int64OrDefault(m.Threads, 2),
+		"fetch_min_bytes":   int64OrDefault(m.FetchMinBytes, 5),
+		"fetch_wait_max":    int64OrDefault(m.FetchWaitMax, 100),
+		"custom_properties": stringOrDefault(m.CustomProperties, ""),
+	}
+	putStringIfSet(cfg, "override_source", m.OverrideSource)
+	return cfg
+}
+func (m *inputKafkaModel) fromConfig(cfg map[string]interface{}) {
+	m.Format = types.StringValue(stringOrDefault(m.Format, "raw"))
+	m.BootstrapServer = cfgString(cfg, "bootstrap_server")
+	m.TopicFilter = cfgString(cfg, "topic_filter")
+	m.GroupID = cfgString(cfg, "group_id")
+	m.OffsetReset = cfgString(cfg, "offset_reset")
+	m.Threads = cfgInt64(cfg, "threads")
+	m.FetchMinBytes = cfgInt64(cfg, "fetch_min_bytes")
+	m.FetchWaitMax = cfgInt64(cfg, "fetch_wait_max")
+	m.CustomProperties = cfgString(cfg, "custom_properties")
+	m.OverrideSource = cfgString(cfg, "override_source")
+}
+
+// typedBlock returns the configured typed block, or nil when `configuration` is used.
+func (m *inputModel) typedBlock() typedInputBlock {
+	switch {
+	case m.SyslogUDP != nil:
+		return m.SyslogUDP
+	case m.SyslogTCP != nil:
+		return m.SyslogTCP
+	case m.GELFUDP != nil:
+		return m.GELFUDP
+	case m.GELFTCP != nil:
+		return m.GELFTCP
+	case m.GELFHTTP != nil:
+		return m.GELFHTTP
+	case m.Beats != nil:
+		return m.Beats
+	case m.RawTCP != nil:
+		return m.RawTCP
+	case m.Kafka != nil:
+		return m.Kafka
+	}
+	return nil
+}
+
+// typedBlockPath returns the schema path of the configured typed block.
+func (m *inputModel) typedBlockPath() path.Path {
+	for name, set := range map[string]bool{
+		"syslog_udp": m.SyslogUDP != nil, "syslog_tcp": m.SyslogTCP != nil,
+		"gelf_udp": m.GELFUDP != nil, "gelf_tcp": m.GELFTCP != nil, "gelf_http": m.GELFHTTP != nil,
+		"beats": m.Beats != nil, "raw_tcp": m.RawTCP != nil, "kafka": m.Kafka != nil,
+	} {
+		if set {
+			return path.Root(name)
+		}
+	}
+	return path.Root("configuration")
+}
+
+// ---- helpers and schema ----
+
+func putStringIfSet(cfg map[string]interface{}, key string, v types.String) {
+	if !v.IsNull() && !v.IsUnknown() && v.ValueString() != "" {
+		cfg[key] = v.ValueString()
+	}
+}
+
+func putInt64IfSet(cfg map[string]interface{}, key string, v types.Int64) {
+	if !v.IsNull() && !v.IsUnknown() {
+		cfg[key] = v.ValueInt64()
+	}
+}
+
+func mergeInputAttrs(parts ...map[string]schema.Attribute) map[string]schema.Attribute {
+	out := map[string]schema.Attribute{}
+	for _, p := range parts {
+		for k, v := range p {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func inputNetworkAttrs(recvDefault string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"bind_address": schema.StringAttribute{Optional: true, Computed: true, Description: "Address to listen on (default 0.0.0.0)"},
+		"port": schema.Int64Attribute{
+			Required:    true,
+			Description: "Port to listen on (1-65535)",
+			Validators:  []validator.Int64{int64validator.Between(1, 65535)},
+		},
+		"recv_buffer_size": schema.Int64Attribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Receive buffer size in bytes (default " + recvDefault + ")",
+			Validators:  []validator.Int64{int64validator.Between(1024, 2147483647)},
+		},
+		"number_worker_threads": schema.Int64Attribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Number of worker threads (Graylog default: number of CPU cores)",
+			Validators:  []validator.Int64{int64validator.AtLeast(1)},
+		},
+		"override_source": schema.StringAttribute{Optional: true, Computed: true, Description: "Override the source field of received messages"},
+	}
+}
+
+func inputTLSAttrs() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"tls_enable": schema.BoolAttribute{Optional: true, Computed: true, Description: "Enable TLS (default false)"},
+		"tls_cert_file": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Path to the TLS certificate file on the Graylog node; requires tls_key_file",
+			Validators:  []validator.String{stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("tls_key_file"))},
+		},
+		"tls_key_file": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Path to the TLS private key file on the Graylog node; requires tls_cert_file",
+			Validators:  []validator.String{stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("tls_cert_file"))},
+		},
+		"tls_key_password": schema.StringAttribute{Optional: true, Sensitive: true, Description: "Password of the TLS private key"},
+		"tls_client_auth": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Client authentication: disabled | optional | required (default disabled)",
+			Validators:  []validator.String{stringvalidator.OneOf("disabled", "optional", "required")},
+		},
+		"tls_client_auth_cert_file": schema.StringAttribute{Optional: true, Computed: true, Description: "Trusted client certificates (file or directory); required when tls_client_auth is not disabled"},
+		"tcp_keepalive":             schema.BoolAttribute{Optional: true, Computed: true, Description: "Enable TCP keepalive (default false)"},
+	}
+}
+
+func inputFramingAttrs(nullDelimiterDefault string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"use_null_delimiter": schema.BoolAttribute{Optional: true, Computed: true, Description: "Use null byte instead of newline as frame delimiter (default " + nullDelimiterDefault + ")"},
+		"max_message_size": schema.Int64Attribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Maximum message size in bytes (default 2097152)",
+			Validators:  []validator.Int64{int64validator.AtLeast(1)},
+		},
+	}
+}
+
+func inputSyslogAttrs() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"force_rdns":             schema.BoolAttribute{Optional: true, Computed: true, Description: "Force reverse DNS lookup of the sender (default false)"},
+		"allow_override_date":    schema.BoolAttribute{Optional: true, Computed: true, Description: "Allow overriding the date with the receive time if parsing fails (default true)"},
+		"store_full_message":     schema.BoolAttribute{Optional: true, Computed: true, Description: "Store the full original syslog message (default false)"},
+		"expand_structured_data": schema.BoolAttribute{Optional: true, Computed: true, Description: "Expand structured data into fields (default false)"},
+		"timezone":               schema.StringAttribute{Optional: true, Computed: true, Description: "Time zone of messages without zone information"},
+	}
+}
+
+func inputGELFAttrs() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"decompress_size_limit": schema.Int64Attribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Maximum size of a decompressed message in bytes (default 8388608)",
+			Validators:  []validator.Int64{int64validator.AtLeast(1)},
+		},
+	}
+}
+
+// typedInputBlocks returns the schema blocks for typed input configuration.
+func typedInputBlocks() map[string]schema.Block {
+	return map[string]schema.Block{
+		"syslog_udp": schema.SingleNestedBlock{
+			Description: "Syslog UDP input (" + inputClassSyslogUDP + ")",
+			Attributes:  mergeInputAttrs(inputNetworkAttrs("262144"), inputSyslogAttrs()),
+		},
+		"syslog_tcp": schema.SingleNestedBlock{
+			Description: "Syslog TCP input (" + inputClassSyslogTCP + ")",
+			Attributes:  mergeInputAttrs(inputNetworkAttrs("1048576"), inputTLSAttrs(), inputFramingAttrs("false"), inputSyslogAttrs()),
+		},
+		"gelf_udp": schema.SingleNestedBlock{
+			Description: "GELF UDP input (" + inputClassGELFUDP + ")",
+			Attributes:  mergeInputAttrs(inputNetworkAttrs("262144"), inputGELFAttrs()),
+		},
+		"gelf_tcp": schema.SingleNestedBlock{
+			Description: "GELF TCP input (" + inputClassGELFTCP + ")",
+			Attributes:  mergeInputAttrs(inputNetworkAttrs("1048576"), inputTLSAttrs(), inputFramingAttrs("true"), inputGELFAttrs()),
+		},
+		"gelf_http": schema.SingleNestedBlock{
+			Description: "GELF HTTP input (" + inputClassGELFHTTP + ")",
+			Attributes: mergeInputAttrs(inputNetworkAttrs("1048576"), inputTLSAttrs(), inputGELFAttrs(), map[string]schema.Attribute{
+				"enable_cors":         schema.BoolAttribute{Optional: true, Computed: true, Description: "Send CORS headers (default true)"},
+				"idle_writer_timeout": schema.Int64Attribute{Optional: true, Computed: true, Description: "Idle writer timeout in seconds, 0 disables (default 60)", Validators: []validator.Int64{int64validator.AtLeast(0)}},
+				"max_chunk_size":      schema.Int64Attribute{Optional: true, Computed: true, Description: "Maximum HTTP chunk size in bytes (default 65536)", Validators: []validator.Int64{int64validator.AtLeast(1)}},
+			}),
+		},
+		"beats": schema.SingleNestedBlock{
+			Description: "Beats input (" + inputClassBeats + ")",
+			Attributes: mergeInputAttrs(inputNetworkAttrs("1048576"), inputTLSAttrs(), map[string]schema.Attribute{
+				"no_beats_prefix": schema.BoolAttribute{Optional: true, Computed: true, Description: "Do not prefix Beats fields with the Beat type (default false)"},
+			}),
+		},
+		"raw_tcp": schema.SingleNestedBlock{
+			Description: "Raw/Plaintext TCP input (" + inputClassRawTCP + ")",
+			Attributes:  mergeInputAttrs(inputNetworkAttrs("1048576"), inputTLSAttrs(), inputFramingAttrs("false")),
+		},
+		"kafka": schema.SingleNestedBlock{
+			Description: "Kafka input; the class depends on format (raw, gelf or syslog)",
+			Attributes: map[string]schema.Attribute{
+				"format": schema.StringAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "Payload format: raw | gelf | syslog (default raw)",
+					Validators:  []validator.String{stringvalidator.OneOf("raw", "gelf", "syslog")},
+				},
+				"bootstrap_server":  schema.StringAttribute{Required: true, Description: "Comma-separated Kafka bootstrap servers (host:port)"},
+				"topic_filter":      schema.StringAttribute{Required: true, Description: "Regular expression of topics to consume"},
+				"group_id":          schema.StringAttribute{Optional: true, Computed: true, Description: "Consumer group ID (default graylog2)"},
+				"offset_reset":      schema.StringAttribute{Optional: true, Computed: true, Description: "Where to start without a committed offset: largest | smallest (default largest)", Validators: []validator.String{stringvalidator.OneOf("largest", "smallest", "latest", "earliest")}},
+				"threads":           schema.Int64Attribute{Optional: true, Computed: true, Description: "Number of consumer threads (default 2)", Validators: []validator.Int64{int64validator.AtLeast(1)}},
+				"fetch_min_bytes":   schema.Int64Attribute{Optional: true, Computed: true, Description: "Minimum bytes per fetch (default 5)", Validators: []validator.Int64{int64validator.AtLeast(1)}},
+				"fetch_wait_max":    schema.Int64Attribute{Optional: true, Computed: true, Description: "Maximum fetch wait in ms (default 100)", Validators: []validator.Int64{int64validator.AtLeast(1)}},
+				"custom_properties": schema.StringAttribute{Optional: true, Computed: true, Description: "Additional consumer properties in Java properties format"},
+				"override_source":   schema.StringAttribute{Optional: true, Computed: true, Description: "Override the source field of received messages"},
+			},
+		},
+	}
+}
+
+// tlsSettings returns the TLS part of the block, if the input type supports TLS.
+func tlsSettings(b typedInputBlock) *inputTLSModel {
+	switch t := b.(type) {
+	case *inputSyslogTCPModel:
+		return &t.inputTLSModel
+	case *inputGELFTCPModel:
+		return &t.inputTLSModel
+	case *inputGELFHTTPModel:
+		return &t.inputTLSModel
+	case *inputBeatsModel:
+		return &t.inputTLSModel
+	case *inputRawTCPModel:
+		return &t.inputTLSModel
+	}
+	return nil
+}
+
+// kafkaFormatForClass is the inverse of inputKafkaClasses.
+func kafkaFormatForClass(class string) string {
+	for f, c := range inputKafkaClasses {
+		if c == class {
+			return f
+		}
+	}
+	return ""
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// nullObject builds an object value with all attributes null, overriding the given ones.
+func nullObject(t tftypes.Object, set map[string]tftypes.Value) tftypes.Value {
+	vals := make(map[string]tftypes.Value, len(t.AttributeTypes))
+	for k, at := range t.AttributeTypes {
+		vals[k] = tftypes.NewValue(at, nil)
+	}
+	for k, v := range set {
+		vals[k] = v
+	}
+	return tftypes.NewValue(t, vals)
+}
+
+func TestInputResource_TypedBlockModelMatchesSchema(t *testing.T) {
+	ctx := context.Background()
+	var sresp resource.SchemaResponse
+	NewInputResource().Schema(ctx, resource.SchemaRequest{}, &sresp)
+	if sresp.Diagnostics.HasError() {
+		t.Fatalf("schema diagnostics: %v", sresp.Diagnostics)
+	}
+	objType := sresp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	tcpType := objType.AttributeTypes["syslog_tcp"].(tftypes.Object)
+
+	state := tfsdk.State{
+		Schema: sresp.Schema,
+		Raw: nullObject(objType, map[string]tftypes.Value{
+			"title":      tftypes.NewValue(tftypes.String, "syslog"),
+			"syslog_tcp": nullObject(tcpType, map[string]tftypes.Value{"port": tftypes.NewValue(tftypes.Number, 1514)}),
+		}),
+	}
+	var m inputModel
+	if diags := state.Get(ctx, &m); diags.HasError() {
+		t.Fatalf("model does not match schema: %v", diags)
+	}
+	if m.SyslogTCP == nil || m.SyslogTCP.Port.ValueInt64() != 1514 {
+		t.Fatalf("expected syslog_tcp.port=1514, got %+v", m.SyslogTCP)
+	}
+	if b := m.typedBlock(); b == nil || b.class() != inputClassSyslogTCP {
+		t.Fatalf("unexpected typed block: %#v", b)
+	}
+}
+
+func TestTypedInputBlocks_ToConfigDefaults(t *testing.T) {
+	gelf := &inputGELFTCPModel{}
+	gelf.Port = types.Int64Value(12201)
+	gelf.TLSEnable = types.BoolUnknown()
+	cfg := gelf.toConfig()
+	if cfg["bind_address"] != "0.0.0.0" || cfg["port"] != int64(12201) || cfg["recv_buffer_size"] != int64(tcpRecvBufferDefault) {
+		t.Fatalf("unexpected network config: %+v", cfg)
+	}
+	if cfg["use_null_delimiter"] != true || cfg["tls_enable"] != false || cfg["tls_client_auth"] != "disabled" {
+		t.Fatalf("unexpected gelf tcp defaults: %+v", cfg)
+	}
+	if _, ok := cfg["tls_cert_file"]; ok {
+		t.Fatalf("unset optional keys must be omitted: %+v", cfg)
+	}
+
+	udp := &inputSyslogUDPModel{}
+	udp.Port = types.Int64Value(514)
+	udp.RecvBufferSize = types.Int64Value(4096)
+	cfg = udp.toConfig()
+	if cfg["recv_buffer_size"] != int64(4096) || cfg["allow_override_date"] != true {
+		t.Fatalf("unexpected syslog udp config: %+v", cfg)
+	}
+
+	k := &inputKafkaModel{Format: types.StringValue("gelf"), BootstrapServer: types.StringValue("kafka:9092"), TopicFilter: types.StringValue("^logs$")}
+	if k.class() != "org.graylog2.inputs.gelf.kafka.GELFKafkaInput" || kafkaFormatForClass(k.class()) != "gelf" {
+		t.Fatalf("unexpected kafka class: %s", k.class())
+	}
+	if cfg := k.toConfig(); cfg["offset_reset"] != "largest" || cfg["threads"] != int64(2) {
+		t.Fatalf("unexpected kafka config: %+v", cfg)
+	}
+}
+
+func TestTypedInputBlocks_FromConfig(t *testing.T) {
+	b := &inputBeatsModel{}
+	b.TLSKeyPassword = types.StringValue("secret")
+	b.fromConfig(map[string]interface{}{
+		"bind_address":     "127.0.0.1",
+		"port":             float64(5044),
+		"recv_buffer_size": float64(1048576),
+		"tls_enable":       true,
+		"tls_key_password": "********",
+		"no_beats_prefix":  true,
+	})
+	if b.Port.ValueInt64() != 5044 || !b.TLSEnable.ValueBool() || !b.NoBeatsPrefix.ValueBool() {
+		t.Fatalf("unexpected beats model: %+v", b)
+	}
+	if b.TLSKeyPassword.ValueString() != "secret" {
+		t.Fatalf("tls_key_password must keep the configured value, got %s", b.TLSKeyPassword.ValueString())
+	}
+}
+
+func TestValidateInput_TypedBlock(t *testing.T) {
+	ok := &inputModel{Title: types.StringValue("gelf"), Type: types.StringUnknown(), GELFUDP: &inputGELFUDPModel{}}
+	if diags := validateInput(context.Background(), ok, nil); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	mismatch := &inputModel{Title: types.StringValue("gelf"), Type: types.StringValue(inputClassSyslogUDP), GELFUDP: &inputGELFUDPModel{}}
+	if diags := validateInput(context.Background(), mismatch, nil); !diags.HasError() {
+		t.Fatal("expected error for type not matching the block")
+	}
+	raw := &inputRawTCPModel{}
+	raw.TLSClientAuth = types.StringValue("required")
+	noCA := &inputModel{Title: types.StringValue("raw"), Type: types.StringUnknown(), RawTCP: raw}
+	if diags := validateInput(context.Background(), noCA, nil); !diags.HasError() {
+		t.Fatal("expected error for client auth without tls_client_auth_cert_file")
+	}
+}
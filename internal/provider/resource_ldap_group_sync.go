@@ -0,0 +1,772 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// graylog_ldap_group_sync — синхронизация пользователей Graylog по членству в группах LDAP
+type ldapGroupSyncResource struct{ client *client.Client }
+
+type ldapGroupSyncModel struct {
+	ID       types.String `tfsdk:"id"`
+	URL      types.String `tfsdk:"url"`
+	StartTLS types.Bool   `tfsdk:"starttls"`
+	Insecure types.Bool   `tfsdk:"insecure_skip_verify"`
+	BindDN   types.String `tfsdk:"bind_dn"`
+	Password types.String `tfsdk:"bind_password"`
+	BaseDN   types.String `tfsdk:"base_dn"`
+
+	GroupFilter     types.String `tfsdk:"group_filter"`
+	MemberAttr      types.String `tfsdk:"member_attr"`
+	UserFilter      types.String `tfsdk:"user_filter"`
+	UserIDAttr      types.String `tfsdk:"user_id_attr"`
+	EmailAttr       types.String `tfsdk:"email_attr"`
+	DisplayNameAttr types.String `tfsdk:"display_name_attr"`
+
+	GroupSchema         types.String `tfsdk:"group_schema"`
+	Recursive           types.Bool   `tfsdk:"recursive"`
+	MaxDepth            types.Int64  `tfsdk:"max_depth"`
+	MatchingRuleInChain types.Bool   `tfsdk:"matching_rule_in_chain"`
+
+	Mappings      []ldapGroupMappingModel `tfsdk:"mapping"`
+	RemovalPolicy types.String            `tfsdk:"removal_policy"`
+
+	Users        types.Map      `tfsdk:"users"`
+	CreatedUsers types.List     `tfsdk:"created_users"`
+	Additions    types.List     `tfsdk:"additions"`
+	Removals     types.List     `tfsdk:"removals"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+type ldapGroupMappingModel struct {
+	GroupName types.String `tfsdk:"group_name"`
+	Roles     types.List   `tfsdk:"roles"`
+}
+
+type ldapSyncUserModel struct {
+	Email    types.String   `tfsdk:"email"`
+	FullName types.String   `tfsdk:"full_name"`
+	Roles    []types.String `tfsdk:"roles"`
+}
+
+// ldapSyncUser is the Graylog user a directory member maps to.
+type ldapSyncUser struct {
+	Email    string
+	FullName string
+	Roles    []string // sorted
+}
+
+const ldapSyncRemovalDefault = "disable"
+
+var ldapSyncUserType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"email":     types.StringType,
+	"full_name": types.StringType,
+	"roles":     types.ListType{ElemType: types.StringType},
+}}
+
+func NewLDAPGroupSyncResource() resource.Resource { return &ldapGroupSyncResource{} }
+
+func (r *ldapGroupSyncResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "graylog_ldap_group_sync"
+}
+
+func (r *ldapGroupSyncResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Keeps Graylog users in sync with LDAP group membership: creates users of mapped groups, assigns the mapped roles and disables or deletes users that left all groups.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Synthetic ID: <group names>@<base_dn>",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"url":                  schema.StringAttribute{Required: true, Description: "LDAP URL, e.g., ldap://host:389 or ldaps://host:636"},
+			"starttls":             schema.BoolAttribute{Optional: true, Description: "Use StartTLS on LDAP connection"},
+			"insecure_skip_verify": schema.BoolAttribute{Optional: true, Description: "Skip TLS verification (dev/test only)"},
+			"bind_dn":              schema.StringAttribute{Required: true, Description: "Bind DN"},
+			"bind_password":        schema.StringAttribute{Required: true, Sensitive: true, Description: "Bind password"},
+			"base_dn":              schema.StringAttribute{Required: true, Description: "Base DN for search"},
+
+			"group_filter":      schema.StringAttribute{Optional: true, Description: "Group search filter with %s placeholder for group name (default: (cn=%s))"},
+			"member_attr":       schema.StringAttribute{Optional: true, Description: "Attribute holding members (default: per group_schema, member)"},
+			"user_filter":       schema.StringAttribute{Optional: true, Description: "Filter to apply to user objects (default: (objectClass=inetOrgPerson))"},
+			"user_id_attr":      schema.StringAttribute{Optional: true, Description: "User ID attribute used as Graylog username (default: uid)"},
+			"email_attr":        schema.StringAttribute{Optional: true, Description: "Email attribute (default: mail)"},
+			"display_name_attr": schema.StringAttribute{Optional: true, Description: "Display name attribute used as full name (default: cn)"},
+
+			"group_schema": schema.StringAttribute{
+				Optional:    true,
+				Description: "Group object schema: groupOfNames | groupOfUniqueNames | posixGroup (default: groupOfNames)",
+				Validators:  []validator.String{stringvalidator.OneOf("groupOfNames", "groupOfUniqueNames", "posixGroup")},
+			},
+			"recursive":              schema.BoolAttribute{Optional: true, Description: ldapRecursiveDescription},
+			"max_depth":              schema.Int64Attribute{Optional: true, Description: ldapMaxDepthDescription, Validators: []validator.Int64{int64validator.AtLeast(1)}},
+			"matching_rule_in_chain": schema.BoolAttribute{Optional: true, Description: ldapMatchingRuleInChainDescription},
+
+			"removal_policy": schema.StringAttribute{
+				Optional:    true,
+				Description: "What happens to users the sync created once they left all mapped groups: disable | delete | ignore (default disable). Users that leave always lose the mapped roles; adopted accounts are never disabled or deleted.",
+				Validators:  []validator.String{stringvalidator.OneOf("disable", "delete", "ignore")},
+			},
+			"users": schema.MapNestedAttribute{
+				Computed:    true,
+				Description: "Synced users by username with the roles granted by their groups",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"email":     schema.StringAttribute{Computed: true},
+						"full_name": schema.StringAttribute{Computed: true},
+						"roles":     schema.ListAttribute{Computed: true, ElementType: types.StringType},
+					},
+				},
+			},
+			"created_users": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Usernames of synced users the sync created. Only these are handled per removal_policy on destroy; adopted existing accounts are left alone.",
+			},
+			"additions": schema.ListAttribute{Computed: true, ElementType: types.StringType, Description: "Usernames brought under sync by the last change"},
+			"removals":  schema.ListAttribute{Computed: true, ElementType: types.StringType, Description: "Usernames released by the last change (handled per removal_policy)"},
+			"timeouts":  timeouts.Attributes(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+		},
+		Blocks: map[string]schema.Block{
+			"mapping": schema.ListNestedBlock{
+				Description: "LDAP group to Graylog roles mapping; users in several groups get the union of roles",
+				Validators:  []validator.List{listvalidator.IsRequired(), listvalidator.SizeAtLeast(1)},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"group_name": schema.StringAttribute{Required: true, Description: "Group common name (cn) to lookup"},
+						"roles": schema.ListAttribute{
+							Required:    true,
+							ElementType: types.StringType,
+							Description: "Graylog role names granted to group members",
+							Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ldapGroupSyncResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*client.Client)
+}
+
+// ModifyPlan queries the directory so that the plan shows which users will be added and removed.
+func (r *ldapGroupSyncResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan ldapGroupSyncModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state *ldapGroupSyncModel
+	if !req.State.Raw.IsNull() {
+		state = &ldapGroupSyncModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if !plan.searchKnown() {
+		plan.Users = types.MapUnknown(ldapSyncUserType)
+		plan.Additions = types.ListUnknown(types.StringType)
+		plan.Removals = types.ListUnknown(types.StringType)
+		plan.CreatedUsers = types.ListUnknown(types.StringType)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+	desired, err := plan.resolveUsers(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read LDAP groups", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(plan.setUsers(ctx, state, desired)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Whether a new user is created or adopted is only known once it is synced
+	plan.CreatedUsers = types.ListUnknown(types.StringType)
+	if state != nil && plan.Users.Equal(state.Users) && !state.CreatedUsers.IsNull() {
+		plan.CreatedUsers = state.CreatedUsers
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *ldapGroupSyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ldapGroupSyncModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	createTimeout, diags := data.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+	desired, diags := data.plannedUsers(ctx, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	groups := make([]string, 0, len(data.Mappings))
+	for _, m := range data.Mappings {
+		groups = append(groups, m.GroupName.ValueString())
+	}
+	data.ID = types.StringValue(strings.Join(groups, ",") + "@" + data.BaseDN.ValueString())
+	// State is written even when the sync fails, so users created so far are tainted rather than orphaned
+	if err := r.sync(ctx, &data, nil, desired); err != nil {
+		resp.Diagnostics.AddError("Error syncing LDAP group users", err.Error())
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read refreshes the synced users from Graylog; deleted or disabled users drop out and are restored by the next apply.
+func (r *ldapGroupSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ldapGroupSyncModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	users, diags := ldapSyncUsersFrom(ctx, data.Users)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	managed := data.managedRoles(ctx, nil)
+	c := r.client.WithContext(ctx)
+	for name, u := range users {
+		gu, err := c.GetUser(name)
+		if err != nil {
+			if errors.Is(err, client.ErrNotFound) {
+				delete(users, name)
+				continue
+			}
+			resp.Diagnostics.AddError("Error reading user "+name, err.Error())
+			return
+		}
+		if gu.Disabled {
+			delete(users, name)
+			continue
+		}
+		u.Roles = filterRoles(gu.Roles, managed)
+		users[name] = u
+	}
+	data.Users, diags = ldapSyncUsersValue(ctx, users)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ldapGroupSyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ldapGroupSyncModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = state.ID
+	updateTimeout, diags := data.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	desired, diags := data.plannedUsers(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.sync(ctx, &data, &state, desired); err != nil {
+		resp.Diagnostics.AddError("Error syncing LDAP group users", err.Error())
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete applies the removal policy to the users the sync created and leaves adopted accounts alone.
+func (r *ldapGroupSyncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ldapGroupSyncModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, 3*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	var created []string
+	resp.Diagnostics.Append(data.CreatedUsers.ElementsAs(ctx, &created, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	c := r.client.WithContext(ctx)
+	for _, name := range created {
+		if err := removeSyncedUser(c, name, data.removalPolicy()); err != nil {
+			resp.Diagnostics.AddError("Error removing user "+name, err.Error())
+			return
+		}
+	}
+}
+
+// plannedUsers returns the desired users from the plan, or queries the directory when they were unknown
+// at plan time (e.g. the bind password came from another resource).
+func (m *ldapGroupSyncModel) plannedUsers(ctx context.Context, state *ldapGroupSyncModel) (map[string]ldapSyncUser, diag.Diagnostics) {
+	if !m.Users.IsUnknown() && !m.Users.IsNull() {
+		return ldapSyncUsersFrom(ctx, m.Users)
+	}
+	var diags diag.Diagnostics
+	desired, err := m.resolveUsers(ctx)
+	if err != nil {
+		diags.AddError("Unable to read LDAP groups", err.Error())
+		return nil, diags
+	}
+	diags.Append(m.setUsers(ctx, state, desired)...)
+	return desired, diags
+}
+
+// setUsers stores the desired users and, when they differ from state, the additions and removals.
+// Unchanged users keep the previous additions/removals so that a no-op plan stays empty.
+func (m *ldapGroupSyncModel) setUsers(ctx context.Context, state *ldapGroupSyncModel, desired map[string]ldapSyncUser) diag.Diagnostics {
+	users, diags := ldapSyncUsersValue(ctx, desired)
+	if diags.HasError() {
+		return diags
+	}
+	prior := map[string]ldapSyncUser{}
+	if state != nil {
+		if users.Equal(state.Users) && !state.Additions.IsNull() && !state.Removals.IsNull() {
+			m.Users, m.Additions, m.Removals = state.Users, state.Additions, state.Removals
+			return diags
+		}
+		var d diag.Diagnostics
+		prior, d = ldapSyncUsersFrom(ctx, state.Users)
+		diags.Append(d...)
+	}
+	additions, removals := diffLDAPSyncUsers(prior, desired)
+	m.Users = users
+	m.Additions = stringListValue(additions)
+	m.Removals = stringListValue(removals)
+	return diags
+}
+
+// sync creates or updates the desired users and handles users that left all groups: users it created
+// get the removal policy, adopted users only lose the mapped roles. It records the synced users it
+// created in plan.CreatedUsers; when it fails partway, plan.Users is narrowed to the users synced so far.
+func (r *ldapGroupSyncResource) sync(ctx context.Context, plan, state *ldapGroupSyncModel, desired map[string]ldapSyncUser) (err error) {
+	prior := map[string]ldapSyncUser{}
+	created := map[string]bool{}
+	if state != nil {
+		var diags diag.Diagnostics
+		if prior, diags = ldapSyncUsersFrom(ctx, state.Users); diags.HasError() {
+			return fmt.Errorf("invalid users in state")
+		}
+		var names []string
+		if diags = state.CreatedUsers.ElementsAs(ctx, &names, false); diags.HasError() {
+			return fmt.Errorf("invalid created_users in state")
+		}
+		for _, name := range names {
+			created[name] = true
+		}
+	}
+	synced := make(map[string]ldapSyncUser, len(prior))
+	for name, u := range prior {
+		synced[name] = u
+	}
+	defer func() {
+		if err != nil {
+			plan.Users, _ = ldapSyncUsersValue(ctx, synced)
+		}
+		plan.CreatedUsers = createdUsersValue(created, synced)
+	}()
+
+	managed := plan.managedRoles(ctx, state)
+	c := r.client.WithContext(ctx)
+	for _, name := range sortedUsernames(desired) {
+		u := desired[name]
+		if p, ok := prior[name]; ok && p.Email == u.Email && p.FullName == u.FullName && sameRoles(p.Roles, u.Roles) {
+			continue
+		}
+		isNew, err := ensureSyncedUser(c, name, u, managed)
+		if err != nil {
+			return fmt.Errorf("user %s: %w", name, err)
+		}
+		if isNew {
+			created[name] = true
+		}
+		synced[name] = u
+	}
+	policy := plan.removalPolicy()
+	for _, name := range sortedUsernames(prior) {
+		if _, ok := desired[name]; ok {
+			continue
+		}
+		var err error
+		if created[name] && policy == "delete" {
+			err = removeSyncedUser(c, name, policy)
+		} else if err = releaseSyncedUser(c, name, managed); err == nil && created[name] {
+			err = removeSyncedUser(c, name, policy)
+		}
+		if err != nil {
+			return fmt.Errorf("user %s: %w", name, err)
+		}
+		delete(synced, name)
+		delete(created, name)
+	}
+	return nil
+}
+
+// ensureSyncedUser creates the user or adopts an existing one: roles outside the mappings are kept,
+// mapped roles are set from group membership and a disabled account is enabled again.
+// It reports whether the user was created.
+func ensureSyncedUser(c *client.Client, name string, u ldapSyncUser, managed map[string]bool) (bool, error) {
+	current, err := c.GetUser(name)
+	if err != nil {
+		if !errors.Is(err, client.ErrNotFound) {
+			return false, err
+		}
+		password, err := randomPassword()
+		if err != nil {
+			return false, err
+		}
+		// Пароль случайный: вход выполняется через LDAP
+		_, err = c.CreateUser(&client.User{
+			Username: name,
+			FullName: firstNonEmpty(u.FullName, name),
+			Email:    u.Email,
+			Roles:    u.Roles,
+			Password: password,
+		})
+		return err == nil, err
+	}
+	roles := mergeManagedRoles(current.Roles, managed, u.Roles)
+	changed := !sameRoles(current.Roles, roles)
+	update := *current
+	update.Roles = roles
+	if u.Email != "" && u.Email != current.Email {
+		update.Email, changed = u.Email, true
+	}
+	if u.FullName != "" && u.FullName != current.FullName {
+		update.FullName, changed = u.FullName, true
+	}
+	if changed {
+		if _, err := c.UpdateUser(name, &update); err != nil {
+			return false, err
+		}
+	}
+	if current.Disabled {
+		return false, c.SetUserDisabled(name, false)
+	}
+	return false, nil
+}
+
+func removeSyncedUser(c *client.Client, name, policy string) error {
+	var err error
+	switch policy {
+	case "delete":
+		err = c.DeleteUser(name)
+	case "disable":
+		err = c.SetUserDisabled(name, true)
+	}
+	if errors.Is(err, client.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// releaseSyncedUser removes the mapped roles from a user that is no longer synced and keeps the account.
+func releaseSyncedUser(c *client.Client, name string, managed map[string]bool) error {
+	current, err := c.GetUser(name)
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	roles := mergeManagedRoles(current.Roles, managed, nil)
+	if sameRoles(current.Roles, roles) {
+		return nil
+	}
+	update := *current
+	update.Roles = roles
+	_, err = c.UpdateUser(name, &update)
+	return err
+}
+
+func (m *ldapGroupSyncModel) removalPolicy() string {
+	return firstNonEmpty(getString(m.RemovalPolicy), ldapSyncRemovalDefault)
+}
+
+func (m *ldapGroupSyncModel) searchSettings() ldapSearchSettings {
+	return ldapSearchSettings{
+		URL:             m.URL.ValueString(),
+		StartTLS:        getBool(m.StartTLS, false),
+		Insecure:        getBool(m.Insecure, false),
+		BindDN:          m.BindDN.ValueString(),
+		BindPassword:    m.Password.ValueString(),
+		BaseDN:          m.BaseDN.ValueString(),
+		GroupFilter:     getString(m.GroupFilter),
+		MemberAttr:      getString(m.MemberAttr),
+		UserFilter:      getString(m.UserFilter),
+		UserIDAttr:      getString(m.UserIDAttr),
+		EmailAttr:       getString(m.EmailAttr),
+		DisplayNameAttr: getString(m.DisplayNameAttr),
+
+		GroupSchema:         getString(m.GroupSchema),
+		Recursive:           getBool(m.Recursive, false),
+		MaxDepth:            int(m.MaxDepth.ValueInt64()),
+		MatchingRuleInChain: getBool(m.MatchingRuleInChain, false),
+	}
+}
+
+// searchKnown reports whether every value needed for the directory query is known.
+func (m *ldapGroupSyncModel) searchKnown() bool {
+	values := []attr.Value{m.URL, m.StartTLS, m.Insecure, m.BindDN, m.Password, m.BaseDN,
+		m.GroupFilter, m.MemberAttr, m.UserFilter, m.UserIDAttr, m.EmailAttr, m.DisplayNameAttr,
+		m.GroupSchema, m.Recursive, m.MaxDepth, m.MatchingRuleInChain}
+	for _, mp := range m.Mappings {
+		values = append(values, mp.GroupName, mp.Roles)
+		if !mp.Roles.IsUnknown() {
+			values = append(values, mp.Roles.Elements()...)
+		}
+	}
+	for _, v := range values {
+		if v.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+// groupRoles returns the mapped roles per group name in config order.
+func (m *ldapGroupSyncModel) groupRoles(ctx context.Context) ([]string, map[string][]string) {
+	var groups []string
+	roles := map[string][]string{}
+	for _, mp := range m.Mappings {
+		g := mp.GroupName.ValueString()
+		var rs []string
+		_ = mp.Roles.ElementsAs(ctx, &rs, false)
+		if _, ok := roles[g]; !ok {
+			groups = append(groups, g)
+		}
+		roles[g] = append(roles[g], rs...)
+	}
+	return groups, roles
+}
+
+// managedRoles are the roles the sync owns on its users: all roles of the current and, on update, previous mappings.
+func (m *ldapGroupSyncModel) managedRoles(ctx context.Context, state *ldapGroupSyncModel) map[string]bool {
+	managed := map[string]bool{}
+	for _, src := range []*ldapGroupSyncModel{m, state} {
+		if src == nil {
+			continue
+		}
+		_, roles := src.groupRoles(ctx)
+		for _, rs := range roles {
+			for _, r := range rs {
+				managed[r] = true
+			}
+		}
+	}
+	return managed
+}
+
+// resolveUsers reads the members of all mapped groups.
+func (m *ldapGroupSyncModel) resolveUsers(ctx context.Context) (map[string]ldapSyncUser, error) {
+	search := m.searchSettings()
+	conn, err := search.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	groups, roles := m.groupRoles(ctx)
+	members := map[string][]ldapMember{}
+	for _, g := range groups {
+		ms, err := search.groupMembers(conn, g)
+		if err != nil {
+			return nil, fmt.Errorf("group %s: %w", g, err)
+		}
+		members[g] = ms
+	}
+	return desiredLDAPSyncUsers(groups, members, roles), nil
+}
+
+// desiredLDAPSyncUsers merges group members into users; a user in several groups gets the union of their roles.
+func desiredLDAPSyncUsers(groups []string, members map[string][]ldapMember, roles map[string][]string) map[string]ldapSyncUser {
+	users := map[string]ldapSyncUser{}
+	for _, g := range groups {
+		for _, mb := range members[g] {
+			name := mb.Username.ValueString()
+			if name == "" {
+				continue
+			}
+			u, ok := users[name]
+			if !ok {
+				u = ldapSyncUser{Email: mb.Email.ValueString(), FullName: mb.DisplayName.ValueString()}
+			}
+			u.Roles = mergeManagedRoles(u.Roles, nil, roles[g])
+			sort.Strings(u.Roles)
+			users[name] = u
+		}
+	}
+	return users
+}
+
+// diffLDAPSyncUsers returns the sorted usernames that join and leave the synced set.
+func diffLDAPSyncUsers(prior, desired map[string]ldapSyncUser) (additions, removals []string) {
+	additions, removals = []string{}, []string{}
+	for name := range desired {
+		if _, ok := prior[name]; !ok {
+			additions = append(additions, name)
+		}
+	}
+	for name := range prior {
+		if _, ok := desired[name]; !ok {
+			removals = append(removals, name)
+		}
+	}
+	sort.Strings(additions)
+	sort.Strings(removals)
+	return additions, removals
+}
+
+// mergeManagedRoles keeps the current roles the sync does not manage and adds the desired ones.
+func mergeManagedRoles(current []string, managed map[string]bool, desired []string) []string {
+	out := []string{}
+	seen := map[string]bool{}
+	for _, r := range current {
+		if !managed[r] && !seen[r] {
+			seen[r] = true
+			out = append(out, r)
+		}
+	}
+	for _, r := range desired {
+		if !seen[r] {
+			seen[r] = true
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// filterRoles returns the sorted roles that are managed by the sync.
+func filterRoles(roles []string, managed map[string]bool) []string {
+	out := []string{}
+	for _, r := range roles {
+		if managed[r] {
+			out = append(out, r)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func sameRoles(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := map[string]bool{}
+	for _, r := range a {
+		set[r] = true
+	}
+	for _, r := range b {
+		if !set[r] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedUsernames(users map[string]ldapSyncUser) []string {
+	names := make([]string, 0, len(users))
+	for name := range users {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func ldapSyncUsersValue(ctx context.Context, users map[string]ldapSyncUser) (types.Map, diag.Diagnostics) {
+	models := make(map[string]ldapSyncUserModel, len(users))
+	for name, u := range users {
+		roles := make([]types.String, 0, len(u.Roles))
+		for _, r := range u.Roles {
+			roles = append(roles, types.StringValue(r))
+		}
+		models[name] = ldapSyncUserModel{Email: types.StringValue(u.Email), FullName: types.StringValue(u.FullName), Roles: roles}
+	}
+	return types.MapValueFrom(ctx, ldapSyncUserType, models)
+}
+
+func ldapSyncUsersFrom(ctx context.Context, v types.Map) (map[string]ldapSyncUser, diag.Diagnostics) {
+	users := map[string]ldapSyncUser{}
+	if v.IsNull() || v.IsUnknown() {
+		return users, nil
+	}
+	var models map[string]ldapSyncUserModel
+	diags := v.ElementsAs(ctx, &models, false)
+	for name, m := range models {
+		u := ldapSyncUser{Email: m.Email.ValueString(), FullName: m.FullName.ValueString(), Roles: []string{}}
+		for _, r := range m.Roles {
+			u.Roles = append(u.Roles, r.ValueString())
+		}
+		users[name] = u
+	}
+	return users, diags
+}
+
+// createdUsersValue lists the created usernames that are still synced.
+func createdUsersValue(created map[string]bool, desired map[string]ldapSyncUser) types.List {
+	names := []string{}
+	for _, name := range sortedUsernames(desired) {
+		if created[name] {
+			names = append(names, name)
+		}
+	}
+	return stringListValue(names)
+}
+
+func stringListValue(values []string) types.List {
+	elems := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		elems = append(elems, types.StringValue(v))
+	}
+	return types.ListValueMust(types.StringType, elems)
+}
+
+// randomPassword generates the local password of created users; they log in through LDAP.
+func randomPassword() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestLDAPGroupSyncResource_ModelMatchesSchema(t *testing.T) {
+	ctx := context.Background()
+	var sresp resource.SchemaResponse
+	NewLDAPGroupSyncResource().Schema(ctx, resource.SchemaRequest{}, &sresp)
+	if sresp.Diagnostics.HasError() {
+		t.Fatalf("schema diagnostics: %v", sresp.Diagnostics)
+	}
+	objType := sresp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	mappingType := objType.AttributeTypes["mapping"].(tftypes.List).ElementType.(tftypes.Object)
+	rolesType := mappingType.AttributeTypes["roles"]
+
+	state := tfsdk.State{
+		Schema: sresp.Schema,
+		Raw: nullObject(objType, map[string]tftypes.Value{
+			"url": tftypes.NewValue(tftypes.String, "ldap://ldap:389"),
+			"mapping": tftypes.NewValue(objType.AttributeTypes["mapping"], []tftypes.Value{
+				nullObject(mappingType, map[string]tftypes.Value{
+					"group_name": tftypes.NewValue(tftypes.String, "devops"),
+					"roles":      tftypes.NewValue(rolesType, []tftypes.Value{tftypes.NewValue(tftypes.String, "Reader")}),
+				}),
+			}),
+		}),
+	}
+	var m ldapGroupSyncModel
+	if diags := state.Get(ctx, &m); diags.HasError() {
+		t.Fatalf("model does not match schema: %v", diags)
+	}
+	if m.removalPolicy() != "disable" || !m.searchKnown() {
+		t.Fatalf("unexpected model: %+v", m)
+	}
+	users, diags := ldapSyncUsersValue(ctx, map[string]ldapSyncUser{"jdoe": {Email: "jdoe@example.com", Roles: []string{"Reader"}}})
+	if diags.HasError() {
+		t.Fatalf("users value: %v", diags)
+	}
+	if !users.Type(ctx).Equal(sresp.Schema.Attributes["users"].GetType()) {
+		t.Fatalf("users type %s does not match schema", users.Type(ctx))
+	}
+}
+
+func TestDesiredLDAPSyncUsers_MergesGroupRoles(t *testing.T) {
+	member := func(name, email string) ldapMember {
+		return ldapMember{Username: types.StringValue(name), Email: types.StringValue(email), DisplayName: types.StringValue(name)}
+	}
+	users := desiredLDAPSyncUsers(
+		[]string{"devops", "security"},
+		map[string][]ldapMember{
+			"devops":   {member("alice", "alice@example.com"), member("bob", "bob@example.com")},
+			"security": {member("alice", "other@example.com"), member("", "")},
+		},
+		map[string][]string{"devops": {"Reader", "DevOps"}, "security": {"Reader", "Security"}},
+	)
+	if len(users) != 2 {
+		t.Fatalf("expected 2 users, got %+v", users)
+	}
+	if got := users["alice"].Roles; !reflect.DeepEqual(got, []string{"DevOps", "Reader", "Security"}) {
+		t.Fatalf("unexpected alice roles: %v", got)
+	}
+	if users["alice"].Email != "alice@example.com" {
+		t.Fatalf("first group must win for email, got %s", users["alice"].Email)
+	}
+	if got := users["bob"].Roles; !reflect.DeepEqual(got, []string{"DevOps", "Reader"}) {
+		t.Fatalf("unexpected bob roles: %v", got)
+	}
+}
+
+func TestDiffLDAPSyncUsers(t *testing.T) {
+	prior := map[string]ldapSyncUser{"alice": {}, "carol": {}}
+	desired := map[string]ldapSyncUser{"alice": {}, "bob": {}, "dave": {}}
+	add, rem := diffLDAPSyncUsers(prior, desired)
+	if !reflect.DeepEqual(add, []string{"bob", "dave"}) || !reflect.DeepEqual(rem, []string{"carol"}) {
+		t.Fatalf("unexpected diff: +%v -%v", add, rem)
+	}
+	add, rem = diffLDAPSyncUsers(nil, nil)
+	if add == nil || rem == nil {
+		t.Fatalf("empty diff must be empty lists, not null")
+	}
+}
+
+func TestMergeManagedRoles(t *testing.T) {
+	managed := map[string]bool{"Reader": true, "DevOps": true, "Security": true}
+	got := mergeManagedRoles([]string{"Admin", "Reader", "Security"}, managed, []string{"Reader", "DevOps"})
+	if !reflect.DeepEqual(got, []string{"Admin", "Reader", "DevOps"}) {
+		t.Fatalf("unexpected roles: %v", got)
+	}
+	if got := filterRoles([]string{"Admin", "Security", "Reader"}, managed); !reflect.DeepEqual(got, []string{"Reader", "Security"}) {
+		t.Fatalf("unexpected managed roles: %v", got)
+	}
+	if !sameRoles([]string{"a", "b"}, []string{"b", "a"}) || sameRoles([]string{"a"}, []string{"a", "b"}) {
+		t.Fatalf("sameRoles must compare as sets")
+	}
+}
+
+func TestLDAPGroupSyncSetUsers_KeepsLastChangeWhenUnchanged(t *testing.T) {
+	ctx := context.Background()
+	desired := map[string]ldapSyncUser{"alice": {Email: "a@example.com", Roles: []string{"Reader"}}}
+	var created ldapGroupSyncModel
+	if d := created.setUsers(ctx, nil, desired); d.HasError() {
+		t.Fatalf("setUsers: %v", d)
+	}
+	if len(created.Additions.Elements()) != 1 || len(created.Removals.Elements()) != 0 {
+		t.Fatalf("create must add all users: %s / %s", created.Additions, created.Removals)
+	}
+
+	var again ldapGroupSyncModel
+	_ = again.setUsers(ctx, &created, desired)
+	if !again.Additions.Equal(created.Additions) || !again.Users.Equal(created.Users) {
+		t.Fatalf("unchanged users must keep state: %s", again.Additions)
+	}
+
+	var removed ldapGroupSyncModel
+	_ = removed.setUsers(ctx, &created, map[string]ldapSyncUser{})
+	if len(removed.Additions.Elements()) != 0 || removed.Removals.Elements()[0].(types.String).ValueString() != "alice" {
+		t.Fatalf("expected alice removal: %s / %s", removed.Additions, removed.Removals)
+	}
+}
+
+func TestCreatedUsersValue_KeepsOnlySyncedUsers(t *testing.T) {
+	created := map[string]bool{"alice": true, "carol": true}
+	desired := map[string]ldapSyncUser{"alice": {}, "bob": {}}
+	got := createdUsersValue(created, desired)
+	if len(got.Elements()) != 1 || got.Elements()[0].(types.String).ValueString() != "alice" {
+		t.Fatalf("expected only alice, got %v", got)
+	}
+}
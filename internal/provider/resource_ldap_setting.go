@@ -43,6 +43,8 @@ func (r *ldapSettingResource) Metadata(_ context.Context, _ resource.MetadataReq
 func (r *ldapSettingResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages Graylog global LDAP settings (singleton).",
+		DeprecationMessage: "The /system/ldap/settings API was removed in Graylog 4.0. Use graylog_authentication_backend " +
+			"with an ldap or active_directory block instead.",
 		Attributes: map[string]schema.Attribute{
 			"id":                       schema.StringAttribute{Computed: true, Description: "Fixed ID for singleton (always 'ldap')"},
 			"enabled":                  schema.BoolAttribute{Optional: true, Description: "Enable LDAP authentication"},
@@ -0,0 +1,57 @@
+//go:build acceptance
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccLookupTable_dns(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + `
+resource "graylog_lookup_adapter" "dns" {
+  name  = "tf-acc-dns"
+  title = "tf-acc dns"
+  dns {
+    lookup_type = "PTR"
+  }
+}
+
+resource "graylog_lookup_cache" "c" {
+  name  = "tf-acc-cache"
+  title = "tf-acc cache"
+  guava {
+    max_size = 500
+  }
+}
+
+resource "graylog_lookup_table" "t" {
+  name            = "tf-acc-rdns"
+  title           = "tf-acc rdns"
+  cache_id        = graylog_lookup_cache.c.id
+  data_adapter_id = graylog_lookup_adapter.dns.id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("graylog_lookup_adapter.dns", "id"),
+					resource.TestCheckResourceAttr("graylog_lookup_adapter.dns", "dns.lookup_type", "PTR"),
+					resource.TestCheckResourceAttr("graylog_lookup_cache.c", "guava.max_size", "500"),
+					resource.TestCheckResourceAttrPair("graylog_lookup_table.t", "cache_id", "graylog_lookup_cache.c", "id"),
+					resource.TestCheckResourceAttr("graylog_lookup_table.t", "default_single_value_type", "NULL"),
+				),
+			},
+			{
+				ResourceName:      "graylog_lookup_table.t",
+				ImportState:       true,
+				ImportStateId:     "tf-acc-rdns",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,469 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type lookupAdapterResource struct{ client *client.Client }
+
+type lookupAdapterModel struct {
+	ID          types.String           `tfsdk:"id"`
+	Name        types.String           `tfsdk:"name"`
+	Title       types.String           `tfsdk:"title"`
+	Description types.String           `tfsdk:"description"`
+	Config      types.String           `tfsdk:"config"`
+	CSVFile     *lookupAdapterCSVModel `tfsdk:"csv_file"`
+	DSVHTTP     *lookupAdapterDSVModel `tfsdk:"dsv_http"`
+	DNS         *lookupAdapterDNSModel `tfsdk:"dns"`
+	Timeouts    timeouts.Value         `tfsdk:"timeouts"`
+}
+
+// lookupAdapterCSVModel maps to config type=csvfile
+type lookupAdapterCSVModel struct {
+	Path                  types.String `tfsdk:"path"`
+	Separator             types.String `tfsdk:"separator"`
+	QuoteChar             types.String `tfsdk:"quotechar"`
+	KeyColumn             types.String `tfsdk:"key_column"`
+	ValueColumn           types.String `tfsdk:"value_column"`
+	CheckInterval         types.Int64  `tfsdk:"check_interval"`
+	CaseInsensitiveLookup types.Bool   `tfsdk:"case_insensitive_lookup"`
+	CIDRLookup            types.Bool   `tfsdk:"cidr_lookup"`
+}
+
+// lookupAdapterDSVModel maps to config type=dsvhttp
+type lookupAdapterDSVModel struct {
+	URL                   types.String `tfsdk:"url"`
+	LineSeparator         types.String `tfsdk:"line_separator"`
+	ValueSeparator        types.String `tfsdk:"value_separator"`
+	QuoteChar             types.String `tfsdk:"quotechar"`
+	IgnoreChar            types.String `tfsdk:"ignorechar"`
+	KeyColumn             types.Int64  `tfsdk:"key_column"`
+	ValueColumn           types.Int64  `tfsdk:"value_column"`
+	CheckPresenceOnly     types.Bool   `tfsdk:"check_presence_only"`
+	DefaultValue          types.String `tfsdk:"default_value"`
+	RefreshInterval       types.Int64  `tfsdk:"refresh_interval"`
+	CaseInsensitiveLookup types.Bool   `tfsdk:"case_insensitive_lookup"`
+}
+
+// lookupAdapterDNSModel maps to config type=dnslookup
+type lookupAdapterDNSModel struct {
+	LookupType              types.String `tfsdk:"lookup_type"`
+	ServerIPs               types.String `tfsdk:"server_ips"`
+	RequestTimeout          types.Int64  `tfsdk:"request_timeout"`
+	CacheTTLOverrideEnabled types.Bool   `tfsdk:"cache_ttl_override_enabled"`
+	CacheTTLOverride        types.Int64  `tfsdk:"cache_ttl_override"`
+	CacheTTLOverrideUnit    types.String `tfsdk:"cache_ttl_override_unit"`
+}
+
+func NewLookupAdapterResource() resource.Resource { return &lookupAdapterResource{} }
+
+func (r *lookupAdapterResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "graylog_lookup_adapter"
+}
+
+func (r *lookupAdapterResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Graylog lookup table data adapter (CSV file, DSV over HTTP, DNS or any type via JSON config).",
+		Attributes: map[string]schema.Attribute{
+			"id":          schema.StringAttribute{Computed: true, Description: "Data adapter ID"},
+			"name":        schema.StringAttribute{Required: true, Description: "Unique adapter name (used to reference the adapter and for import)"},
+			"title":       schema.StringAttribute{Required: true, Description: "Adapter title"},
+			"description": schema.StringAttribute{Optional: true, Description: "Adapter description"},
+			// JSON-encoded free-form object remains as an escape-hatch
+			"config":   schema.StringAttribute{Optional: true, Computed: true, Description: "JSON-encoded adapter configuration (must include `type`). Conflicts with typed blocks; computed when a typed block is used."},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+		},
+		Blocks: map[string]schema.Block{
+			"csv_file": schema.SingleNestedBlock{
+				Description: "CSV file adapter (maps to type=csvfile)",
+				Attributes: map[string]schema.Attribute{
+					"path":                    schema.StringAttribute{Optional: true, Description: "Path to the CSV file on Graylog nodes (required)"},
+					"separator":               schema.StringAttribute{Optional: true, Computed: true, Description: "Field separator (default `,`)"},
+					"quotechar":               schema.StringAttribute{Optional: true, Computed: true, Description: "Quote character (default `\"`)"},
+					"key_column":              schema.StringAttribute{Optional: true, Description: "Name of the key column (required)"},
+					"value_column":            schema.StringAttribute{Optional: true, Description: "Name of the value column (required)"},
+					"check_interval":          schema.Int64Attribute{Optional: true, Computed: true, Description: "File change check interval in seconds (default 60)"},
+					"case_insensitive_lookup": schema.BoolAttribute{Optional: true, Computed: true, Description: "Case-insensitive key lookup (default false)"},
+					"cidr_lookup":             schema.BoolAttribute{Optional: true, Computed: true, Description: "Treat keys as CIDR ranges (default false)"},
+				},
+			},
+			"dsv_http": schema.SingleNestedBlock{
+				Description: "DSV file from HTTP adapter (maps to type=dsvhttp)",
+				Attributes: map[string]schema.Attribute{
+					"url":                     schema.StringAttribute{Optional: true, Description: "URL of the DSV file (required)"},
+					"line_separator":          schema.StringAttribute{Optional: true, Computed: true, Description: "Line separator (default `\\n`)"},
+					"value_separator":         schema.StringAttribute{Optional: true, Computed: true, Description: "Value separator (default `,`)"},
+					"quotechar":               schema.StringAttribute{Optional: true, Computed: true, Description: "Quote character (default `\"`)"},
+					"ignorechar":              schema.StringAttribute{Optional: true, Computed: true, Description: "Lines starting with this character are ignored (default `#`)"},
+					"key_column":              schema.Int64Attribute{Optional: true, Computed: true, Description: "Key column index (default 0)"},
+					"value_column":            schema.Int64Attribute{Optional: true, Computed: true, Description: "Value column index (default 1)"},
+					"check_presence_only":     schema.BoolAttribute{Optional: true, Computed: true, Description: "Only check key presence, return `true` as value (default false)"},
+					"default_value":           schema.StringAttribute{Optional: true, Computed: true, Description: "Value used when check_presence_only is enabled"},
+					"refresh_interval":        schema.Int64Attribute{Optional: true, Computed: true, Description: "Refresh interval in seconds (default 60)"},
+					"case_insensitive_lookup": schema.BoolAttribute{Optional: true, Computed: true, Description: "Case-insensitive key lookup (default false)"},
+				},
+			},
+			"dns": schema.SingleNestedBlock{
+				Description: "DNS lookup adapter (maps to type=dnslookup)",
+				Attributes: map[string]schema.Attribute{
+					"lookup_type": schema.StringAttribute{
+						Optional:    true,
+						Description: "Lookup type: A | AAAA | A_AAAA | PTR | TXT (required)",
+						Validators: []validator.String{
+							stringvalidator.OneOf("A", "AAAA", "A_AAAA", "PTR", "TXT"),
+						},
+					},
+					"server_ips":                 schema.StringAttribute{Optional: true, Computed: true, Description: "Comma-separated DNS server IPs (empty uses system resolvers)"},
+					"request_timeout":            schema.Int64Attribute{Optional: true, Computed: true, Description: "Request timeout in milliseconds (default 10000)"},
+					"cache_ttl_override_enabled": schema.BoolAttribute{Optional: true, Computed: true, Description: "Override the TTL returned by DNS (default false)"},
+					"cache_ttl_override":         schema.Int64Attribute{Optional: true, Computed: true, Description: "TTL override value"},
+					"cache_ttl_override_unit":    schema.StringAttribute{Optional: true, Computed: true, Description: "TTL override unit (e.g., MINUTES)"},
+				},
+			},
+		},
+	}
+}
+
+func (r *lookupAdapterResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(path.MatchRoot("config"), path.MatchRoot("csv_file"), path.MatchRoot("dsv_http"), path.MatchRoot("dns")),
+	}
+}
+
+func (r *lookupAdapterResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*client.Client)
+}
+
+func (r *lookupAdapterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data lookupAdapterModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cfg, diags := buildLookupAdapterConfig(&data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	created, err := r.client.WithContext(ctx).CreateLookupAdapter(&client.LookupDataAdapter{
+		Name:        data.Name.ValueString(),
+		Title:       data.Title.ValueString(),
+		Description: data.Description.ValueString(),
+		Config:      cfg,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating lookup adapter", err.Error())
+		return
+	}
+	data.ID = types.StringValue(created.ID)
+	applyLookupAdapterConfig(&data, firstNonNilMap(created.Config, cfg))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *lookupAdapterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data lookupAdapterModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	a, err := r.client.WithContext(ctx).GetLookupAdapter(data.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading lookup adapter", err.Error())
+		return
+	}
+	// Import by name stores the name in id; replace it with the real ID
+	data.ID = types.StringValue(a.ID)
+	data.Name = types.StringValue(a.Name)
+	data.Title = types.StringValue(a.Title)
+	if a.Description != "" || !data.Description.IsNull() {
+		data.Description = types.StringValue(a.Description)
+	}
+	applyLookupAdapterConfig(&data, a.Config)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *lookupAdapterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state lookupAdapterModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = state.ID
+
+	cfg, diags := buildLookupAdapterConfig(&data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	updated, err := r.client.WithContext(ctx).UpdateLookupAdapter(data.ID.ValueString(), &client.LookupDataAdapter{
+		Name:        data.Name.ValueString(),
+		Title:       data.Title.ValueString(),
+		Description: data.Description.ValueString(),
+		Config:      cfg,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating lookup adapter", err.Error())
+		return
+	}
+	applyLookupAdapterConfig(&data, firstNonNilMap(updated.Config, cfg))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *lookupAdapterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data lookupAdapterModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, 3*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if err := r.client.WithContext(ctx).DeleteLookupAdapter(data.ID.ValueString()); err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return
+		}
+		resp.Diagnostics.AddError("Error deleting lookup adapter", err.Error())
+	}
+}
+
+// ImportState accepts the adapter ID or name; Graylog resolves both on GET.
+func (r *lookupAdapterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// buildLookupAdapterConfig produces the adapter config from the typed block or the JSON escape hatch.
+func buildLookupAdapterConfig(m *lookupAdapterModel) (map[string]interface{}, diag.Diagnostics) {
+	var d diag.Diagnostics
+	switch {
+	case m.CSVFile != nil:
+		b := m.CSVFile
+		requireLookupString(&d, path.Root("csv_file").AtName("path"), b.Path)
+		requireLookupString(&d, path.Root("csv_file").AtName("key_column"), b.KeyColumn)
+		requireLookupString(&d, path.Root("csv_file").AtName("value_column"), b.ValueColumn)
+		return map[string]interface{}{
+			"type":                    "csvfile",
+			"path":                    b.Path.ValueString(),
+			"separator":               stringOrDefault(b.Separator, ","),
+			"quotechar":               stringOrDefault(b.QuoteChar, `"`),
+			"key_column":              b.KeyColumn.ValueString(),
+			"value_column":            b.ValueColumn.ValueString(),
+			"check_interval":          int64OrDefault(b.CheckInterval, 60),
+			"case_insensitive_lookup": boolOrDefault(b.CaseInsensitiveLookup, false),
+			"cidr_lookup":             boolOrDefault(b.CIDRLookup, false),
+		}, d
+	case m.DSVHTTP != nil:
+		b := m.DSVHTTP
+		requireLookupString(&d, path.Root("dsv_http").AtName("url"), b.URL)
+		return map[string]interface{}{
+			"type":                    "dsvhttp",
+			"url":                     b.URL.ValueString(),
+			"line_separator":          stringOrDefault(b.LineSeparator, "\n"),
+			"value_separator":         stringOrDefault(b.ValueSeparator, ","),
+			"quotechar":               stringOrDefault(b.QuoteChar, `"`),
+			"ignorechar":              stringOrDefault(b.IgnoreChar, "#"),
+			"key_column":              int64OrDefault(b.KeyColumn, 0),
+			"value_column":            int64OrDefault(b.ValueColumn, 1),
+			"is_check_presence_only":  boolOrDefault(b.CheckPresenceOnly, false),
+			"default_value":           stringOrDefault(b.DefaultValue, ""),
+			"refresh_interval":        int64OrDefault(b.RefreshInterval, 60),
+			"case_insensitive_lookup": boolOrDefault(b.CaseInsensitiveLookup, false),
+		}, d
+	case m.DNS != nil:
+		b := m.DNS
+		requireLookupString(&d, path.Root("dns").AtName("lookup_type"), b.LookupType)
+		return map[string]interface{}{
+			"type":                       "dnslookup",
+			"lookup_type":                b.LookupType.ValueString(),
+			"server_ips":                 stringOrDefault(b.ServerIPs, ""),
+			"request_timeout":            int64OrDefault(b.RequestTimeout, 10000),
+			"cache_ttl_override_enabled": boolOrDefault(b.CacheTTLOverrideEnabled, false),
+			"cache_ttl_override":         int64OrDefault(b.CacheTTLOverride, 60),
+			"cache_ttl_override_unit":    stringOrDefault(b.CacheTTLOverrideUnit, "MINUTES"),
+		}, d
+	}
+	return parseLookupConfigJSON(m.Config, "Provide one of `csv_file`, `dsv_http`, `dns` or a JSON `config` with a `type` key.")
+}
+
+// applyLookupAdapterConfig stores server config into the typed block in use or into `config`.
+func applyLookupAdapterConfig(m *lookupAdapterModel, cfg map[string]interface{}) {
+	if s, err := CanonicalizeJSONValue(cfg); err == nil {
+		if m.CSVFile == nil && m.DSVHTTP == nil && m.DNS == nil && !m.Config.IsUnknown() {
+			// Escape-hatch mode: keep only user-managed keys to avoid drift from server defaults
+			if ns, err := normalizeJSONForState(m.Config.ValueString(), cfg); err == nil {
+				s = ns
+			}
+		}
+		m.Config = types.StringValue(s)
+	}
+	if m.CSVFile != nil {
+		b := m.CSVFile
+		b.Path = cfgString(cfg, "path")
+		b.Separator = cfgString(cfg, "separator")
+		b.QuoteChar = cfgString(cfg, "quotechar")
+		b.KeyColumn = cfgString(cfg, "key_column")
+		b.ValueColumn = cfgString(cfg, "value_column")
+		b.CheckInterval = cfgInt64(cfg, "check_interval")
+		b.CaseInsensitiveLookup = cfgBool(cfg, "case_insensitive_lookup")
+		b.CIDRLookup = cfgBool(cfg, "cidr_lookup")
+	}
+	if m.DSVHTTP != nil {
+		b := m.DSVHTTP
+		b.URL = cfgString(cfg, "url")
+		b.LineSeparator = cfgString(cfg, "line_separator")
+		b.ValueSeparator = cfgString(cfg, "value_separator")
+		b.QuoteChar = cfgString(cfg, "quotechar")
+		b.IgnoreChar = cfgString(cfg, "ignorechar")
+		b.KeyColumn = cfgInt64(cfg, "key_column")
+		b.ValueColumn = cfgInt64(cfg, "value_column")
+		b.CheckPresenceOnly = cfgBool(cfg, "is_check_presence_only")
+		b.DefaultValue = cfgString(cfg, "default_value")
+		b.RefreshInterval = cfgInt64(cfg, "refresh_interval")
+		b.CaseInsensitiveLookup = cfgBool(cfg, "case_insensitive_lookup")
+	}
+	if m.DNS != nil {
+		b := m.DNS
+		b.LookupType = cfgString(cfg, "lookup_type")
+		b.ServerIPs = cfgString(cfg, "server_ips")
+		b.RequestTimeout = cfgInt64(cfg, "request_timeout")
+		b.CacheTTLOverrideEnabled = cfgBool(cfg, "cache_ttl_override_enabled")
+		b.CacheTTLOverride = cfgInt64(cfg, "cache_ttl_override")
+		b.CacheTTLOverrideUnit = cfgString(cfg, "cache_ttl_override_unit")
+	}
+}
+
+// parseLookupConfigJSON decodes the JSON `config` escape hatch and checks the `type` discriminator.
+func parseLookupConfigJSON(v types.String, hint string) (map[string]interface{}, diag.Diagnostics) {
+	var d diag.Diagnostics
+	if v.IsNull() || v.IsUnknown() || strings.TrimSpace(v.ValueString()) == "" {
+		d.AddAttributeError(path.Root("config"), "Missing configuration", hint)
+		return nil, d
+	}
+	cfg := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(v.ValueString()), &cfg); err != nil {
+		d.AddAttributeError(path.Root("config"), "Invalid JSON", err.Error())
+		return nil, d
+	}
+	if t, _ := cfg["type"].(string); t == "" {
+		d.AddAttributeError(path.Root("config"), "Missing type", "Attribute 'config' must contain a non-empty `type` key.")
+	}
+	return cfg, d
+}
+
+func requireLookupString(d *diag.Diagnostics, p path.Path, v types.String) {
+	if v.IsUnknown() {
+		return
+	}
+	if v.IsNull() || strings.TrimSpace(v.ValueString()) == "" {
+		d.AddAttributeError(p, "Missing required attribute", "Attribute '"+p.String()+"' must be set.")
+	}
+}
+
+func stringOrDefault(v types.String, def string) string {
+	if v.IsNull() || v.IsUnknown() {
+		return def
+	}
+	return v.ValueString()
+}
+
+func int64OrDefault(v types.Int64, def int64) int64 {
+	if v.IsNull() || v.IsUnknown() {
+		return def
+	}
+	return v.ValueInt64()
+}
+
+func boolOrDefault(v types.Bool, def bool) bool {
+	if v.IsNull() || v.IsUnknown() {
+		return def
+	}
+	return v.ValueBool()
+}
+
+// cfgString/cfgInt64/cfgBool read typed values from a decoded config map (null if absent).
+func cfgString(cfg map[string]interface{}, key string) types.String {
+	if v, ok := cfg[key]; ok && v != nil {
+		return types.StringValue(toString(v))
+	}
+	return types.StringNull()
+}
+
+func cfgInt64(cfg map[string]interface{}, key string) types.Int64 {
+	switch t := cfg[key].(type) {
+	case float64:
+		return types.Int64Value(int64(t))
+	case int64:
+		return types.Int64Value(t)
+	case int:
+		return types.Int64Value(int64(t))
+	case json.Number:
+		if n, err := t.Int64(); err == nil {
+			return types.Int64Value(n)
+		}
+	}
+	return types.Int64Null()
+}
+
+func cfgBool(cfg map[string]interface{}, key string) types.Bool {
+	if b, ok := cfg[key].(bool); ok {
+		return types.BoolValue(b)
+	}
+	return types.BoolNull()
+}
+
+func firstNonNilMap(maps ...map[string]interface{}) map[string]interface{} {
+	for _, m := range maps {
+		if len(m) > 0 {
+			return m
+		}
+	}
+	return map[string]interface{}{}
+}
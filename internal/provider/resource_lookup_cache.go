@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type lookupCacheResource struct{ client *client.Client }
+
+type lookupCacheModel struct {
+	ID          types.String           `tfsdk:"id"`
+	Name        types.String           `tfsdk:"name"`
+	Title       types.String           `tfsdk:"title"`
+	Description types.String           `tfsdk:"description"`
+	Config      types.String           `tfsdk:"config"`
+	Guava       *lookupCacheGuavaModel `tfsdk:"guava"`
+	Timeouts    timeouts.Value         `tfsdk:"timeouts"`
+}
+
+// lookupCacheGuavaModel maps to config type=guava_cache (in-memory node-local cache)
+type lookupCacheGuavaModel struct {
+	MaxSize               types.Int64  `tfsdk:"max_size"`
+	ExpireAfterAccess     types.Int64  `tfsdk:"expire_after_access"`
+	ExpireAfterAccessUnit types.String `tfsdk:"expire_after_access_unit"`
+	ExpireAfterWrite      types.Int64  `tfsdk:"expire_after_write"`
+	ExpireAfterWriteUnit  types.String `tfsdk:"expire_after_write_unit"`
+}
+
+func NewLookupCacheResource() resource.Resource { return &lookupCacheResource{} }
+
+func (r *lookupCacheResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "graylog_lookup_cache"
+}
+
+func (r *lookupCacheResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Graylog lookup table cache. Without `guava` or `config` a no-op cache (type=none) is created.",
+		Attributes: map[string]schema.Attribute{
+			"id":          schema.StringAttribute{Computed: true, Description: "Cache ID"},
+			"name":        schema.StringAttribute{Required: true, Description: "Unique cache name (used for import)"},
+			"title":       schema.StringAttribute{Required: true, Description: "Cache title"},
+			"description": schema.StringAttribute{Optional: true, Description: "Cache description"},
+			// JSON-encoded free-form object remains as an escape-hatch
+			"config":   schema.StringAttribute{Optional: true, Computed: true, Description: "JSON-encoded cache configuration (must include `type`). Conflicts with `guava`."},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+		},
+		Blocks: map[string]schema.Block{
+			"guava": schema.SingleNestedBlock{
+				Description: "Node-local in-memory cache (maps to type=guava_cache)",
+				Attributes: map[string]schema.Attribute{
+					"max_size":                 schema.Int64Attribute{Optional: true, Computed: true, Description: "Maximum number of entries (default 1000)"},
+					"expire_after_access":      schema.Int64Attribute{Optional: true, Computed: true, Description: "Expire entries after last access (default 60, 0 disables)"},
+					"expire_after_access_unit": schema.StringAttribute{Optional: true, Computed: true, Description: "Unit for expire_after_access (default SECONDS)"},
+					"expire_after_write":       schema.Int64Attribute{Optional: true, Computed: true, Description: "Expire entries after write (default 0 = disabled)"},
+					"expire_after_write_unit":  schema.StringAttribute{Optional: true, Computed: true, Description: "Unit for expire_after_write"},
+				},
+			},
+		},
+	}
+}
+
+func (r *lookupCacheResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(path.MatchRoot("config"), path.MatchRoot("guava")),
+	}
+}
+
+func (r *lookupCacheResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*client.Client)
+}
+
+func (r *lookupCacheResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data lookupCacheModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cfg, diags := buildLookupCacheConfig(&data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	created, err := r.client.WithContext(ctx).CreateLookupCache(&client.LookupCache{
+		Name:        data.Name.ValueString(),
+		Title:       data.Title.ValueString(),
+		Description: data.Description.ValueString(),
+		Config:      cfg,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating lookup cache", err.Error())
+		return
+	}
+	data.ID = types.StringValue(created.ID)
+	applyLookupCacheConfig(&data, firstNonNilMap(created.Config, cfg))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *lookupCacheResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data lookupCacheModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c, err := r.client.WithContext(ctx).GetLookupCache(data.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading lookup cache", err.Error())
+		return
+	}
+	data.ID = types.StringValue(c.ID)
+	data.Name = types.StringValue(c.Name)
+	data.Title = types.StringValue(c.Title)
+	if c.Description != "" || !data.Description.IsNull() {
+		data.Description = types.StringValue(c.Description)
+	}
+	applyLookupCacheConfig(&data, c.Config)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *lookupCacheResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state lookupCacheModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = state.ID
+
+	cfg, diags := buildLookupCacheConfig(&data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	updated, err := r.client.WithContext(ctx).UpdateLookupCache(data.ID.ValueString(), &client.LookupCache{
+		Name:        data.Name.ValueString(),
+		Title:       data.Title.ValueString(),
+		Description: data.Description.ValueString(),
+		Config:      cfg,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating lookup cache", err.Error())
+		return
+	}
+	applyLookupCacheConfig(&data, firstNonNilMap(updated.Config, cfg))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *lookupCacheResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data lookupCacheModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, 3*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if err := r.client.WithContext(ctx).DeleteLookupCache(data.ID.ValueString()); err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return
+		}
+		resp.Diagnostics.AddError("Error deleting lookup cache", err.Error())
+	}
+}
+
+// ImportState accepts the cache ID or name; Graylog resolves both on GET.
+func (r *lookupCacheResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// buildLookupCacheConfig produces the cache config from `guava`, the JSON escape hatch, or a no-op cache.
+func buildLookupCacheConfig(m *lookupCacheModel) (map[string]interface{}, diag.Diagnostics) {
+	if m.Guava != nil {
+		b := m.Guava
+		cfg := map[string]interface{}{
+			"type":                     "guava_cache",
+			"max_size":                 int64OrDefault(b.MaxSize, 1000),
+			"expire_after_access":      int64OrDefault(b.ExpireAfterAccess, 60),
+			"expire_after_access_unit": stringOrDefault(b.ExpireAfterAccessUnit, "SECONDS"),
+			"expire_after_write":       int64OrDefault(b.ExpireAfterWrite, 0),
+			"expire_after_write_unit":  nil,
+		}
+		if u := stringOrDefault(b.ExpireAfterWriteUnit, ""); u != "" {
+			cfg["expire_after_write_unit"] = u
+		}
+		return cfg, nil
+	}
+	if m.Config.IsNull() || m.Config.IsUnknown() {
+		return map[string]interface{}{"type": "none"}, nil
+	}
+	return parseLookupConfigJSON(m.Config, "Provide a `guava` block or a JSON `config` with a `type` key.")
+}
+
+// applyLookupCacheConfig stores server config into `guava` (when used) or into `config`.
+func applyLookupCacheConfig(m *lookupCacheModel, cfg map[string]interface{}) {
+	if s, err := CanonicalizeJSONValue(cfg); err == nil {
+		if m.Guava == nil && !m.Config.IsUnknown() {
+			// Escape-hatch mode: keep only user-managed keys to avoid drift from server defaults
+			if ns, err := normalizeJSONForState(m.Config.ValueString(), cfg); err == nil {
+				s = ns
+			}
+		}
+		m.Config = types.StringValue(s)
+	}
+	if m.Guava != nil {
+		b := m.Guava
+		b.MaxSize = cfgInt64(cfg, "max_size")
+		b.ExpireAfterAccess = cfgInt64(cfg, "expire_after_access")
+		b.ExpireAfterAccessUnit = cfgString(cfg, "expire_after_access_unit")
+		b.ExpireAfterWrite = cfgInt64(cfg, "expire_after_write")
+		b.ExpireAfterWriteUnit = cfgString(cfg, "expire_after_write_unit")
+	}
+}
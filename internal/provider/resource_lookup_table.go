@@ -0,0 +1,285 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type lookupTableResource struct{ client *client.Client }
+
+type lookupTableModel struct {
+	ID                     types.String   `tfsdk:"id"`
+	Name                   types.String   `tfsdk:"name"`
+	Title                  types.String   `tfsdk:"title"`
+	Description            types.String   `tfsdk:"description"`
+	CacheID                types.String   `tfsdk:"cache_id"`
+	DataAdapterID          types.String   `tfsdk:"data_adapter_id"`
+	DefaultSingleValue     types.String   `tfsdk:"default_single_value"`
+	DefaultSingleValueType types.String   `tfsdk:"default_single_value_type"`
+	DefaultMultiValue      types.String   `tfsdk:"default_multi_value"`
+	DefaultMultiValueType  types.String   `tfsdk:"default_multi_value_type"`
+	Timeouts               timeouts.Value `tfsdk:"timeouts"`
+}
+
+var lookupDefaultValueTypes = []string{"NULL", "STRING", "NUMBER", "OBJECT", "BOOLEAN"}
+
+func NewLookupTableResource() resource.Resource { return &lookupTableResource{} }
+
+func (r *lookupTableResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "graylog_lookup_table"
+}
+
+func (r *lookupTableResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Graylog lookup table that combines a data adapter and a cache.",
+		Attributes: map[string]schema.Attribute{
+			"id":                   schema.StringAttribute{Computed: true, Description: "Lookup table ID"},
+			"name":                 schema.StringAttribute{Required: true, Description: "Unique table name (used in pipeline functions such as lookup_value and for import)"},
+			"title":                schema.StringAttribute{Required: true, Description: "Table title"},
+			"description":          schema.StringAttribute{Optional: true, Description: "Table description"},
+			"cache_id":             schema.StringAttribute{Required: true, Description: "ID of the lookup cache (graylog_lookup_cache.id)"},
+			"data_adapter_id":      schema.StringAttribute{Required: true, Description: "ID of the data adapter (graylog_lookup_adapter.id)"},
+			"default_single_value": schema.StringAttribute{Optional: true, Computed: true, Description: "Default single value returned when the key is not found"},
+			"default_single_value_type": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Type of the default single value: NULL | STRING | NUMBER | OBJECT | BOOLEAN (default NULL)",
+				Validators:  []validator.String{stringvalidator.OneOf(lookupDefaultValueTypes...)},
+			},
+			"default_multi_value": schema.StringAttribute{Optional: true, Computed: true, Description: "Default multi value returned when the key is not found"},
+			"default_multi_value_type": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Type of the default multi value: NULL | STRING | NUMBER | OBJECT | BOOLEAN (default NULL)",
+				Validators:  []validator.String{stringvalidator.OneOf(lookupDefaultValueTypes...)},
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+		},
+	}
+}
+
+func (r *lookupTableResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*client.Client)
+}
+
+func (r *lookupTableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data lookupTableModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Referential validation; cache/adapter names are resolved to IDs
+	refs, diags := resolveLookupTableRefs(r.client.WithContext(ctx), &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.WithContext(ctx).CreateLookupTable(refs.apply(lookupTableFromModel(&data)))
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating lookup table", err.Error())
+		return
+	}
+	data.ID = types.StringValue(created.ID)
+	applyLookupTableDefaults(&data, created)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *lookupTableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data lookupTableModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	t, err := r.client.WithContext(ctx).GetLookupTable(data.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading lookup table", err.Error())
+		return
+	}
+	data.ID = types.StringValue(t.ID)
+	data.Name = types.StringValue(t.Name)
+	data.Title = types.StringValue(t.Title)
+	if t.Description != "" || !data.Description.IsNull() {
+		data.Description = types.StringValue(t.Description)
+	}
+	c := r.client.WithContext(ctx)
+	// Keep a configured cache/adapter name while it still resolves to the referenced ID
+	data.CacheID = types.StringValue(keepLookupRef(data.CacheID, t.CacheID, func(ref string) (string, error) {
+		lc, err := c.GetLookupCache(ref)
+		if err != nil {
+			return "", err
+		}
+		return lc.ID, nil
+	}))
+	data.DataAdapterID = types.StringValue(keepLookupRef(data.DataAdapterID, t.DataAdapterID, func(ref string) (string, error) {
+		la, err := c.GetLookupAdapter(ref)
+		if err != nil {
+			return "", err
+		}
+		return la.ID, nil
+	}))
+	applyLookupTableDefaults(&data, t)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *lookupTableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state lookupTableModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = state.ID
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Referential validation; cache/adapter names are resolved to IDs
+	refs, diags := resolveLookupTableRefs(r.client.WithContext(ctx), &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated, err := r.client.WithContext(ctx).UpdateLookupTable(data.ID.ValueString(), refs.apply(lookupTableFromModel(&data)))
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating lookup table", err.Error())
+		return
+	}
+	applyLookupTableDefaults(&data, updated)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *lookupTableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data lookupTableModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, 3*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if err := r.client.WithContext(ctx).DeleteLookupTable(data.ID.ValueString()); err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return
+		}
+		resp.Diagnostics.AddError("Error deleting lookup table", err.Error())
+	}
+}
+
+// ImportState accepts the table ID or name; Graylog resolves both on GET.
+func (r *lookupTableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func lookupTableFromModel(m *lookupTableModel) *client.LookupTable {
+	return &client.LookupTable{
+		Name:                   m.Name.ValueString(),
+		Title:                  m.Title.ValueString(),
+		Description:            m.Description.ValueString(),
+		CacheID:                m.CacheID.ValueString(),
+		DataAdapterID:          m.DataAdapterID.ValueString(),
+		DefaultSingleValue:     stringOrDefault(m.DefaultSingleValue, ""),
+		DefaultSingleValueType: stringOrDefault(m.DefaultSingleValueType, "NULL"),
+		DefaultMultiValue:      stringOrDefault(m.DefaultMultiValue, ""),
+		DefaultMultiValueType:  stringOrDefault(m.DefaultMultiValueType, "NULL"),
+	}
+}
+
+func applyLookupTableDefaults(m *lookupTableModel, t *client.LookupTable) {
+	m.DefaultSingleValue = types.StringValue(t.DefaultSingleValue)
+	m.DefaultSingleValueType = types.StringValue(firstNonEmpty(t.DefaultSingleValueType, "NULL"))
+	m.DefaultMultiValue = types.StringValue(t.DefaultMultiValue)
+	m.DefaultMultiValueType = types.StringValue(firstNonEmpty(t.DefaultMultiValueType, "NULL"))
+}
+
+// lookupTableRefs holds the IDs that cache_id and data_adapter_id (ID or name) resolve to.
+type lookupTableRefs struct {
+	cacheID   string
+	adapterID string
+}
+
+func (r lookupTableRefs) apply(t *client.LookupTable) *client.LookupTable {
+	t.CacheID = firstNonEmpty(r.cacheID, t.CacheID)
+	t.DataAdapterID = firstNonEmpty(r.adapterID, t.DataAdapterID)
+	return t
+}
+
+// resolveLookupTableRefs checks that cache_id and data_adapter_id point to existing objects and
+// resolves them to IDs; Graylog accepts names on GET but stores IDs on the table.
+func resolveLookupTableRefs(c *client.Client, m *lookupTableModel) (refs lookupTableRefs, d diag.Diagnostics) {
+	if !m.CacheID.IsUnknown() {
+		lc, err := c.GetLookupCache(m.CacheID.ValueString())
+		switch {
+		case errors.Is(err, client.ErrNotFound):
+			d.AddAttributeError(path.Root("cache_id"), "Lookup cache not found", "No lookup cache exists with ID or name '"+m.CacheID.ValueString()+"'.")
+		case err != nil:
+			d.AddAttributeError(path.Root("cache_id"), "Unable to verify lookup cache", err.Error())
+		default:
+			refs.cacheID = lc.ID
+		}
+	}
+	if !m.DataAdapterID.IsUnknown() {
+		la, err := c.GetLookupAdapter(m.DataAdapterID.ValueString())
+		switch {
+		case errors.Is(err, client.ErrNotFound):
+			d.AddAttributeError(path.Root("data_adapter_id"), "Lookup data adapter not found", "No lookup data adapter exists with ID or name '"+m.DataAdapterID.ValueString()+"'.")
+		case err != nil:
+			d.AddAttributeError(path.Root("data_adapter_id"), "Unable to verify lookup data adapter", err.Error())
+		default:
+			refs.adapterID = la.ID
+		}
+	}
+	return
+}
+
+// keepLookupRef returns the prior reference when it is the server's ID or a name resolving to it.
+func keepLookupRef(prior types.String, serverID string, resolve func(string) (string, error)) string {
+	ref := prior.ValueString()
+	if prior.IsNull() || prior.IsUnknown() || ref == serverID || serverID == "" {
+		return serverID
+	}
+	if id, err := resolve(ref); err == nil && id == serverID {
+		return ref
+	}
+	return serverID
+}
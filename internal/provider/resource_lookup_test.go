@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestLookupResources_New(t *testing.T) {
+	if NewLookupAdapterResource() == nil || NewLookupCacheResource() == nil || NewLookupTableResource() == nil {
+		t.Fatal("expected non-nil resources")
+	}
+}
+
+func TestBuildLookupAdapterConfig_CSVDefaults(t *testing.T) {
+	m := &lookupAdapterModel{CSVFile: &lookupAdapterCSVModel{
+		Path:        types.StringValue("/etc/graylog/hosts.csv"),
+		KeyColumn:   types.StringValue("ip"),
+		ValueColumn: types.StringValue("host"),
+		Separator:   types.StringUnknown(),
+	}}
+	cfg, d := buildLookupAdapterConfig(m)
+	if d.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", d)
+	}
+	if cfg["type"] != "csvfile" || cfg["separator"] != "," || cfg["check_interval"] != int64(60) {
+		t.Fatalf("unexpected config: %#v", cfg)
+	}
+
+	applyLookupAdapterConfig(m, map[string]interface{}{
+		"type": "csvfile", "path": "/etc/graylog/hosts.csv", "separator": ",", "quotechar": `"`,
+		"key_column": "ip", "value_column": "host", "check_interval": float64(60),
+		"case_insensitive_lookup": false, "cidr_lookup": false,
+	})
+	if m.CSVFile.CheckInterval.ValueInt64() != 60 || m.CSVFile.Separator.ValueString() != "," {
+		t.Fatalf("typed block not populated: %+v", m.CSVFile)
+	}
+	if m.Config.IsNull() || m.Config.IsUnknown() {
+		t.Fatal("expected computed config to be set")
+	}
+}
+
+func TestBuildLookupAdapterConfig_MissingRequired(t *testing.T) {
+	m := &lookupAdapterModel{DNS: &lookupAdapterDNSModel{LookupType: types.StringNull()}}
+	if _, d := buildLookupAdapterConfig(m); !d.HasError() {
+		t.Fatal("expected error for missing lookup_type")
+	}
+	m = &lookupAdapterModel{Config: types.StringValue(`{"path":"/x"}`)}
+	if _, d := buildLookupAdapterConfig(m); !d.HasError() {
+		t.Fatal("expected error for config without type")
+	}
+}
+
+func TestBuildLookupCacheConfig_DefaultsToNone(t *testing.T) {
+	cfg, d := buildLookupCacheConfig(&lookupCacheModel{Config: types.StringUnknown()})
+	if d.HasError() || cfg["type"] != "none" {
+		t.Fatalf("expected none cache, got %#v (%v)", cfg, d)
+	}
+	cfg, _ = buildLookupCacheConfig(&lookupCacheModel{Guava: &lookupCacheGuavaModel{MaxSize: types.Int64Value(500)}})
+	if cfg["type"] != "guava_cache" || cfg["max_size"] != int64(500) || cfg["expire_after_access_unit"] != "SECONDS" {
+		t.Fatalf("unexpected guava config: %#v", cfg)
+	}
+}
+
+func TestKeepLookupRef(t *testing.T) {
+	resolve := func(ref string) (string, error) {
+		if ref == "hosts-cache" {
+			return "c1", nil
+		}
+		return "", client.ErrNotFound
+	}
+	if got := keepLookupRef(types.StringValue("hosts-cache"), "c1", resolve); got != "hosts-cache" {
+		t.Fatalf("name resolving to the same ID must be kept, got %q", got)
+	}
+	if got := keepLookupRef(types.StringValue("hosts-cache"), "c2", resolve); got != "c2" {
+		t.Fatalf("name resolving to another ID must drift to the server ID, got %q", got)
+	}
+	if got := keepLookupRef(types.StringNull(), "c1", resolve); got != "c1" {
+		t.Fatalf("import must use the server ID, got %q", got)
+	}
+}
@@ -3,25 +3,42 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type pipelineResource struct{ client *client.Client }
 
 type pipelineModel struct {
-	ID          types.String   `tfsdk:"id"`
-	Title       types.String   `tfsdk:"title"`
-	Description types.String   `tfsdk:"description"`
-	Source      types.String   `tfsdk:"source"`
-	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+	ID          types.String         `tfsdk:"id"`
+	Title       types.String         `tfsdk:"title"`
+	Description types.String         `tfsdk:"description"`
+	Source      types.String         `tfsdk:"source"`
+	Stages      []pipelineStageModel `tfsdk:"stage"`
+	Timeouts    timeouts.Value       `tfsdk:"timeouts"`
+}
+
+// pipelineStageModel is a typed alternative to raw `source`: one `stage N match X` section with its rules.
+type pipelineStageModel struct {
+	Number types.Int64    `tfsdk:"number"`
+	Match  types.String   `tfsdk:"match"`
+	Rules  []types.String `tfsdk:"rules"`
 }
 
 func NewPipelineResource() resource.Resource { return &pipelineResource{} }
@@ -38,10 +55,104 @@ func (r *pipelineResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 			"id":          schema.StringAttribute{Computed: true, Description: "Pipeline ID"},
 			"title":       schema.StringAttribute{Required: true, Description: "Pipeline title"},
 			"description": schema.StringAttribute{Optional: true, Description: "Pipeline description"},
-			"source":      schema.StringAttribute{Optional: true, Description: "Pipeline source definition"},
-			"timeouts":    timeouts.Attributes(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+			"source": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   "Pipeline source definition. Conflicts with `stage`; when stages are used, contains the rendered source.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
 		},
+		Blocks: map[string]schema.Block{
+			"stage": schema.ListNestedBlock{
+				Description: "Typed pipeline stage. The provider renders stages into canonical pipeline source. Conflicts with `source`.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"number": schema.Int64Attribute{Required: true, Description: "Stage number (stages run in ascending order)"},
+						"match": schema.StringAttribute{
+							Required:    true,
+							Description: "Stage match mode: all | either | pass",
+							Validators: []validator.String{
+								stringvalidator.OneOf("all", "either", "pass"),
+							},
+						},
+						"rules": schema.ListAttribute{Optional: true, ElementType: types.StringType, Description: "Titles of pipeline rules executed in this stage"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *pipelineResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(path.MatchRoot("source"), path.MatchRoot("stage")),
+	}
+}
+
+// ModifyPlan renders typed stages into the planned source, so the plan shows the source that will be sent
+// instead of the prior one kept by UseStateForUnknown.
+func (r *pipelineResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan pipelineModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || len(plan.Stages) == 0 {
+		return
+	}
+	if !pipelineStagesKnown(&plan) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("source"), types.StringUnknown())...)
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var state pipelineModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// Same title and stages: keep the stored source as the server formats it
+		if state.Title.Equal(plan.Title) && samePipelineStages(parsePipelineStages(state.Source.ValueString()), plan.Stages) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("source"), state.Source)...)
+			return
+		}
+	}
+	src := renderPipelineSource(plan.Title.ValueString(), plan.Stages)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("source"), types.StringValue(src))...)
+}
+
+func pipelineStagesKnown(m *pipelineModel) bool {
+	if m.Title.IsUnknown() {
+		return false
+	}
+	for _, st := range m.Stages {
+		if st.Number.IsUnknown() || st.Match.IsUnknown() {
+			return false
+		}
+		for _, rule := range st.Rules {
+			if rule.IsUnknown() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func samePipelineStages(a, b []pipelineStageModel) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Number.ValueInt64() != b[i].Number.ValueInt64() || a[i].Match.ValueString() != b[i].Match.ValueString() || len(a[i].Rules) != len(b[i].Rules) {
+			return false
+		}
+		for j := range a[i].Rules {
+			if a[i].Rules[j].ValueString() != b[i].Rules[j].ValueString() {
+				return false
+			}
+		}
 	}
+	return true
 }
 
 func (r *pipelineResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
@@ -72,6 +183,10 @@ func (r *pipelineResource) Create(ctx context.Context, req resource.CreateReques
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
 
+	// ModifyPlan renders stages into source; it is unknown only when stage values were unknown at plan time
+	if len(data.Stages) > 0 && data.Source.IsUnknown() {
+		data.Source = types.StringValue(renderPipelineSource(data.Title.ValueString(), data.Stages))
+	}
 	created, err := r.client.WithContext(ctx).CreatePipeline(&client.Pipeline{
 		Title:       data.Title.ValueString(),
 		Description: data.Description.ValueString(),
@@ -82,6 +197,9 @@ func (r *pipelineResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 	data.ID = types.StringValue(created.ID)
+	if data.Source.IsUnknown() {
+		data.Source = types.StringValue(created.Source)
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -104,15 +222,22 @@ func (r *pipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 	data.Title = types.StringValue(p.Title)
 	data.Description = types.StringValue(p.Description)
 	data.Source = types.StringValue(p.Source)
+	// Typed mode: parse the returned source back into stages so plans show per-stage diffs
+	if len(data.Stages) > 0 {
+		data.Stages = keepEmptyStageRules(data.Stages, parsePipelineStages(p.Source))
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *pipelineResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data pipelineModel
+	var data, state pipelineModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	// id is computed and unknown in plan; take it from state
+	data.ID = state.ID
 
 	// Runtime validation
 	resp.Diagnostics.Append(validatePipeline(&data)...)
@@ -128,7 +253,11 @@ func (r *pipelineResource) Update(ctx context.Context, req resource.UpdateReques
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
 	defer cancel()
 
-	_, err := r.client.WithContext(ctx).UpdatePipeline(data.ID.ValueString(), &client.Pipeline{
+	// ModifyPlan renders stages into source; it is unknown only when stage values were unknown at plan time
+	if len(data.Stages) > 0 && data.Source.IsUnknown() {
+		data.Source = types.StringValue(renderPipelineSource(data.Title.ValueString(), data.Stages))
+	}
+	updated, err := r.client.WithContext(ctx).UpdatePipeline(data.ID.ValueString(), &client.Pipeline{
 		Title:       data.Title.ValueString(),
 		Description: data.Description.ValueString(),
 		Source:      data.Source.ValueString(),
@@ -137,20 +266,113 @@ func (r *pipelineResource) Update(ctx context.Context, req resource.UpdateReques
 		resp.Diagnostics.AddError("Error updating pipeline", err.Error())
 		return
 	}
+	if data.Source.IsUnknown() {
+		data.Source = types.StringValue(updated.Source)
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-// validatePipeline checks title presence and warns about empty source.
+// validatePipeline checks title presence, stage consistency and warns about empty source.
 func validatePipeline(m *pipelineModel) (d diag.Diagnostics) {
 	if m.Title.IsNull() || m.Title.IsUnknown() || m.Title.ValueString() == "" {
 		d.AddAttributeError(path.Root("title"), "Invalid title", "Attribute 'title' must be a non-empty string.")
 	}
+	if len(m.Stages) > 0 {
+		seen := map[int64]bool{}
+		for i, st := range m.Stages {
+			if st.Number.IsNull() || st.Number.IsUnknown() {
+				continue
+			}
+			n := st.Number.ValueInt64()
+			if seen[n] {
+				d.AddAttributeError(path.Root("stage").AtListIndex(i).AtName("number"), "Duplicate stage number", fmt.Sprintf("Stage number %d is declared more than once.", n))
+			}
+			seen[n] = true
+			for j, rule := range st.Rules {
+				if !rule.IsUnknown() && strings.TrimSpace(rule.ValueString()) == "" {
+					d.AddAttributeError(path.Root("stage").AtListIndex(i).AtName("rules").AtListIndex(j), "Invalid rule", "Rule titles must be non-empty strings.")
+				}
+			}
+		}
+		return
+	}
 	if m.Source.IsNull() || m.Source.IsUnknown() || m.Source.ValueString() == "" {
 		d.AddAttributeWarning(path.Root("source"), "Empty pipeline source", "Attribute 'source' is empty; pipeline with no stages/rules may have no effect.")
 	}
 	return
 }
 
+var (
+	pipelineStageRe = regexp.MustCompile(`^stage\s+(-?\d+)\s+match\s+(all|either|pass)\b`)
+	pipelineRuleRe  = regexp.MustCompile(`^rule\s+"((?:[^"\\]|\\.)*)"\s*;?`)
+)
+
+// renderPipelineSource renders typed stages into canonical pipeline DSL:
+//
+//	pipeline "<title>"
+//	stage 0 match either
+//	rule "<rule>";
+//	end
+func renderPipelineSource(title string, stages []pipelineStageModel) string {
+	var b strings.Builder
+	b.WriteString("pipeline " + quoteDSL(title) + "\n")
+	for _, st := range stages {
+		fmt.Fprintf(&b, "stage %d match %s\n", st.Number.ValueInt64(), st.Match.ValueString())
+		for _, rule := range st.Rules {
+			b.WriteString("rule " + quoteDSL(rule.ValueString()) + ";\n")
+		}
+	}
+	b.WriteString("end\n")
+	return b.String()
+}
+
+// quoteDSL wraps s into double quotes escaping embedded quotes, as expected by the pipeline DSL.
+func quoteDSL(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// parsePipelineStages extracts stages and their rules from pipeline source, keeping source order.
+// Comments and unknown lines are ignored.
+func parsePipelineStages(source string) []pipelineStageModel {
+	var stages []pipelineStageModel
+	for _, line := range strings.Split(source, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "//") {
+			continue
+		}
+		if m := pipelineStageRe.FindStringSubmatch(line); m != nil {
+			n, _ := strconv.ParseInt(m[1], 10, 64)
+			stages = append(stages, pipelineStageModel{
+				Number: types.Int64Value(n),
+				Match:  types.StringValue(m[2]),
+			})
+			continue
+		}
+		if m := pipelineRuleRe.FindStringSubmatch(line); m != nil && len(stages) > 0 {
+			last := &stages[len(stages)-1]
+			last.Rules = append(last.Rules, types.StringValue(strings.ReplaceAll(m[1], `\"`, `"`)))
+		}
+	}
+	return stages
+}
+
+// keepEmptyStageRules keeps `rules = []` of prior stages: the source has no rule lines either way,
+// and parsing returns null rules.
+func keepEmptyStageRules(prior, parsed []pipelineStageModel) []pipelineStageModel {
+	empty := map[int64]bool{}
+	for _, st := range prior {
+		if st.Rules != nil && len(st.Rules) == 0 {
+			empty[st.Number.ValueInt64()] = true
+		}
+	}
+	for i := range parsed {
+		if parsed[i].Rules == nil && empty[parsed[i].Number.ValueInt64()] {
+			parsed[i].Rules = []types.String{}
+		}
+	}
+	return parsed
+}
+
 func (r *pipelineResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data pipelineModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
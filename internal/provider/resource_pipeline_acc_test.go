@@ -41,3 +41,45 @@ end
 		},
 	})
 }
+
+func TestAccPipeline_typedStages(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + `
+resource "graylog_pipeline_rule" "r" {
+  source = <<-EOT
+rule "tf_acc_stage_rule"
+when true
+then
+end
+  EOT
+}
+
+resource "graylog_pipeline" "p" {
+  title = "acc-pipeline-typed"
+
+  stage {
+    number = 0
+    match  = "either"
+    rules  = [graylog_pipeline_rule.r.title]
+  }
+
+  stage {
+    number = 1
+    match  = "all"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("graylog_pipeline.p", "id"),
+					resource.TestCheckResourceAttr("graylog_pipeline.p", "stage.#", "2"),
+					resource.TestCheckResourceAttr("graylog_pipeline.p", "stage.0.rules.0", "tf_acc_stage_rule"),
+					resource.TestCheckResourceAttr("graylog_pipeline.p", "stage.1.match", "all"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,279 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type pipelineRuleResource struct{ client *client.Client }
+
+type pipelineRuleModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Title       types.String   `tfsdk:"title"`
+	Description types.String   `tfsdk:"description"`
+	Source      types.String   `tfsdk:"source"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+// ruleHeaderRe matches the `rule "name"` header of a pipeline rule (escaped quotes allowed).
+var ruleHeaderRe = regexp.MustCompile(`(?m)^\s*rule\s+"((?:[^"\\]|\\.)*)"`)
+
+func NewPipelineRuleResource() resource.Resource { return &pipelineRuleResource{} }
+
+func (r *pipelineRuleResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "graylog_pipeline_rule"
+}
+
+func (r *pipelineRuleResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:     0,
+		Description: "Manages a Graylog pipeline rule. The rule title is derived from the `rule \"...\"` header of the source.",
+		Attributes: map[string]schema.Attribute{
+			"id":          schema.StringAttribute{Computed: true, Description: "Pipeline rule ID"},
+			"title":       schema.StringAttribute{Computed: true, Description: "Rule title (derived from the `rule \"...\"` header in source)"},
+			"description": schema.StringAttribute{Optional: true, Description: "Rule description"},
+			"source":      schema.StringAttribute{Required: true, Description: "Rule source in pipeline DSL (rule \"...\" when ... then ... end)"},
+			"timeouts":    timeouts.Attributes(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+		},
+	}
+}
+
+func (r *pipelineRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*client.Client)
+}
+
+// ModifyPlan derives the computed title from the planned source so that renames are visible in plan.
+func (r *pipelineRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var source types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("source"), &source)...)
+	if resp.Diagnostics.HasError() || source.IsNull() || source.IsUnknown() {
+		return
+	}
+	if title := pipelineRuleTitle(source.ValueString()); title != "" {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("title"), title)...)
+	}
+}
+
+func (r *pipelineRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data pipelineRuleModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Runtime validation
+	resp.Diagnostics.Append(validatePipelineRule(&data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	created, err := r.client.WithContext(ctx).CreatePipelineRule(&client.PipelineRule{
+		Title:       pipelineRuleTitle(data.Source.ValueString()),
+		Description: data.Description.ValueString(),
+		Source:      data.Source.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(pipelineRuleErrorDiagnostics("Error creating pipeline rule", err)...)
+		return
+	}
+	data.ID = types.StringValue(created.ID)
+	data.Title = types.StringValue(firstNonEmpty(created.Title, pipelineRuleTitle(data.Source.ValueString())))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pipelineRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data pipelineRuleModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rule, err := r.client.WithContext(ctx).GetPipelineRule(data.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading pipeline rule", err.Error())
+		return
+	}
+	data.Title = types.StringValue(firstNonEmpty(rule.Title, pipelineRuleTitle(rule.Source)))
+	data.Source = types.StringValue(rule.Source)
+	// Keep null description when it was not configured and Graylog returns an empty one
+	if rule.Description != "" || !data.Description.IsNull() {
+		data.Description = types.StringValue(rule.Description)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pipelineRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data pipelineRuleModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state pipelineRuleModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = state.ID
+
+	// Runtime validation
+	resp.Diagnostics.Append(validatePipelineRule(&data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	updated, err := r.client.WithContext(ctx).UpdatePipelineRule(data.ID.ValueString(), &client.PipelineRule{
+		ID:          data.ID.ValueString(),
+		Title:       pipelineRuleTitle(data.Source.ValueString()),
+		Description: data.Description.ValueString(),
+		Source:      data.Source.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(pipelineRuleErrorDiagnostics("Error updating pipeline rule", err)...)
+		return
+	}
+	data.Title = types.StringValue(firstNonEmpty(updated.Title, pipelineRuleTitle(data.Source.ValueString())))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pipelineRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data pipelineRuleModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, 3*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if err := r.client.WithContext(ctx).DeletePipelineRule(data.ID.ValueString()); err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return
+		}
+		resp.Diagnostics.AddError("Error deleting pipeline rule", err.Error())
+	}
+}
+
+func (r *pipelineRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	raw := req.ID
+	if raw == "" {
+		resp.Diagnostics.AddError("Empty import ID", "Provide a pipeline rule ID or a rule title to import by title.")
+		return
+	}
+	isUUID := regexp.MustCompile(`(?i)^[0-9a-f]{8}-[0-9a-f]{4}-[1-5][0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString
+	isHex24 := regexp.MustCompile(`(?i)^[0-9a-f]{24}$`).MatchString
+	val := strings.TrimSpace(raw)
+	if isUUID(val) || isHex24(val) {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), val)...) //nolint:errcheck
+		return
+	}
+	const prefix = "title:"
+	if strings.HasPrefix(strings.ToLower(val), prefix) {
+		val = strings.TrimSpace(val[len(prefix):])
+	}
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Client is nil; cannot resolve pipeline rule by title during import.")
+		return
+	}
+	list, err := r.client.WithContext(ctx).ListPipelineRules()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to list pipeline rules for import", err.Error())
+		return
+	}
+	matches := make([]client.PipelineRule, 0)
+	for _, rule := range list {
+		if firstNonEmpty(rule.Title, pipelineRuleTitle(rule.Source)) == val {
+			matches = append(matches, rule)
+		}
+	}
+	if len(matches) == 1 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), matches[0].ID)...) //nolint:errcheck
+		return
+	}
+	if len(matches) == 0 {
+		resp.Diagnostics.AddError("Pipeline rule not found by title", "No pipeline rule found with exact title: "+val+". Provide a rule ID or an exact title.")
+		return
+	}
+	resp.Diagnostics.AddError("Multiple pipeline rules match title", "Found "+fmt.Sprintf("%d", len(matches))+" pipeline rules with title '"+val+"'. Please import by ID.")
+}
+
+// pipelineRuleTitle extracts the rule title from the `rule "..."` header; returns empty string if absent.
+func pipelineRuleTitle(source string) string {
+	m := ruleHeaderRe.FindStringSubmatch(source)
+	if m == nil {
+		return ""
+	}
+	return strings.ReplaceAll(m[1], `\"`, `"`)
+}
+
+// validatePipelineRule checks that source is present and starts with a rule header.
+func validatePipelineRule(m *pipelineRuleModel) (d diag.Diagnostics) {
+	if m.Source.IsUnknown() {
+		return
+	}
+	if m.Source.IsNull() || strings.TrimSpace(m.Source.ValueString()) == "" {
+		d.AddAttributeError(path.Root("source"), "Invalid source", "Attribute 'source' must be a non-empty pipeline rule definition.")
+		return
+	}
+	if pipelineRuleTitle(m.Source.ValueString()) == "" {
+		d.AddAttributeError(path.Root("source"), "Missing rule header", "Attribute 'source' must start with a `rule \"<title>\"` header.")
+	}
+	return
+}
+
+// pipelineRuleErrorDiagnostics reports Graylog rule parse errors (with line/column) against `source`.
+// Falls back to a generic error when the response carries no parse details.
+func pipelineRuleErrorDiagnostics(summary string, err error) (d diag.Diagnostics) {
+	parseErrs := client.PipelineRuleParseErrors(err)
+	if len(parseErrs) == 0 {
+		d.AddError(summary, err.Error())
+		return
+	}
+	for _, pe := range parseErrs {
+		d.AddAttributeError(path.Root("source"), "Invalid pipeline rule source",
+			fmt.Sprintf("line %d, column %d: %s", pe.Line, pe.PositionInLine, pe.Text()))
+	}
+	return
+}
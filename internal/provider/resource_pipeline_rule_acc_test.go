@@ -0,0 +1,48 @@
+//go:build acceptance
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"testing"
+)
+
+func TestAccPipelineRule_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + `
+resource "graylog_pipeline_rule" "r" {
+  description = "Acceptance rule"
+  source = <<-EOT
+rule "tf_acc_rule"
+when
+  has_field("message")
+then
+  set_field("tf_acc", true);
+end
+  EOT
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("graylog_pipeline_rule.r", "id"),
+					resource.TestCheckResourceAttr("graylog_pipeline_rule.r", "title", "tf_acc_rule"),
+				),
+			},
+			{
+				ResourceName:      "graylog_pipeline_rule.r",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:            "graylog_pipeline_rule.r",
+				ImportState:             true,
+				ImportStateId:           "tf_acc_rule",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+		},
+	})
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPipelineRuleResource_New(t *testing.T) {
+	r := NewPipelineRuleResource()
+	if r == nil {
+		t.Fatal("expected non-nil resource")
+	}
+}
+
+func TestPipelineRuleTitle(t *testing.T) {
+	cases := map[string]string{
+		"rule \"drop_empty\"\nwhen true\nthen drop_message();\nend": "drop_empty",
+		"  // comment\n  rule \"with space\" when true then end":    "with space",
+		`rule "quoted \"name\"" when true then end`:                 `quoted "name"`,
+		"when true then end": "",
+	}
+	for src, want := range cases {
+		if got := pipelineRuleTitle(src); got != want {
+			t.Fatalf("pipelineRuleTitle(%q) = %q, want %q", src, got, want)
+		}
+	}
+}
+
+func TestValidatePipelineRule(t *testing.T) {
+	m := &pipelineRuleModel{Source: types.StringValue("when true then end")}
+	if d := validatePipelineRule(m); !d.HasError() {
+		t.Fatal("expected error for source without rule header")
+	}
+	m.Source = types.StringValue("rule \"ok\" when true then end")
+	if d := validatePipelineRule(m); d.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", d)
+	}
+}
+
+func TestPipelineRuleErrorDiagnostics_LineColumn(t *testing.T) {
+	err := client.ParseGraylogError(400, []byte(`[{"type":"syntax_error","line":3,"position_in_line":7,"message":"unexpected token"}]`))
+	d := pipelineRuleErrorDiagnostics("Error creating pipeline rule", err)
+	if d.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %d", d.ErrorsCount())
+	}
+	if got := d.Errors()[0].Detail(); got != "line 3, column 7: unexpected token" {
+		t.Fatalf("unexpected detail: %q", got)
+	}
+}
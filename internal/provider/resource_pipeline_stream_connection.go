@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type pipelineStreamConnectionResource struct{ client *client.Client }
+
+type pipelineStreamConnectionModel struct {
+	ID          types.String `tfsdk:"id"`
+	StreamID    types.String `tfsdk:"stream_id"`
+	PipelineIDs types.Set    `tfsdk:"pipeline_ids"`
+}
+
+func NewPipelineStreamConnectionResource() resource.Resource {
+	return &pipelineStreamConnectionResource{}
+}
+
+func (r *pipelineStreamConnectionResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "graylog_pipeline_stream_connection"
+}
+
+func (r *pipelineStreamConnectionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the full set of pipelines connected to a Stream. Pipelines connected outside Terraform are detected as drift and removed on apply.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Same as stream_id",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"stream_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "Stream ID",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"pipeline_ids": schema.SetAttribute{Required: true, ElementType: types.StringType, Description: "IDs of pipelines connected to the stream (empty set disconnects all)"},
+		},
+	}
+}
+
+func (r *pipelineStreamConnectionResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*client.Client)
+}
+
+func (r *pipelineStreamConnectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data pipelineStreamConnectionModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pipelineStreamConnectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data pipelineStreamConnectionModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sid := data.StreamID.ValueString()
+	if sid == "" {
+		sid = data.ID.ValueString()
+	}
+	// Stream deleted outside Terraform — the connection is gone as well
+	if _, err := r.client.WithContext(ctx).GetStream(sid); err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading stream", err.Error())
+		return
+	}
+	conn, err := r.client.WithContext(ctx).GetPipelineConnectionsForStream(sid)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading pipeline connections", err.Error())
+		return
+	}
+	ids := append([]string{}, conn.PipelineIDs...)
+	sort.Strings(ids)
+	set, diags := types.SetValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = types.StringValue(sid)
+	data.StreamID = types.StringValue(sid)
+	data.PipelineIDs = set
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pipelineStreamConnectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data pipelineStreamConnectionModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pipelineStreamConnectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data pipelineStreamConnectionModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sid := data.StreamID.ValueString()
+	conn, err := r.client.WithContext(ctx).GetPipelineConnectionsForStream(sid)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading pipeline connections before disconnect", err.Error())
+		return
+	}
+	// Disconnect only if something is still connected
+	if len(conn.PipelineIDs) == 0 {
+		return
+	}
+	if _, err := r.client.WithContext(ctx).ConnectPipelinesToStream(sid, []string{}); err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return
+		}
+		resp.Diagnostics.AddError("Error disconnecting pipelines from stream", err.Error())
+	}
+}
+
+func (r *pipelineStreamConnectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	sid := strings.TrimSpace(req.ID)
+	if sid == "" {
+		resp.Diagnostics.AddError("Empty import ID", "Provide the stream ID whose pipeline connections should be imported.")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), sid)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("stream_id"), sid)...) //nolint:errcheck
+}
+
+// apply makes the stream's connected pipelines equal to the planned set.
+// Diff-aware: nothing is sent when Graylog already has exactly the planned set.
+func (r *pipelineStreamConnectionResource) apply(ctx context.Context, data *pipelineStreamConnectionModel) (d diag.Diagnostics) {
+	sid := data.StreamID.ValueString()
+	var want []string
+	if !data.PipelineIDs.IsNull() && !data.PipelineIDs.IsUnknown() {
+		d.Append(data.PipelineIDs.ElementsAs(ctx, &want, false)...)
+		if d.HasError() {
+			return
+		}
+	}
+	current, err := r.client.WithContext(ctx).GetPipelineConnectionsForStream(sid)
+	if err != nil {
+		d.AddError("Error reading pipeline connections", err.Error())
+		return
+	}
+	if !sameStringSet(current.PipelineIDs, want) {
+		if _, err := r.client.WithContext(ctx).ConnectPipelinesToStream(sid, want); err != nil {
+			d.AddError("Error connecting pipelines to stream", err.Error())
+			return
+		}
+	}
+	data.ID = types.StringValue(sid)
+	return
+}
+
+// sameStringSet reports whether a and b contain the same elements, ignoring order and duplicates.
+func sameStringSet(a, b []string) bool {
+	am := make(map[string]struct{}, len(a))
+	for _, v := range a {
+		am[v] = struct{}{}
+	}
+	bm := make(map[string]struct{}, len(b))
+	for _, v := range b {
+		bm[v] = struct{}{}
+	}
+	if len(am) != len(bm) {
+		return false
+	}
+	for v := range am {
+		if _, ok := bm[v]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+//go:build acceptance
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPipelineStreamConnection_basic(t *testing.T) {
+	base := testAccProviderConfig() + `
+data "graylog_index_set_default" "def" {}
+
+resource "graylog_stream" "s" {
+  title        = "acc-pipeconn-s"
+  description  = "Acceptance pipeline connection stream"
+  index_set_id = data.graylog_index_set_default.def.id
+}
+
+resource "graylog_pipeline" "p1" {
+  title  = "acc-pipeconn-p1"
+  source = <<-EOT
+pipeline "acc-pipeconn-p1"
+stage 0 match either
+end
+  EOT
+}
+
+resource "graylog_pipeline" "p2" {
+  title  = "acc-pipeconn-p2"
+  source = <<-EOT
+pipeline "acc-pipeconn-p2"
+stage 0 match either
+end
+  EOT
+}
+`
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ExpectNonEmptyPlan: true,
+				Config: base + `
+resource "graylog_pipeline_stream_connection" "c" {
+  stream_id    = graylog_stream.s.id
+  pipeline_ids = [graylog_pipeline.p1.id]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("graylog_pipeline_stream_connection.c", "id", "graylog_stream.s", "id"),
+					resource.TestCheckResourceAttr("graylog_pipeline_stream_connection.c", "pipeline_ids.#", "1"),
+				),
+			},
+			{
+				ExpectNonEmptyPlan: true,
+				Config: base + `
+resource "graylog_pipeline_stream_connection" "c" {
+  stream_id    = graylog_stream.s.id
+  pipeline_ids = [graylog_pipeline.p1.id, graylog_pipeline.p2.id]
+}
+`,
+				Check: resource.TestCheckResourceAttr("graylog_pipeline_stream_connection.c", "pipeline_ids.#", "2"),
+			},
+			{
+				ResourceName:      "graylog_pipeline_stream_connection.c",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
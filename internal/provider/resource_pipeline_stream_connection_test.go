@@ -0,0 +1,22 @@
+package provider
+
+import "testing"
+
+func TestPipelineStreamConnectionResource_New(t *testing.T) {
+	r := NewPipelineStreamConnectionResource()
+	if r == nil {
+		t.Fatal("expected non-nil resource")
+	}
+}
+
+func TestSameStringSet(t *testing.T) {
+	if !sameStringSet([]string{"a", "b"}, []string{"b", "a"}) {
+		t.Fatal("expected equal sets regardless of order")
+	}
+	if !sameStringSet(nil, []string{}) {
+		t.Fatal("expected nil and empty to be equal")
+	}
+	if sameStringSet([]string{"a"}, []string{"a", "b"}) {
+		t.Fatal("expected different sets")
+	}
+}
@@ -1,6 +1,10 @@
 package provider
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 func TestPipelineResource_New(t *testing.T) {
 	r := NewPipelineResource()
@@ -8,3 +12,72 @@ func TestPipelineResource_New(t *testing.T) {
 		t.Fatal("expected non-nil resource")
 	}
 }
+
+func TestRenderPipelineSource_RoundTrip(t *testing.T) {
+	stages := []pipelineStageModel{
+		{Number: types.Int64Value(0), Match: types.StringValue("either"), Rules: []types.String{types.StringValue("drop_empty"), types.StringValue(`say "hi"`)}},
+		{Number: types.Int64Value(10), Match: types.StringValue("all")},
+	}
+	src := renderPipelineSource("sanitize", stages)
+	want := "pipeline \"sanitize\"\nstage 0 match either\nrule \"drop_empty\";\nrule \"say \\\"hi\\\"\";\nstage 10 match all\nend\n"
+	if src != want {
+		t.Fatalf("unexpected rendered source:\n%s\nwant:\n%s", src, want)
+	}
+
+	got := parsePipelineStages(src)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 stages, got %d", len(got))
+	}
+	if got[0].Number.ValueInt64() != 0 || got[0].Match.ValueString() != "either" || len(got[0].Rules) != 2 {
+		t.Fatalf("unexpected stage 0: %+v", got[0])
+	}
+	if got[0].Rules[1].ValueString() != `say "hi"` {
+		t.Fatalf("unexpected rule title: %q", got[0].Rules[1].ValueString())
+	}
+	if got[1].Number.ValueInt64() != 10 || got[1].Match.ValueString() != "all" || got[1].Rules != nil {
+		t.Fatalf("unexpected stage 1: %+v", got[1])
+	}
+}
+
+func TestParsePipelineStages_HandWritten(t *testing.T) {
+	src := `pipeline "p"
+  // comment
+  stage 1 match pass
+    rule "a";
+    rule "b"
+end`
+	got := parsePipelineStages(src)
+	if len(got) != 1 || got[0].Match.ValueString() != "pass" || len(got[0].Rules) != 2 || got[0].Rules[1].ValueString() != "b" {
+		t.Fatalf("unexpected stages: %+v", got)
+	}
+}
+
+func TestValidatePipeline_DuplicateStage(t *testing.T) {
+	m := &pipelineModel{
+		Title: types.StringValue("p"),
+		Stages: []pipelineStageModel{
+			{Number: types.Int64Value(0), Match: types.StringValue("all")},
+			{Number: types.Int64Value(0), Match: types.StringValue("either")},
+		},
+	}
+	if d := validatePipeline(m); !d.HasError() {
+		t.Fatal("expected error for duplicate stage numbers")
+	}
+}
+
+func TestKeepEmptyStageRules(t *testing.T) {
+	prior := []pipelineStageModel{
+		{Number: types.Int64Value(0), Match: types.StringValue("all"), Rules: []types.String{}},
+		{Number: types.Int64Value(1), Match: types.StringValue("all")},
+	}
+	got := keepEmptyStageRules(prior, parsePipelineStages(renderPipelineSource("p", prior)))
+	if got[0].Rules == nil || len(got[0].Rules) != 0 {
+		t.Fatalf("rules = [] must stay an empty list, got %#v", got[0].Rules)
+	}
+	if got[1].Rules != nil {
+		t.Fatalf("unset rules must stay null, got %#v", got[1].Rules)
+	}
+	if !samePipelineStages(got, prior) {
+		t.Fatalf("parsed stages must match the configured ones")
+	}
+}
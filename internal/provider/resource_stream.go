@@ -16,12 +16,25 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 type streamResource struct{ client *client.Client }
 
 type streamRuleModel struct {
+	ID          types.String `tfsdk:"id"`
+	Field       types.String `tfsdk:"field"`
+	Type        types.Int64  `tfsdk:"type"`
+	Match       types.String `tfsdk:"match"`
+	Value       types.String `tfsdk:"value"`
+	Inverted    types.Bool   `tfsdk:"inverted"`
+	Description types.String `tfsdk:"description"`
+}
+
+// streamRuleModelV2 is the rule state shape before `match` was added.
+type streamRuleModelV2 struct {
 	ID          types.String `tfsdk:"id"`
 	Field       types.String `tfsdk:"field"`
 	Type        types.Int64  `tfsdk:"type"`
@@ -37,10 +50,25 @@ type streamModel struct {
 	Disabled                 types.Bool        `tfsdk:"disabled"`
 	IndexSetID               types.String      `tfsdk:"index_set_id"`
 	RemoveMatchesFromDefault types.Bool        `tfsdk:"remove_matches_from_default_stream"`
+	ManageRules              types.Bool        `tfsdk:"manage_rules"`
+	SourceStreamID           types.String      `tfsdk:"source_stream_id"`
+	CloneOnIndexSetChange    types.Bool        `tfsdk:"clone_on_index_set_change"`
 	Rules                    []streamRuleModel `tfsdk:"rule"`
 	Timeouts                 timeouts.Value    `tfsdk:"timeouts"`
 }
 
+// streamModelV2 is the state shape before manage_rules and rule.match were added.
+type streamModelV2 struct {
+	ID                       types.String        `tfsdk:"id"`
+	Title                    types.String        `tfsdk:"title"`
+	Description              types.String        `tfsdk:"description"`
+	Disabled                 types.Bool          `tfsdk:"disabled"`
+	IndexSetID               types.String        `tfsdk:"index_set_id"`
+	RemoveMatchesFromDefault types.Bool          `tfsdk:"remove_matches_from_default_stream"`
+	Rules                    []streamRuleModelV2 `tfsdk:"rule"`
+	Timeouts                 timeouts.Value      `tfsdk:"timeouts"`
+}
+
 func NewStreamResource() resource.Resource { return &streamResource{} }
 
 func (r *streamResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -52,7 +80,11 @@ func (r *streamResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 		Version:     3,
 		Description: "Manages a Graylog stream resource. Compatible with Graylog v5, v6, and v7.",
 		Attributes: map[string]schema.Attribute{
-			"id":           schema.StringAttribute{Computed: true, Description: "The unique identifier of the stream"},
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The unique identifier of the stream",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
 			"title":        schema.StringAttribute{Required: true, Description: "The title of the stream"},
 			"description":  schema.StringAttribute{Optional: true, Description: "Description of the stream"},
 			"disabled":     schema.BoolAttribute{Optional: true, Description: "Whether the stream is disabled"},
@@ -65,6 +97,18 @@ func (r *streamResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"manage_rules": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether the `rule` blocks own the stream's rules (default true). Set to false when rules are managed with graylog_stream_rule; existing rules are then neither read nor changed.",
+			},
+			"source_stream_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Create the stream as a clone of this stream (rules, outputs and settings are copied by Graylog). Only used on create.",
+			},
+			"clone_on_index_set_change": schema.BoolAttribute{
+				Optional:    true,
+				Description: "When true, changing index_set_id replaces the stream by cloning it onto the new index set, moving pipeline connections and deleting the old stream, instead of updating it in place. The stream ID changes.",
+			},
 			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
 		},
 		Blocks: map[string]schema.Block{
@@ -74,8 +118,9 @@ func (r *streamResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 					Attributes: map[string]schema.Attribute{
 						"id":          schema.StringAttribute{Computed: true, Description: "Stream rule ID"},
 						"field":       schema.StringAttribute{Required: true, Description: "Field name to match"},
-						"type":        schema.Int64Attribute{Required: true, Description: "Rule type (Graylog enum as integer)"},
-						"value":       schema.StringAttribute{Required: true, Description: "Value to match"},
+						"type":        streamRuleTypeAttribute(),
+						"match":       streamRuleMatchAttribute(),
+						"value":       schema.StringAttribute{Optional: true, Description: "Value to match; required unless match is field_presence or always_match"},
 						"inverted":    schema.BoolAttribute{Optional: true, Description: "Invert rule condition"},
 						"description": schema.StringAttribute{Optional: true, Description: "Rule description"},
 					},
@@ -89,11 +134,28 @@ func (r *streamResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 // v0/v1/v2 -> v3: ensure the newly Computed attribute 'remove_matches_from_default_stream'
 // is present in state (defaults to false) to avoid drift on migration/import across GL versions.
 func (r *streamResource) UpgradeState(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
-	var priorStateData streamModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &priorStateData)...)
+	var prior streamModelV2
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	priorStateData := streamModel{
+		ID:                       prior.ID,
+		Title:                    prior.Title,
+		Description:              prior.Description,
+		Disabled:                 prior.Disabled,
+		IndexSetID:               prior.IndexSetID,
+		RemoveMatchesFromDefault: prior.RemoveMatchesFromDefault,
+		ManageRules:              types.BoolNull(),
+		SourceStreamID:           types.StringNull(),
+		CloneOnIndexSetChange:    types.BoolNull(),
+		Timeouts:                 prior.Timeouts,
+	}
+	for _, pr := range prior.Rules {
+		rule := streamRuleModel{ID: pr.ID, Field: pr.Field, Type: pr.Type, Value: pr.Value, Inverted: pr.Inverted, Description: pr.Description}
+		rule.Match = streamRuleMatchValue(int(pr.Type.ValueInt64()))
+		priorStateData.Rules = append(priorStateData.Rules, rule)
+	}
 
 	// Ensure remove_matches_from_default_stream has a value
 	// If it's null/unknown from prior state, set to false (API default)
@@ -104,6 +166,50 @@ func (r *streamResource) UpgradeState(ctx context.Context, req resource.UpgradeS
 	resp.Diagnostics.Append(resp.State.Set(ctx, &priorStateData)...)
 }
 
+// ModifyPlan fills each rule's `type` from `match` (and vice versa) so both are known at plan time,
+// and rejects a clone replacement that would duplicate rules managed outside the resource.
+func (r *streamResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var data streamModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var state streamModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if cloneReplaces(&state, &data) {
+			if !managesRules(&data) && r.client != nil {
+				resp.Diagnostics.Append(externalStreamRules(r.client.WithContext(ctx), state.ID.ValueString())...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+			}
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		}
+	}
+	if len(data.Rules) == 0 {
+		return
+	}
+	for i := range data.Rules {
+		fillStreamRuleMatch(&data.Rules[i].Type, &data.Rules[i].Match)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("rule"), data.Rules)...)
+}
+
+// cloneReplaces reports whether an update replaces the stream by cloning it onto a new index set.
+func cloneReplaces(state, plan *streamModel) bool {
+	if !plan.CloneOnIndexSetChange.ValueBool() || plan.IndexSetID.IsUnknown() {
+		return false
+	}
+	return plan.IndexSetID.ValueString() != state.IndexSetID.ValueString()
+}
+
 func (r *streamResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -123,6 +229,9 @@ func (r *streamResource) Create(ctx context.Context, req resource.CreateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	for i := range data.Rules {
+		fillStreamRuleMatch(&data.Rules[i].Type, &data.Rules[i].Match)
+	}
 
 	// Apply timeout
 	createTimeout, diags := data.Timeouts.Create(ctx, 5*time.Minute)
@@ -139,13 +248,21 @@ func (r *streamResource) Create(ctx context.Context, req resource.CreateRequest,
 		removeMatches = data.RemoveMatchesFromDefault.ValueBool()
 	}
 
-	created, err := r.client.WithContext(ctx).CreateStream(&client.Stream{
+	payload := &client.Stream{
 		Title:                          data.Title.ValueString(),
 		Description:                    data.Description.ValueString(),
 		Disabled:                       data.Disabled.ValueBool(),
 		IndexSetID:                     data.IndexSetID.ValueString(),
 		RemoveMatchesFromDefaultStream: removeMatches,
-	})
+	}
+	var created *client.Stream
+	var err error
+	cloned := data.SourceStreamID.ValueString() != ""
+	if cloned {
+		created, err = r.client.WithContext(ctx).CloneStream(data.SourceStreamID.ValueString(), payload)
+	} else {
+		created, err = r.client.WithContext(ctx).CreateStream(payload)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating stream", err.Error())
 		return
@@ -157,6 +274,17 @@ func (r *streamResource) Create(ctx context.Context, req resource.CreateRequest,
 	if !data.Disabled.IsNull() && !data.Disabled.IsUnknown() {
 		data.Disabled = types.BoolValue(created.Disabled)
 	}
+	// A clone already carries the source's rules; reconcile them with the rule blocks
+	if cloned {
+		if managesRules(&data) {
+			resp.Diagnostics.Append(r.syncRules(ctx, created.ID, &data)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
 	// Create rules if provided via dedicated API
 	for i, rr := range data.Rules {
 		rule := &client.StreamRule{
@@ -235,6 +363,11 @@ func (r *streamResource) Read(ctx context.Context, req resource.ReadRequest, res
 	data.IndexSetID = types.StringValue(s.IndexSetID)
 	data.RemoveMatchesFromDefault = types.BoolValue(s.RemoveMatchesFromDefaultStream)
 
+	if !managesRules(&data) {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
 	// Remember which optional fields were present in prior state for rules
 	priorRulesMap := make(map[string]streamRuleModel) // key: field+type+value
 	for _, pr := range data.Rules {
@@ -253,8 +386,13 @@ func (r *streamResource) Read(ctx context.Context, req resource.ReadRequest, res
 				ID:    types.StringValue(rrule.ID),
 				Field: types.StringValue(rrule.Field),
 				Type:  types.Int64Value(int64(rrule.Type)),
+				Match: streamRuleMatchValue(rrule.Type),
 				Value: types.StringValue(rrule.Value),
 			}
+			// field_presence/always_match rules have no value; keep it null when it was not configured
+			if rrule.Value == "" && (!hadPrior || priorRule.Value.IsNull()) {
+				newRule.Value = types.StringNull()
+			}
 
 			// Only materialize inverted if it was in prior state
 			if hadPrior && !priorRule.Inverted.IsNull() && !priorRule.Inverted.IsUnknown() {
@@ -289,6 +427,9 @@ func (r *streamResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	for i := range plan.Rules {
+		fillStreamRuleMatch(&plan.Rules[i].Type, &plan.Rules[i].Match)
+	}
 
 	// Apply timeout
 	updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
@@ -307,22 +448,222 @@ func (r *streamResource) Update(ctx context.Context, req resource.UpdateRequest,
 		removeMatches = plan.RemoveMatchesFromDefault.ValueBool()
 	}
 
-	_, err := r.client.WithContext(ctx).UpdateStream(streamID, &client.Stream{
+	payload := &client.Stream{
 		Title:                          plan.Title.ValueString(),
 		Description:                    plan.Description.ValueString(),
 		Disabled:                       plan.Disabled.ValueBool(),
 		IndexSetID:                     plan.IndexSetID.ValueString(),
 		RemoveMatchesFromDefaultStream: removeMatches,
-	})
-	if err != nil {
+	}
+	if cloneReplaces(&state, &plan) {
+		newID, diags := r.replaceByClone(ctx, streamID, payload, managesRules(&plan))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		streamID = newID
+	} else if _, err := r.client.WithContext(ctx).UpdateStream(streamID, payload); err != nil {
 		resp.Diagnostics.AddError("Error updating stream", err.Error())
 		return
 	}
+	if managesRules(&plan) {
+		resp.Diagnostics.Append(r.syncRules(ctx, streamID, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	// Update state: keep ID from state; other fields come from the plan
+	plan.ID = types.StringValue(streamID)
+	// Ensure remove_matches_from_default_stream is set to the actual value used
+	plan.RemoveMatchesFromDefault = types.BoolValue(removeMatches)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// replaceByClone clones the stream with the new settings, moves what refers to the old stream to
+// the clone and deletes the old stream. Pipeline connections, output bindings, role permissions and
+// event definition filters are moved before the old stream is removed, so routing and alerting
+// continue on the clone. If a move fails, the moves done so far are undone, the clone is removed
+// and the old stream stays in place. Rules the resource does not own cannot be mapped to the
+// clone's copies, so they fail the replacement instead of being duplicated.
+func (r *streamResource) replaceByClone(ctx context.Context, oldID string, s *client.Stream, ownsRules bool) (newID string, d diag.Diagnostics) {
+	c := r.client.WithContext(ctx)
+	if !ownsRules {
+		d.Append(externalStreamRules(c, oldID)...)
+		if d.HasError() {
+			return
+		}
+	}
+	clone, err := c.CloneStream(oldID, s)
+	if err != nil {
+		d.AddError("Error cloning stream", err.Error())
+		return
+	}
+	var undo []func()
+	fail := func(summary string, err error) {
+		for i := len(undo) - 1; i >= 0; i-- {
+			undo[i]()
+		}
+		_ = c.DeleteStream(clone.ID)
+		d.AddError(summary, err.Error())
+	}
+
+	conn, err := c.GetPipelineConnectionsForStream(oldID)
+	if err == nil && len(conn.PipelineIDs) > 0 {
+		_, err = c.ConnectPipelinesToStream(clone.ID, conn.PipelineIDs)
+	}
+	if err != nil {
+		fail("Error moving pipeline connections to cloned stream", err)
+		return
+	}
+	if err := moveStreamOutputs(c, oldID, clone.ID); err != nil {
+		fail("Error moving output bindings to cloned stream", err)
+		return
+	}
+	restoreRoles, err := moveStreamRolePermissions(c, oldID, clone.ID)
+	if err != nil {
+		fail("Error moving stream permissions to cloned stream", err)
+		return
+	}
+	undo = append(undo, restoreRoles)
+	restoreDefs, err := moveStreamEventDefinitions(c, oldID, clone.ID)
+	if err != nil {
+		fail("Error moving event definitions to cloned stream", err)
+		return
+	}
+	undo = append(undo, restoreDefs)
+
+	if err := c.DeleteStream(oldID); err != nil && !errors.Is(err, client.ErrNotFound) {
+		d.AddWarning("Old stream was not deleted", fmt.Sprintf("Stream %s replaces %s, but deleting %s failed: %s. Delete it manually.", clone.ID, oldID, oldID, err.Error()))
+	}
+	return clone.ID, d
+}
+
+// externalStreamRules fails when the stream has rules while manage_rules is false. A clone copies
+// them under new IDs, which graylog_stream_rule resources pointing at the stream cannot follow.
+func externalStreamRules(c *client.Client, streamID string) (d diag.Diagnostics) {
+	rules, err := c.ListStreamRules(streamID)
+	if err != nil {
+		d.AddError("Error listing stream rules", err.Error())
+		return
+	}
+	if len(rules) > 0 {
+		d.AddAttributeError(path.Root("clone_on_index_set_change"), "Stream rules are managed outside this resource",
+			fmt.Sprintf("Stream %s has %d rule(s) and manage_rules is false. The clone would carry copies that graylog_stream_rule resources do not track, so they would be duplicated. Manage the rules with `rule` blocks or change index_set_id without clone_on_index_set_change.", streamID, len(rules)))
+	}
+	return
+}
+
+// moveStreamOutputs attaches the old stream's outputs that the clone does not carry yet.
+func moveStreamOutputs(c *client.Client, oldID, newID string) error {
+	outputs, err := c.ListStreamOutputs(oldID)
+	if err != nil {
+		return err
+	}
+	carried, err := c.ListStreamOutputs(newID)
+	if err != nil {
+		return err
+	}
+	attached := make(map[string]bool, len(carried))
+	for _, o := range carried {
+		attached[o.ID] = true
+	}
+	for _, o := range outputs {
+		if attached[o.ID] {
+			continue
+		}
+		if err := c.AttachOutputToStream(newID, o.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveStreamRolePermissions points the roles' streams:<action>:<oldID> permissions at newID.
+// The returned func restores the previous permissions.
+func moveStreamRolePermissions(c *client.Client, oldID, newID string) (func(), error) {
+	roles, err := c.ListRoles()
+	if err != nil {
+		return nil, err
+	}
+	var moved []client.Role
+	restore := func() {
+		for _, role := range moved {
+			_, _ = c.UpdateRole(role.Name, &client.Role{Description: role.Description, Permissions: role.Permissions, ReadOnly: role.ReadOnly})
+		}
+	}
+	for _, role := range roles {
+		perms, changed := renameStreamPerms(role.Permissions, oldID, newID)
+		if !changed || role.ReadOnly {
+			continue
+		}
+		if _, err := c.UpdateRole(role.Name, &client.Role{Description: role.Description, Permissions: perms, ReadOnly: role.ReadOnly}); err != nil {
+			restore()
+			return nil, err
+		}
+		moved = append(moved, role)
+	}
+	return restore, nil
+}
+
+// renameStreamPerms rewrites the stream-scoped permissions of oldID to newID.
+func renameStreamPerms(perms []string, oldID, newID string) ([]string, bool) {
+	needle := ":" + oldID
+	out := make([]string, len(perms))
+	changed := false
+	for i, p := range perms {
+		if strings.HasPrefix(p, "streams:") && strings.HasSuffix(p, needle) {
+			p = strings.TrimSuffix(p, needle) + ":" + newID
+			changed = true
+		}
+		out[i] = p
+	}
+	return out, changed
+}
+
+// moveStreamEventDefinitions points the filter streams of event definitions at newID.
+// The returned func points them back at oldID.
+func moveStreamEventDefinitions(c *client.Client, oldID, newID string) (func(), error) {
+	defs, err := c.ListEventDefinitions()
+	if err != nil {
+		return nil, err
+	}
+	var moved []string
+	restore := func() {
+		for _, id := range moved {
+			_ = c.ReplaceEventDefinitionStream(id, newID, oldID)
+		}
+	}
+	for _, ed := range defs {
+		if !filtersStream(ed.Config, oldID) {
+			continue
+		}
+		if err := c.ReplaceEventDefinitionStream(ed.ID, oldID, newID); err != nil {
+			restore()
+			return nil, err
+		}
+		moved = append(moved, ed.ID)
+	}
+	return restore, nil
+}
+
+// filtersStream reports whether an event definition config filters on the stream.
+func filtersStream(cfg map[string]interface{}, streamID string) bool {
+	streams, _ := cfg["streams"].([]interface{})
+	for _, s := range streams {
+		if s == streamID {
+			return true
+		}
+	}
+	return false
+}
+
+// syncRules reconciles the stream's rules with plan.Rules and fills rule IDs in the plan.
+func (r *streamResource) syncRules(ctx context.Context, streamID string, plan *streamModel) (d diag.Diagnostics) {
 	// Diff-aware sync of rules: delete extra, create missing; keep matching ones
 	// Build maps by stable key
 	existing, err := r.client.WithContext(ctx).ListStreamRules(streamID)
 	if err != nil {
-		resp.Diagnostics.AddError("Error listing stream rules", err.Error())
+		d.AddError("Error listing stream rules", err.Error())
 		return
 	}
 	type ruleKey string
@@ -369,40 +710,51 @@ func (r *streamResource) Update(ctx context.Context, req resource.UpdateRequest,
 		}
 		cr, err := r.client.WithContext(ctx).CreateStreamRule(streamID, rule)
 		if err != nil {
-			resp.Diagnostics.AddError("Error creating stream rule", err.Error())
+			d.AddError("Error creating stream rule", err.Error())
 			return
 		}
 		if cr != nil && cr.ID != "" {
 			plan.Rules[i].ID = types.StringValue(cr.ID)
 		}
 	}
-	// Update state: keep ID from state; other fields come from the plan
-	plan.ID = types.StringValue(streamID)
-	// Ensure remove_matches_from_default_stream is set to the actual value used
-	plan.RemoveMatchesFromDefault = types.BoolValue(removeMatches)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	return
 }
 
-// validateStream performs basic checks for required fields and rule contents.
+// managesRules reports whether the stream resource owns its rules (manage_rules unset or true).
+func managesRules(m *streamModel) bool {
+	return m.ManageRules.IsNull() || m.ManageRules.IsUnknown() || m.ManageRules.ValueBool()
+}
+
+// validateStream performs basic checks for required fields; rule contents are checked in ValidateConfig.
 func validateStream(m *streamModel) (d diag.Diagnostics) {
 	if m.Title.IsNull() || m.Title.IsUnknown() || m.Title.ValueString() == "" {
 		d.AddAttributeError(path.Root("title"), "Invalid title", "Attribute 'title' must be a non-empty string.")
 	}
-	// Validate rules
-	for i, r := range m.Rules {
-		if r.Field.IsNull() || r.Field.IsUnknown() || r.Field.ValueString() == "" {
-			d.AddAttributeError(path.Root("rule").AtListIndex(i).AtName("field"), "Invalid rule field", "Each rule must have non-empty 'field'.")
-		}
-		if r.Value.IsNull() || r.Value.IsUnknown() || r.Value.ValueString() == "" {
-			d.AddAttributeError(path.Root("rule").AtListIndex(i).AtName("value"), "Invalid rule value", "Each rule must have non-empty 'value'.")
+	if !managesRules(m) && len(m.Rules) > 0 {
+		d.AddAttributeError(path.Root("rule"), "Rules are not managed", "'rule' blocks cannot be used with manage_rules = false; manage the rules with graylog_stream_rule instead.")
+	}
+	return
+}
+
+// ValidateConfig checks each rule block against its match type before planning; unknown rules and values are skipped.
+func (r *streamResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var rules types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rule"), &rules)...)
+	if resp.Diagnostics.HasError() || rules.IsNull() || rules.IsUnknown() {
+		return
+	}
+	for i, v := range rules.Elements() {
+		obj, ok := v.(types.Object)
+		if !ok || obj.IsNull() || obj.IsUnknown() {
+			continue
 		}
-		if r.Type.IsNull() || r.Type.IsUnknown() {
-			d.AddAttributeError(path.Root("rule").AtListIndex(i).AtName("type"), "Invalid rule type", "Each rule must specify 'type' as a non-negative integer.")
-		} else if r.Type.ValueInt64() < 0 {
-			d.AddAttributeError(path.Root("rule").AtListIndex(i).AtName("type"), "Invalid rule type", "Rule 'type' must be >= 0.")
+		var rule streamRuleModel
+		resp.Diagnostics.Append(obj.As(ctx, &rule, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
+		resp.Diagnostics.Append(validateStreamRuleMatch(path.Root("rule").AtListIndex(i), rule.Field, rule.Type, rule.Match, rule.Value)...)
 	}
-	return
 }
 
 func (r *streamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
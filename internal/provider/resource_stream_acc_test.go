@@ -122,3 +122,47 @@ resource "graylog_stream" "test" {
 		},
 	})
 }
+
+func TestAccStream_clone(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + `
+data "graylog_index_set_default" "this" {}
+
+resource "graylog_stream" "source" {
+  title        = "acc-stream-clone-source"
+  index_set_id = data.graylog_index_set_default.this.id
+
+  rule {
+    field = "source"
+    match = "exact"
+    value = "acc-clone"
+  }
+}
+
+resource "graylog_stream" "copy" {
+  title            = "acc-stream-clone-copy"
+  index_set_id     = data.graylog_index_set_default.this.id
+  source_stream_id = graylog_stream.source.id
+  manage_rules     = false
+}
+
+data "graylog_stream_match_test" "copy" {
+  stream_id = graylog_stream.copy.id
+  message = {
+    source  = "acc-clone"
+    message = "hello"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("graylog_stream.copy", "id"),
+					resource.TestCheckResourceAttr("data.graylog_stream_match_test.copy", "matches", "true"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,400 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type streamRuleResource struct{ client *client.Client }
+
+type streamRuleResourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	StreamID    types.String   `tfsdk:"stream_id"`
+	Field       types.String   `tfsdk:"field"`
+	Type        types.Int64    `tfsdk:"type"`
+	Match       types.String   `tfsdk:"match"`
+	Value       types.String   `tfsdk:"value"`
+	Inverted    types.Bool     `tfsdk:"inverted"`
+	Description types.String   `tfsdk:"description"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+// streamRuleMatchTypes maps symbolic rule types to Graylog's StreamRuleType enum.
+var streamRuleMatchTypes = map[string]int{
+	"exact":          1,
+	"greater":        2,
+	"smaller":        3,
+	"regex":          4,
+	"field_presence": 5,
+	"contains":       6,
+	"always_match":   7,
+	"match_input":    8,
+}
+
+var streamRuleMatchNames = []string{"exact", "greater", "smaller", "regex", "field_presence", "contains", "always_match", "match_input"}
+
+func NewStreamRuleResource() resource.Resource { return &streamRuleResource{} }
+
+func (r *streamRuleResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "graylog_stream_rule"
+}
+
+func (r *streamRuleResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a single rule of a Graylog stream. Changes are applied in place, so the rule ID is preserved. Set `manage_rules = false` on the graylog_stream when its rules are managed with this resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: "Stream rule ID"},
+			"stream_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "ID of the stream the rule belongs to",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"field":       schema.StringAttribute{Required: true, Description: "Field name to match"},
+			"type":        streamRuleTypeAttribute(),
+			"match":       streamRuleMatchAttribute(),
+			"value":       schema.StringAttribute{Optional: true, Description: "Value to match; required unless match is field_presence or always_match"},
+			"inverted":    schema.BoolAttribute{Optional: true, Computed: true, Description: "Invert rule condition (default false)"},
+			"description": schema.StringAttribute{Optional: true, Description: "Rule description"},
+			"timeouts":    timeouts.Attributes(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+		},
+	}
+}
+
+// ModifyPlan fills `type` from `match` (and vice versa) so both are known at plan time.
+func (r *streamRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var data streamRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	fillStreamRuleMatch(&data.Type, &data.Match)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("type"), data.Type)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("match"), data.Match)...)
+}
+
+func (r *streamRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*client.Client)
+}
+
+// ValidateConfig checks the rule against its match type before planning; unknown values are skipped.
+func (r *streamRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data streamRuleResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateStreamRuleResource(&data)...)
+}
+
+func (r *streamRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data streamRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	c := r.client.WithContext(ctx)
+	rule := streamRuleFromModel(&data)
+	// Refuse to duplicate a rule that already exists on the stream
+	existing, err := c.ListStreamRules(data.StreamID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing stream rules", err.Error())
+		return
+	}
+	for _, ex := range existing {
+		if sameStreamRule(&ex, rule) {
+			resp.Diagnostics.AddError("Stream rule already exists",
+				fmt.Sprintf("Stream %s already has rule %s with the same field, type, value and inverted flag. Import it with `terraform import` using the ID %q instead of creating a duplicate.", data.StreamID.ValueString(), ex.ID, data.StreamID.ValueString()+"/"+ex.ID))
+			return
+		}
+	}
+
+	created, err := c.CreateStreamRule(data.StreamID.ValueString(), rule)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating stream rule", err.Error())
+		return
+	}
+	if created == nil || created.ID == "" {
+		resp.Diagnostics.AddError("Error creating stream rule", "Graylog did not return the ID of the created rule.")
+		return
+	}
+	data.ID = types.StringValue(created.ID)
+	data.Inverted = types.BoolValue(data.Inverted.ValueBool())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *streamRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data streamRuleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rule, err := r.client.WithContext(ctx).GetStreamRule(data.StreamID.ValueString(), data.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading stream rule", err.Error())
+		return
+	}
+	applyStreamRule(&data, rule)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *streamRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state streamRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = state.ID
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	if _, err := r.client.WithContext(ctx).UpdateStreamRule(data.StreamID.ValueString(), data.ID.ValueString(), streamRuleFromModel(&data)); err != nil {
+		resp.Diagnostics.AddError("Error updating stream rule", err.Error())
+		return
+	}
+	data.Inverted = types.BoolValue(data.Inverted.ValueBool())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *streamRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data streamRuleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, 3*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if err := r.client.WithContext(ctx).DeleteStreamRule(data.StreamID.ValueString(), data.ID.ValueString()); err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return
+		}
+		resp.Diagnostics.AddError("Error deleting stream rule", err.Error())
+	}
+}
+
+// ImportState expects "<stream_id>/<rule_id>".
+func (r *streamRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(strings.TrimSpace(req.ID), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError("Invalid import ID", "Expected '<stream_id>/<rule_id>'.")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("stream_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
+
+// sameStreamRule reports whether two rules match the same messages; the description is ignored.
+func sameStreamRule(a, b *client.StreamRule) bool {
+	return a.Field == b.Field && a.Type == b.Type && a.Value == b.Value && a.Inverted == b.Inverted
+}
+
+func validateStreamRuleResource(m *streamRuleResourceModel) diag.Diagnostics {
+	return validateStreamRuleMatch(path.Empty(), m.Field, m.Type, m.Match, m.Value)
+}
+
+func streamRuleFromModel(m *streamRuleResourceModel) *client.StreamRule {
+	fillStreamRuleMatch(&m.Type, &m.Match)
+	return &client.StreamRule{
+		Field:       m.Field.ValueString(),
+		Type:        int(m.Type.ValueInt64()),
+		Value:       m.Value.ValueString(),
+		Inverted:    m.Inverted.ValueBool(),
+		Description: m.Description.ValueString(),
+	}
+}
+
+// applyStreamRule copies server values into the model; optional strings stay null when the server returns them empty.
+func applyStreamRule(m *streamRuleResourceModel, rule *client.StreamRule) {
+	m.Field = types.StringValue(rule.Field)
+	m.Type = types.Int64Value(int64(rule.Type))
+	m.Match = streamRuleMatchValue(rule.Type)
+	m.Inverted = types.BoolValue(rule.Inverted)
+	if rule.Value != "" || !m.Value.IsNull() {
+		m.Value = types.StringValue(rule.Value)
+	}
+	if rule.Description != "" || !m.Description.IsNull() {
+		m.Description = types.StringValue(rule.Description)
+	}
+}
+
+func streamRuleTypeAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{Optional: true, Computed: true, Description: "Rule type as Graylog integer enum; prefer `match`"}
+}
+
+func streamRuleMatchAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Rule type: exact | regex | greater | smaller | field_presence | contains | always_match | match_input",
+		Validators:  []validator.String{stringvalidator.OneOf(streamRuleMatchNames...)},
+	}
+}
+
+// streamRuleMatchValue returns the symbolic name of a Graylog rule type, or null for types unknown to the provider.
+func streamRuleMatchValue(t int) types.String {
+	for name, v := range streamRuleMatchTypes {
+		if v == t {
+			return types.StringValue(name)
+		}
+	}
+	return types.StringNull()
+}
+
+// fillStreamRuleMatch derives whichever of type/match is not configured from the other one.
+func fillStreamRuleMatch(typ *types.Int64, match *types.String) {
+	if !match.IsNull() && !match.IsUnknown() {
+		if v, ok := streamRuleMatchTypes[match.ValueString()]; ok && (typ.IsNull() || typ.IsUnknown()) {
+			*typ = types.Int64Value(int64(v))
+		}
+		return
+	}
+	if !typ.IsNull() && !typ.IsUnknown() {
+		*match = streamRuleMatchValue(int(typ.ValueInt64()))
+	}
+}
+
+// validateStreamRuleMatch checks that the rule type is set consistently and that field/value fit it.
+// p is the path of the rule object (empty for graylog_stream_rule).
+func validateStreamRuleMatch(p path.Path, field types.String, typ types.Int64, match types.String, value types.String) (d diag.Diagnostics) {
+	at := func(name string) path.Path {
+		if len(p.Steps()) == 0 {
+			return path.Root(name)
+		}
+		return p.AtName(name)
+	}
+	if field.IsUnknown() || typ.IsUnknown() || match.IsUnknown() || value.IsUnknown() {
+		return
+	}
+	name := match.ValueString()
+	switch {
+	case match.IsNull() && typ.IsNull():
+		d.AddAttributeError(at("match"), "Missing rule type", "Each rule must set 'match' (or the integer 'type').")
+		return
+	case !match.IsNull() && !typ.IsNull() && int64(streamRuleMatchTypes[name]) != typ.ValueInt64():
+		d.AddAttributeError(at("type"), "Conflicting rule type", "'type' = "+strconv.FormatInt(typ.ValueInt64(), 10)+" does not match 'match' = \""+name+"\"; set only one of them.")
+		return
+	case match.IsNull():
+		if typ.ValueInt64() < 0 {
+			d.AddAttributeError(at("type"), "Invalid rule type", "Rule 'type' must be >= 0.")
+			return
+		}
+		name = streamRuleMatchValue(int(typ.ValueInt64())).ValueString()
+	}
+
+	if name != "always_match" && field.ValueString() == "" {
+		d.AddAttributeError(at("field"), "Invalid rule field", "Each rule must have non-empty 'field'.")
+	}
+	v := value.ValueString()
+	switch name {
+	case "field_presence", "always_match":
+		if v != "" {
+			d.AddAttributeError(at("value"), "Unexpected rule value", "Rules with match = \""+name+"\" do not take a 'value'.")
+		}
+	case "":
+		// Type unknown to the provider: leave the value as is
+	default:
+		if v == "" {
+			d.AddAttributeError(at("value"), "Invalid rule value", "Rules with match = \""+name+"\" require a non-empty 'value'.")
+			return
+		}
+	}
+	switch name {
+	case "greater", "smaller":
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			d.AddAttributeWarning(at("value"), "Rule value is not a number", "Rules with match = \""+name+"\" compare numbers; '"+v+"' is not a number, so the rule never matches.")
+		}
+	case "regex":
+		d.Append(validateStreamRuleRegex(at("value"), v)...)
+	}
+	return
+}
+
+// validateStreamRuleRegex compiles a regex rule value. Graylog evaluates Java regular expressions, so
+// constructs RE2 does not support (lookarounds, possessive quantifiers, ...) only produce a warning.
+func validateStreamRuleRegex(p path.Path, expr string) (d diag.Diagnostics) {
+	_, err := syntax.Parse(expr, syntax.Perl)
+	if err == nil {
+		if c := javaRegexDivergence(expr); c != "" {
+			d.AddAttributeWarning(p, "Regex not verified", "The expression uses "+c+", which Java and Go regular expressions interpret differently; Graylog will evaluate it as a Java regular expression.")
+		}
+		return
+	}
+	var serr *syntax.Error
+	if errors.As(err, &serr) {
+		switch serr.Code {
+		case syntax.ErrInvalidPerlOp, syntax.ErrInvalidRepeatOp, syntax.ErrInvalidEscape, syntax.ErrInvalidCharRange:
+			d.AddAttributeWarning(p, "Regex not verified", "The expression uses syntax that cannot be checked locally ("+serr.Error()+"); Graylog will evaluate it as a Java regular expression.")
+			return
+		}
+	}
+	d.AddAttributeError(p, "Invalid regex", err.Error())
+	return
+}
+
+var javaUnicodeFlagRe = regexp.MustCompile(`\(\?[a-zA-Z]*U`)
+
+// javaRegexDivergence names a construct that compiles in RE2 but means something else in Java:
+// (?U) is ungreedy in RE2 and Unicode classes in Java, \v is a single character in RE2 and a class
+// in Java, and Java has no POSIX bracket classes ([[:alpha:]] is a plain character class there).
+func javaRegexDivergence(expr string) string {
+	switch {
+	case javaUnicodeFlagRe.MatchString(expr):
+		return "the (?U) flag"
+	case strings.Contains(expr, `\v`):
+		return `\v`
+	case strings.Contains(expr, "[[:"):
+		return "a POSIX character class"
+	}
+	return ""
+}
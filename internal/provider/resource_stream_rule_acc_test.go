@@ -0,0 +1,77 @@
+//go:build acceptance
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func testAccStreamRuleConfig(value string) string {
+	return testAccProviderConfig() + `
+data "graylog_index_set_default" "this" {}
+
+resource "graylog_stream" "shared" {
+  title        = "acc-stream-rule"
+  index_set_id = data.graylog_index_set_default.this.id
+  manage_rules = false
+}
+
+resource "graylog_stream_rule" "source" {
+  stream_id   = graylog_stream.shared.id
+  field       = "source"
+  match       = "exact"
+  value       = "` + value + `"
+  description = "added by a second module"
+}
+`
+}
+
+func TestAccStreamRule_updateInPlace(t *testing.T) {
+	var firstID string
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStreamRuleConfig("web-1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("graylog_stream_rule.source", "id"),
+					resource.TestCheckResourceAttr("graylog_stream_rule.source", "inverted", "false"),
+					resource.TestCheckResourceAttrWith("graylog_stream_rule.source", "id", func(v string) error {
+						firstID = v
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testAccStreamRuleConfig("web-2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("graylog_stream_rule.source", "value", "web-2"),
+					resource.TestCheckResourceAttrWith("graylog_stream_rule.source", "id", func(v string) error {
+						if v != firstID {
+							return fmt.Errorf("stream rule was recreated: %s != %s", v, firstID)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName: "graylog_stream_rule.source",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["graylog_stream_rule.source"]
+					if !ok {
+						return "", fmt.Errorf("stream rule resource not found in state")
+					}
+					return rs.Primary.Attributes["stream_id"] + "/" + rs.Primary.ID, nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+		},
+	})
+}
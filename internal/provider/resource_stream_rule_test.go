@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ultrafenrir/terraform-provider-graylog/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestStreamRuleResource_New(t *testing.T) {
+	if NewStreamRuleResource() == nil {
+		t.Fatal("expected non-nil resource")
+	}
+}
+
+func TestApplyStreamRule_KeepsNullOptionals(t *testing.T) {
+	m := &streamRuleResourceModel{Value: types.StringNull(), Description: types.StringNull()}
+	applyStreamRule(m, &client.StreamRule{ID: "r1", Field: "source", Type: 5, Inverted: true})
+	if !m.Value.IsNull() || !m.Description.IsNull() {
+		t.Fatalf("expected empty value/description to stay null, got %v / %v", m.Value, m.Description)
+	}
+	if m.Field.ValueString() != "source" || m.Type.ValueInt64() != 5 || !m.Inverted.ValueBool() {
+		t.Fatalf("unexpected model: %+v", m)
+	}
+}
+
+func TestValidateStream_ManageRulesFalseRejectsRuleBlocks(t *testing.T) {
+	m := &streamModel{
+		Title:       types.StringValue("s"),
+		ManageRules: types.BoolValue(false),
+		Rules: []streamRuleModel{{
+			Field: types.StringValue("source"),
+			Type:  types.Int64Value(1),
+			Value: types.StringValue("web"),
+		}},
+	}
+	if d := validateStream(m); !d.HasError() {
+		t.Fatal("expected error for rule blocks with manage_rules = false")
+	}
+	m.ManageRules = types.BoolNull()
+	if d := validateStream(m); d.HasError() {
+		t.Fatalf("unexpected diags: %v", d)
+	}
+}
+
+func TestStreamRuleValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	validate := func(r resource.ResourceWithValidateConfig, raw func(tftypes.Object) tftypes.Value) resource.ValidateConfigResponse {
+		var sresp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &sresp)
+		objType := sresp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+		var resp resource.ValidateConfigResponse
+		r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: sresp.Schema, Raw: raw(objType)}}, &resp)
+		return resp
+	}
+	rule := func(match string, value tftypes.Value) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"field": tftypes.NewValue(tftypes.String, "source"),
+			"match": tftypes.NewValue(tftypes.String, match),
+			"value": value,
+		}
+	}
+
+	standalone := func(value tftypes.Value) func(tftypes.Object) tftypes.Value {
+		return func(o tftypes.Object) tftypes.Value { return nullObject(o, rule("exact", value)) }
+	}
+	if resp := validate(NewStreamRuleResource().(resource.ResourceWithValidateConfig), standalone(tftypes.NewValue(tftypes.String, nil))); !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for an exact rule without value")
+	}
+	if resp := validate(NewStreamRuleResource().(resource.ResourceWithValidateConfig), standalone(tftypes.NewValue(tftypes.String, tftypes.UnknownValue))); resp.Diagnostics.HasError() {
+		t.Fatalf("unknown value must be skipped: %v", resp.Diagnostics)
+	}
+
+	inStream := func(value tftypes.Value) func(tftypes.Object) tftypes.Value {
+		return func(o tftypes.Object) tftypes.Value {
+			list := o.AttributeTypes["rule"].(tftypes.List)
+			return nullObject(o, map[string]tftypes.Value{
+				"title": tftypes.NewValue(tftypes.String, "s"),
+				"rule":  tftypes.NewValue(list, []tftypes.Value{nullObject(list.ElementType.(tftypes.Object), rule("greater", value))}),
+			})
+		}
+	}
+	resp := validate(NewStreamResource().(resource.ResourceWithValidateConfig), inStream(tftypes.NewValue(tftypes.String, "abc")))
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected one warning for a non-numeric greater rule, got %v", resp.Diagnostics)
+	}
+	if resp := validate(NewStreamResource().(resource.ResourceWithValidateConfig), inStream(tftypes.NewValue(tftypes.String, nil))); !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for a greater rule without value")
+	}
+}
+
+func TestFillStreamRuleMatch(t *testing.T) {
+	typ, match := types.Int64Unknown(), types.StringValue("regex")
+	fillStreamRuleMatch(&typ, &match)
+	if typ.ValueInt64() != 4 {
+		t.Fatalf("expected type 4 for regex, got %v", typ)
+	}
+
+	typ, match = types.Int64Value(5), types.StringUnknown()
+	fillStreamRuleMatch(&typ, &match)
+	if match.ValueString() != "field_presence" {
+		t.Fatalf("expected field_presence for type 5, got %v", match)
+	}
+
+	typ, match = types.Int64Value(42), types.StringUnknown()
+	fillStreamRuleMatch(&typ, &match)
+	if !match.IsNull() {
+		t.Fatalf("expected null match for unknown type, got %v", match)
+	}
+}
+
+func TestValidateStreamRuleMatch(t *testing.T) {
+	cases := []struct {
+		name    string
+		field   string
+		typ     types.Int64
+		match   types.String
+		value   types.String
+		wantErr bool
+		wantWrn bool
+	}{
+		{name: "exact ok", field: "source", typ: types.Int64Null(), match: types.StringValue("exact"), value: types.StringValue("web")},
+		{name: "exact without value", field: "source", typ: types.Int64Null(), match: types.StringValue("exact"), value: types.StringNull(), wantErr: true},
+		{name: "presence ok", field: "source", typ: types.Int64Null(), match: types.StringValue("field_presence"), value: types.StringNull()},
+		{name: "presence with value", field: "source", typ: types.Int64Null(), match: types.StringValue("field_presence"), value: types.StringValue("x"), wantErr: true},
+		{name: "always_match without field", field: "", typ: types.Int64Null(), match: types.StringValue("always_match"), value: types.StringNull()},
+		{name: "greater not a number", field: "took_ms", typ: types.Int64Null(), match: types.StringValue("greater"), value: types.StringValue("slow"), wantWrn: true},
+		{name: "smaller via type", field: "took_ms", typ: types.Int64Value(3), match: types.StringNull(), value: types.StringValue("100")},
+		{name: "conflicting type", field: "source", typ: types.Int64Value(1), match: types.StringValue("regex"), value: types.StringValue("a"), wantErr: true},
+		{name: "neither set", field: "source", typ: types.Int64Null(), match: types.StringNull(), value: types.StringValue("a"), wantErr: true},
+		{name: "broken regex", field: "message", typ: types.Int64Null(), match: types.StringValue("regex"), value: types.StringValue("(timeout"), wantErr: true},
+		{name: "java-only regex", field: "message", typ: types.Int64Null(), match: types.StringValue("regex"), value: types.StringValue("foo(?=bar)"), wantWrn: true},
+		{name: "regex flag differs", field: "message", typ: types.Int64Null(), match: types.StringValue("regex"), value: types.StringValue("(?U)a+"), wantWrn: true},
+		{name: "posix class", field: "message", typ: types.Int64Null(), match: types.StringValue("regex"), value: types.StringValue("[[:digit:]]+"), wantWrn: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := validateStreamRuleMatch(path.Empty(), types.StringValue(tc.field), tc.typ, tc.match, tc.value)
+			if d.HasError() != tc.wantErr {
+				t.Fatalf("HasError = %v, want %v: %v", d.HasError(), tc.wantErr, d)
+			}
+			if tc.wantWrn && d.WarningsCount() == 0 {
+				t.Fatalf("expected a warning: %v", d)
+			}
+		})
+	}
+}
+
+func TestSameStreamRule_IgnoresDescription(t *testing.T) {
+	a := &client.StreamRule{Field: "source", Type: 1, Value: "web", Description: "copied"}
+	b := &client.StreamRule{Field: "source", Type: 1, Value: "web"}
+	if !sameStreamRule(a, b) {
+		t.Fatal("rules differing only in description should be the same")
+	}
+	b.Inverted = true
+	if sameStreamRule(a, b) {
+		t.Fatal("inverted rule must not be the same")
+	}
+}
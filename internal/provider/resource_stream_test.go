@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -164,3 +165,42 @@ func TestStreamResource_UpgradeState(t *testing.T) {
 		})
 	}
 }
+
+func TestCloneReplaces(t *testing.T) {
+	state := &streamModel{IndexSetID: types.StringValue("is1")}
+	plan := &streamModel{IndexSetID: types.StringValue("is2")}
+	if cloneReplaces(state, plan) {
+		t.Fatal("expected in-place update without clone_on_index_set_change")
+	}
+	plan.CloneOnIndexSetChange = types.BoolValue(true)
+	if !cloneReplaces(state, plan) {
+		t.Fatal("expected clone replace when index set changes")
+	}
+	plan.IndexSetID = types.StringValue("is1")
+	if cloneReplaces(state, plan) {
+		t.Fatal("expected no clone replace when index set is unchanged")
+	}
+}
+
+func TestRenameStreamPerms(t *testing.T) {
+	perms, changed := renameStreamPerms([]string{"streams:read:old", "streams:edit:old", "streams:read:other", "dashboards:read:old"}, "old", "new")
+	if !changed {
+		t.Fatal("expected permissions to change")
+	}
+	want := []string{"streams:read:new", "streams:edit:new", "streams:read:other", "dashboards:read:old"}
+	for i := range want {
+		if perms[i] != want[i] {
+			t.Fatalf("got %v, want %v", perms, want)
+		}
+	}
+	if _, changed := renameStreamPerms([]string{"streams:read:other"}, "old", "new"); changed {
+		t.Fatal("unrelated permissions must not change")
+	}
+}
+
+func TestFiltersStream(t *testing.T) {
+	cfg := map[string]interface{}{"type": "aggregation-v1", "streams": []interface{}{"a", "b"}}
+	if !filtersStream(cfg, "b") || filtersStream(cfg, "c") || filtersStream(nil, "a") {
+		t.Fatal("unexpected filtersStream result")
+	}
+}